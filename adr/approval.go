@@ -0,0 +1,288 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The verdicts of a sign-off.
+const (
+	VerdictApproved = "Approved"
+	VerdictObjected = "Objected"
+)
+
+// The sources of the approvals that count towards a quorum.
+const (
+	// QuorumFromDeciders only counts the approvals of the deciders of the
+	// record.
+	QuorumFromDeciders = "deciders"
+	// QuorumFromAnyone counts the approvals of anyone.
+	QuorumFromAnyone = "anyone"
+)
+
+const (
+	signOffStart = "<!-- docula:sign-offs -->"
+	signOffEnd   = "<!-- /docula:sign-offs -->"
+)
+
+var (
+	// ErrInvalidQuorum is returned when the quorum of a directory cannot be
+	// met by any record, or names an unknown source of approvals.
+	ErrInvalidQuorum = errors.New("invalid quorum")
+
+	// ErrQuorumNotMet is returned when a record is accepted before the
+	// quorum of its directory is met.
+	ErrQuorumNotMet = errors.New("quorum not met")
+)
+
+var (
+	signOffEntry = regexp.MustCompile(`^- (\d{4}-\d{2}-\d{2}): ((?i:approved|objected)) by (.+?)(?:: (.+))?$`)
+	personEmail  = regexp.MustCompile(`^(.*?)\s*<([^<>]+)>$`)
+)
+
+// SignOff represents an approval of, or an objection to, the decision of a
+// record.
+type SignOff struct {
+	Date    time.Time
+	Verdict string
+	// By is the person signing off, such as "Jane Doe <jane@example.com>".
+	By string
+	// Note optionally explains the verdict, such as the reason of an
+	// objection.
+	Note string
+}
+
+func (s SignOff) String() string {
+	entry := fmt.Sprintf("- %s: %s by %s", s.Date.Format(DateFormat), s.Verdict, s.By)
+
+	if s.Note != "" {
+		entry = fmt.Sprintf("%s: %s", entry, s.Note)
+	}
+
+	return entry
+}
+
+func parseSignOff(line string) (SignOff, bool) {
+	match := signOffEntry.FindStringSubmatch(line)
+	if match == nil {
+		return SignOff{}, false
+	}
+
+	date, err := time.Parse(DateFormat, match[1])
+	if err != nil {
+		return SignOff{}, false
+	}
+
+	verdict := VerdictApproved
+	if strings.EqualFold(match[2], VerdictObjected) {
+		verdict = VerdictObjected
+	}
+
+	return SignOff{Date: date, Verdict: verdict, By: match[3], Note: match[4]}, true
+}
+
+// Quorum describes the sign-offs a record of a directory needs before it can
+// be accepted, such as two approvals of its deciders and no open objections.
+type Quorum struct {
+	// Approvals is the number of approvals that are needed.
+	Approvals int `yaml:"approvals"`
+	// From is the source of the approvals that count, either deciders or
+	// anyone. It defaults to deciders.
+	From string `yaml:"from,omitempty"`
+	// AllowObjections lets records be accepted despite open objections.
+	AllowObjections bool `yaml:"allow-objections,omitempty"`
+}
+
+// Validate checks that the quorum can be met.
+func (q Quorum) Validate() error {
+	if q.Approvals < 1 {
+		return fmt.Errorf("%w: approvals must be at least 1", ErrInvalidQuorum)
+	}
+
+	switch q.From {
+	case "", QuorumFromDeciders, QuorumFromAnyone:
+	default:
+		return fmt.Errorf("%w: unknown source of approvals %q", ErrInvalidQuorum, q.From)
+	}
+
+	return nil
+}
+
+// Tally represents the standing of the sign-offs of a record against the
+// quorum of its directory. Only the latest verdict of each person counts, so
+// an objection is withdrawn by approving.
+type Tally struct {
+	// Required is the number of approvals the quorum needs.
+	Required int
+	// Approvers holds the people whose approvals count towards the quorum.
+	Approvers []string
+	// Waiting holds the deciders that have not approved.
+	Waiting []string
+	// Objections holds the objections that are open.
+	Objections []SignOff
+	// Met reports whether the record can be accepted.
+	Met bool
+}
+
+// Shortfall describes why the quorum is not met.
+func (t Tally) Shortfall() string {
+	var reasons []string
+
+	if len(t.Approvers) < t.Required {
+		reasons = append(reasons, fmt.Sprintf("%d of %d approvals", len(t.Approvers), t.Required))
+	}
+
+	if !t.Met {
+		for _, o := range t.Objections {
+			reasons = append(reasons, fmt.Sprintf("open objection by %s", o.By))
+		}
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+// SamePerson reports whether a decider, such as @jane, Jane Doe or
+// jane@example.com, refers to the person signing off. The name and email of
+// the person are compared without regard to case or a leading @, and a
+// handle also matches the user part of the email.
+func SamePerson(decider string, by string) bool {
+	normalize := func(v string) string {
+		return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), "@"))
+	}
+
+	split := func(v string) (string, string) {
+		if match := personEmail.FindStringSubmatch(strings.TrimSpace(v)); match != nil {
+			return normalize(match[1]), normalize(match[2])
+		}
+
+		if strings.Contains(v, "@") && !strings.HasPrefix(strings.TrimSpace(v), "@") {
+			return "", normalize(v)
+		}
+
+		return normalize(v), ""
+	}
+
+	name, email := split(by)
+	dName, dEmail := split(decider)
+
+	switch {
+	case dEmail != "":
+		return dEmail == email
+	case dName == "":
+		return false
+	case dName == name:
+		return true
+	}
+
+	user, _, _ := strings.Cut(email, "@")
+
+	return dName == user
+}
+
+// Tally counts the sign-offs of the record against the quorum of the
+// directory. Without a quorum, no approvals are required.
+func (d Directory) Tally(rec Record) Tally {
+	var q Quorum
+	if d.Quorum != nil {
+		q = *d.Quorum
+	}
+
+	latest := map[string]SignOff{}
+
+	var order []string
+
+	for _, s := range rec.SignOffs {
+		key := strings.ToLower(strings.TrimSpace(s.By))
+		if _, ok := latest[key]; !ok {
+			order = append(order, key)
+		}
+
+		latest[key] = s
+	}
+
+	t := Tally{Required: q.Approvals}
+
+	var approvers []string
+
+	for _, key := range order {
+		switch s := latest[key]; s.Verdict {
+		case VerdictApproved:
+			approvers = append(approvers, s.By)
+		case VerdictObjected:
+			t.Objections = append(t.Objections, s)
+		}
+	}
+
+	isDecider := func(by string) bool {
+		for _, decider := range rec.Deciders {
+			if SamePerson(decider, by) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, by := range approvers {
+		if q.From == QuorumFromAnyone || isDecider(by) {
+			t.Approvers = append(t.Approvers, by)
+		}
+	}
+
+	for _, decider := range rec.Deciders {
+		approved := false
+
+		for _, by := range approvers {
+			approved = approved || SamePerson(decider, by)
+		}
+
+		if !approved {
+			t.Waiting = append(t.Waiting, decider)
+		}
+	}
+
+	t.Met = len(t.Approvers) >= t.Required && (q.AllowObjections || len(t.Objections) == 0)
+
+	return t
+}
+
+// AddSignOff appends the sign-off to the sign-offs of the record markdown.
+// Records with a frontmatter have the sign-off written into the frontmatter.
+// Otherwise it is written into a block that follows the status history, or
+// the status of the record if it has no history.
+func AddSignOff(data []byte, s SignOff) ([]byte, error) {
+	if _, _, ok := splitFrontmatter(data); ok {
+		return editFrontmatter(data, func(mapping *yaml.Node) {
+			signOffs := sequence(mapping, "sign-offs")
+			signOffs.Content = append(signOffs.Content, signOffEntryNode(s))
+		})
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	if start, end := findBlock(lines, signOffStart, signOffEnd); start >= 0 {
+		return join(insert(lines, end, s.String())), nil
+	}
+
+	block := []string{signOffStart, s.String(), signOffEnd}
+
+	if _, end := findHistory(lines); end >= 0 {
+		return join(insert(lines, end+1, append([]string{""}, block...)...)), nil
+	}
+
+	statusLine := findStatusLine(lines)
+	if statusLine < 0 {
+		return nil, ErrNoStatus
+	}
+
+	return join(addBlock(lines, statusLine, block)), nil
+}
+
+func signOffEntryNode(s SignOff) *yaml.Node {
+	return entry("date", s.Date.Format(DateFormat), "verdict", s.Verdict, "by", s.By, "note", s.Note)
+}
@@ -0,0 +1,200 @@
+package adr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestSamePerson(t *testing.T) {
+	testCases := []struct {
+		decider string
+		by      string
+		wants   bool
+	}{
+		{decider: "Jane Doe", by: "jane doe <jane@example.com>", wants: true},
+		{decider: "@jane", by: "Jane Doe <jane@example.com>", wants: true},
+		{decider: "JANE@example.com", by: "Jane Doe <jane@example.com>", wants: true},
+		{decider: "Jane <jane@example.com>", by: "J. Doe <jane@example.com>", wants: true},
+		{decider: "@jane", by: "jane", wants: true},
+		{decider: "@john", by: "Jane Doe <jane@example.com>"},
+		{decider: "jane@other.com", by: "Jane Doe <jane@example.com>"},
+		{decider: "@org/platform", by: "Jane Doe <jane@example.com>"},
+		{decider: "", by: "Jane Doe"},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.decider+" is "+tt.by, func(t *testing.T) {
+			assert.Equal(t, tt.wants, adr.SamePerson(tt.decider, tt.by))
+		})
+	}
+}
+
+func TestTally(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	signOff := func(verdict string, by string) adr.SignOff {
+		return adr.SignOff{Date: date, Verdict: verdict, By: by}
+	}
+
+	rec := adr.Record{
+		Deciders: []string{"@jane", "@john", "Mallory"},
+		SignOffs: []adr.SignOff{
+			signOff(adr.VerdictObjected, "Jane Doe <jane@example.com>"),
+			signOff(adr.VerdictApproved, "Bob <bob@example.com>"),
+			signOff(adr.VerdictApproved, "Jane Doe <jane@example.com>"),
+			signOff(adr.VerdictObjected, "Mallory <m@example.com>"),
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		quorum    *adr.Quorum
+		wants     adr.Tally
+		shortfall string
+	}{
+		{
+			name: "no quorum",
+			wants: adr.Tally{
+				Approvers:  []string{"Jane Doe <jane@example.com>"},
+				Waiting:    []string{"@john", "Mallory"},
+				Objections: []adr.SignOff{signOff(adr.VerdictObjected, "Mallory <m@example.com>")},
+			},
+			shortfall: "open objection by Mallory <m@example.com>",
+		},
+		{
+			name:   "deciders with open objections",
+			quorum: &adr.Quorum{Approvals: 2},
+			wants: adr.Tally{
+				Required:   2,
+				Approvers:  []string{"Jane Doe <jane@example.com>"},
+				Waiting:    []string{"@john", "Mallory"},
+				Objections: []adr.SignOff{signOff(adr.VerdictObjected, "Mallory <m@example.com>")},
+			},
+			shortfall: "1 of 2 approvals, open objection by Mallory <m@example.com>",
+		},
+		{
+			name:   "anyone despite objections",
+			quorum: &adr.Quorum{Approvals: 2, From: adr.QuorumFromAnyone, AllowObjections: true},
+			wants: adr.Tally{
+				Required:   2,
+				Approvers:  []string{"Jane Doe <jane@example.com>", "Bob <bob@example.com>"},
+				Waiting:    []string{"@john", "Mallory"},
+				Objections: []adr.SignOff{signOff(adr.VerdictObjected, "Mallory <m@example.com>")},
+				Met:        true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			tally := adr.Directory{Quorum: tt.quorum}.Tally(rec)
+
+			assert.Equal(t, tt.wants, tally)
+			assert.Equal(t, tt.shortfall, tally.Shortfall())
+		})
+	}
+}
+
+func TestQuorumValidate(t *testing.T) {
+	assert.NoError(t, adr.Quorum{Approvals: 1}.Validate())
+	assert.NoError(t, adr.Quorum{Approvals: 2, From: adr.QuorumFromAnyone}.Validate())
+	assert.ErrorIs(t, adr.Quorum{}.Validate(), adr.ErrInvalidQuorum)
+	assert.ErrorIs(t, adr.Quorum{Approvals: 1, From: "owners"}.Validate(), adr.ErrInvalidQuorum)
+}
+
+func TestAddSignOff(t *testing.T) {
+	approval := adr.SignOff{
+		Date:    time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		Verdict: adr.VerdictApproved,
+		By:      "Jane Doe <jane@example.com>",
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		wants string
+		err   error
+	}{
+		{
+			name:  "status section",
+			input: "# Use PostgreSQL\n\n## Status\n\nProposed\n\n## Context\n",
+			wants: "# Use PostgreSQL\n\n## Status\n\nProposed\n\n" +
+				"<!-- docula:sign-offs -->\n" +
+				"- 2024-03-02: Approved by Jane Doe <jane@example.com>\n" +
+				"<!-- /docula:sign-offs -->\n\n## Context\n",
+		},
+		{
+			name: "after the status history",
+			input: "# Use PostgreSQL\n\n## Status\n\nProposed\n\n" +
+				"<!-- docula:status-history -->\n- 2024-03-01: Proposed\n<!-- /docula:status-history -->\n\n## Context\n",
+			wants: "# Use PostgreSQL\n\n## Status\n\nProposed\n\n" +
+				"<!-- docula:status-history -->\n- 2024-03-01: Proposed\n<!-- /docula:status-history -->\n\n" +
+				"<!-- docula:sign-offs -->\n" +
+				"- 2024-03-02: Approved by Jane Doe <jane@example.com>\n" +
+				"<!-- /docula:sign-offs -->\n\n## Context\n",
+		},
+		{
+			name: "existing sign-offs",
+			input: "# Use PostgreSQL\n\n* Status: proposed\n\n" +
+				"<!-- docula:sign-offs -->\n" +
+				"- 2024-03-01: Objected by Bob: too costly\n" +
+				"<!-- /docula:sign-offs -->\n\n## Context\n",
+			wants: "# Use PostgreSQL\n\n* Status: proposed\n\n" +
+				"<!-- docula:sign-offs -->\n" +
+				"- 2024-03-01: Objected by Bob: too costly\n" +
+				"- 2024-03-02: Approved by Jane Doe <jane@example.com>\n" +
+				"<!-- /docula:sign-offs -->\n\n## Context\n",
+		},
+		{
+			name:  "inline status field",
+			input: "# Use PostgreSQL\n\n* Status: proposed\n\n## Context\n",
+			wants: "# Use PostgreSQL\n\n* Status: proposed\n\n" +
+				"<!-- docula:sign-offs -->\n" +
+				"- 2024-03-02: Approved by Jane Doe <jane@example.com>\n" +
+				"<!-- /docula:sign-offs -->\n\n## Context\n",
+		},
+		{
+			name:  "frontmatter",
+			input: "---\nstatus: Proposed\n---\n\n# Use PostgreSQL\n",
+			wants: "---\nstatus: Proposed\nsign-offs:\n" +
+				"  - date: \"2024-03-02\"\n" +
+				"    verdict: Approved\n" +
+				"    by: Jane Doe <jane@example.com>\n" +
+				"---\n\n# Use PostgreSQL\n",
+		},
+		{
+			name:  "no status",
+			input: "# Use PostgreSQL\n\n## Context\n",
+			err:   adr.ErrNoStatus,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := adr.AddSignOff([]byte(tt.input), approval)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, string(res))
+
+			rec, err := adr.ParseRecord("0001-use-postgresql.md", res)
+			assert.NoError(t, err)
+			assert.Equal(t, "Use PostgreSQL", rec.Title)
+			assert.Equal(t, approval, rec.SignOffs[len(rec.SignOffs)-1])
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package adr
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The info strings that mark the fenced code blocks holding the rules of a
+// record written in the inline style. Records with a frontmatter hold the
+// rules under the keys of the same name instead.
+const (
+	importsFence = "docula:imports"
+	modulesFence = "docula:modules"
+)
+
+// blockFence returns the kind of rules held by the fenced code block that
+// the line opens, if any.
+func blockFence(line string) (string, bool) {
+	if !strings.HasPrefix(line, "```") {
+		return "", false
+	}
+
+	for _, fence := range []string{importsFence, modulesFence} {
+		if strings.Contains(line, fence) {
+			return fence, true
+		}
+	}
+
+	return "", false
+}
+
+// parseBlock reads the rules of a fenced code block into the record.
+func parseBlock(rec *Record, fence string, block []string) error {
+	data := []byte(strings.Join(block, "\n"))
+
+	switch fence {
+	case importsFence:
+		var rules []ImportRule
+
+		if err := yaml.Unmarshal(data, &rules); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidImportRule, err)
+		}
+
+		rec.Imports = append(rec.Imports, rules...)
+	case modulesFence:
+		var policies []ModulePolicy
+
+		if err := yaml.Unmarshal(data, &policies); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidModulePolicy, err)
+		}
+
+		rec.Modules = append(rec.Modules, policies...)
+	}
+
+	return nil
+}
+
+// renderBlocks renders the fenced code blocks holding the rules of the
+// record, separated by blank lines.
+func renderBlocks(rec Record) ([]string, error) {
+	var lines []string
+
+	add := func(fence string, value interface{}) error {
+		data, err := encodeYAML(value)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, "", "```yaml "+fence)
+		lines = append(lines, strings.Split(strings.TrimRight(string(data), "\n"), "\n")...)
+		lines = append(lines, "```")
+
+		return nil
+	}
+
+	if len(rec.Imports) > 0 {
+		if err := add(importsFence, rec.Imports); err != nil {
+			return nil, fmt.Errorf("marshal import rules: %w", err)
+		}
+	}
+
+	if len(rec.Modules) > 0 {
+		if err := add(modulesFence, rec.Modules); err != nil {
+			return nil, fmt.Errorf("marshal module policies: %w", err)
+		}
+	}
+
+	return lines, nil
+}
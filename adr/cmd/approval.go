@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/approval"
+)
+
+type approvalHandler func(ctx context.Context, out io.Writer, opts approval.Options) error
+
+// signOffCmd produces a command that signs off on a record with the given
+// verdict.
+func signOffCmd(use string, verdict string, short string, handler approvalHandler) *cobra.Command {
+	opts := approval.Options{Verdict: verdict}
+
+	signOffCmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s <id>", use),
+		Short: short,
+		Long: short + " The sign-off is added to the metadata of the record, " +
+			"signed by the name and email of the git user. A record can only " +
+			"be accepted once the quorum of its directory is met, and only " +
+			"the latest sign-off of each person counts, so an objection is " +
+			"withdrawn by approving.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ID = args[0]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("%s handler: %w", use, err)
+			}
+
+			return nil
+		},
+	}
+
+	signOffCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	signOffCmd.Flags().StringVarP(&opts.Note, "note", "m", "", "note explaining the verdict")
+
+	return signOffCmd
+}
+
+func approvalCmds(handler approvalHandler) []*cobra.Command {
+	return []*cobra.Command{
+		signOffCmd("approve", adr.VerdictApproved, "Approves a proposed decision record.", handler),
+		signOffCmd("object", adr.VerdictObjected, "Objects to a proposed decision record.", handler),
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/approval"
+)
+
+func TestApprovalCmds(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  bool
+		opts approval.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "approve",
+			args: []string{"approve", "12"},
+			wants: want{
+				opts: approval.Options{ID: "12", Verdict: "Approved"},
+			},
+		},
+		{
+			name: "object with flags",
+			args: []string{"object", "12", "--dir", "security", "-m", "too costly"},
+			wants: want{
+				opts: approval.Options{ID: "12", Dir: "security", Verdict: "Objected", Note: "too costly"},
+			},
+		},
+		{
+			name: "missing id",
+			args: []string{"approve"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{"object", "1"},
+			wants: want{
+				err:  true,
+				opts: approval.Options{ID: "1", Verdict: "Objected"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts approval.Options
+
+			h := func(ctx context.Context, out io.Writer, o approval.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			root := RootCmd()
+			root.ResetCommands()
+			root.AddCommand(approvalCmds(h)...)
+
+			root.SetArgs(tt.args)
+
+			err := root.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
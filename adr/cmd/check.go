@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/check"
+)
+
+type checkHandler func(ctx context.Context, out io.Writer, opts check.Options) error
+
+func checkCmd(handler checkHandler) *cobra.Command {
+	var opts check.Options
+
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Enforces the import rules declared by accepted records.",
+		Long: "Loads the Go packages of every module within the project, and " +
+			"reports each import that is denied by the import rules of an " +
+			"accepted record, citing the record. Rules are declared under " +
+			"imports in the frontmatter of a record, or in a fenced yaml " +
+			"block marked docula:imports, such as:\n\n" +
+			"  - from: internal/billing/...\n" +
+			"    deny: [internal/auth/...]\n\n" +
+			"Packages are import paths, or paths relative to their module, " +
+			"and may use the ... wildcard. Test files are checked too. The " +
+			"rules of records that are not accepted, such as superseded ones, " +
+			"are not enforced. The command fails if any rule is violated.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("check handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	checkCmd.Flags().StringVar(&opts.Dir, "dir", "", "only enforce the rules of this ADR directory, by name or path")
+
+	return checkCmd
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/deps"
+)
+
+type checkDepsHandler func(ctx context.Context, out io.Writer, opts deps.Options) error
+
+func checkDepsCmd(handler checkDepsHandler) *cobra.Command {
+	var opts deps.Options
+
+	checkDepsCmd := &cobra.Command{
+		Use:   "check-deps",
+		Short: "Enforces the module policies declared by accepted records.",
+		Long: "Reads every go.mod file within the project, nested modules " +
+			"included, and reports each required module that violates the " +
+			"module policies of an accepted record, citing the record. " +
+			"Policies are declared under modules in the frontmatter of a " +
+			"record, or in a fenced yaml block marked docula:modules, such as:\n\n" +
+			"  - module: github.com/jackc/pgx/v5\n" +
+			"    policy: preferred\n" +
+			"    version: \">= v5.4\"\n" +
+			"    over: [github.com/lib/pq]\n" +
+			"  - module: gopkg.in/yaml.v2\n" +
+			"    policy: banned\n" +
+			"    instead: gopkg.in/yaml.v3\n\n" +
+			"A policy is allowed, banned or preferred. Modules may use the ... " +
+			"wildcard, and versions are comma separated constraints such as " +
+			"\">= v1.2, < v2\". A banned module is an error within its " +
+			"versions, an allowed or preferred module is an error outside of " +
+			"them, and the modules a preferred one is chosen over are " +
+			"warnings. The policies of records that are not accepted, such " +
+			"as superseded ones, are not enforced. The command fails if any " +
+			"error is found.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("check-deps handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	checkDepsCmd.Flags().StringVar(&opts.Dir, "dir", "", "only enforce the policies of this ADR directory, by name or path")
+
+	return checkDepsCmd
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/deps"
+)
+
+func TestCheckDepsCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts deps.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "every dir",
+			args: []string{},
+		},
+		{
+			name: "single dir",
+			args: []string{"--dir", "platform"},
+			wants: want{
+				opts: deps.Options{Dir: "platform"},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"platform"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "rules violated",
+			handlerRet: deps.ErrViolations,
+			args:       []string{},
+			wants: want{
+				err: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts deps.Options
+
+			h := func(ctx context.Context, out io.Writer, o deps.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := checkDepsCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/codeowners"
+)
+
+type codeownersHandler func(ctx context.Context, out io.Writer, opts codeowners.Options) error
+
+func codeownersCmd(handler codeownersHandler) *cobra.Command {
+	var opts codeowners.Options
+
+	codeownersCmd := &cobra.Command{
+		Use:   "codeowners",
+		Short: "Generates CODEOWNERS entries from the deciders of records.",
+		Long: "Generates CODEOWNERS entries so that changes to an ADR " +
+			"directory request review from the deciders of its accepted " +
+			"records, and changes to a record request review from its own " +
+			"deciders. Deciders that are not a @user, an @org/team or an email " +
+			"address are skipped and noted in a comment. The entries are " +
+			"printed, or kept up to date between docula markers within the " +
+			"file given by --write, such as .github/CODEOWNERS, leaving the " +
+			"other entries of the file untouched. Each directory has markers " +
+			"of its own, so that --dir only updates the entries of that " +
+			"directory.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("codeowners handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := codeownersCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVar(&opts.Write, "write", "", "path of the CODEOWNERS file to keep the entries up to date in")
+
+	return codeownersCmd
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/codeowners"
+)
+
+func TestCodeownersCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts codeowners.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "print",
+			args: []string{},
+		},
+		{
+			name: "write for a single dir",
+			args: []string{"--dir", "platform", "--write", ".github/CODEOWNERS"},
+			wants: want{
+				opts: codeowners.Options{Dir: "platform", Write: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"CODEOWNERS"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unbalanced markers",
+			handlerRet: adr.ErrUnbalancedMarkers,
+			args:       []string{"--write", "CODEOWNERS"},
+			wants: want{
+				err:  true,
+				opts: codeowners.Options{Write: "CODEOWNERS"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts codeowners.Options
+
+			h := func(ctx context.Context, out io.Writer, o codeowners.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := codeownersCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
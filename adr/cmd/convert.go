@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/convert"
+)
+
+type convertHandler func(ctx context.Context, out io.Writer, opts convert.Options) error
+
+func convertCmd(handler convertHandler) *cobra.Command {
+	var opts convert.Options
+
+	convertCmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Converts decision records to another metadata style.",
+		Long: "Converts the decision records of every ADR directory, or of the " +
+			"directory selected with the --dir flag, to the metadata style " +
+			"given with the --to flag. Inline records hold their metadata as " +
+			"fields below the title and a status section, while frontmatter " +
+			"records hold it in a YAML block at the top of the file. Either " +
+			"every record is converted or none are.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("convert handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	convertCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	convertCmd.Flags().StringVar(&opts.To, "to", "", "metadata style to convert to: inline or frontmatter")
+
+	_ = convertCmd.MarkFlagRequired("to")
+
+	return convertCmd
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/convert"
+)
+
+func TestConvertCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts convert.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "to frontmatter",
+			args: []string{"--to", "frontmatter"},
+			wants: want{
+				opts: convert.Options{To: adr.StyleFrontmatter},
+			},
+		},
+		{
+			name: "single dir to inline",
+			args: []string{"--dir", "security", "--to", "inline"},
+			wants: want{
+				opts: convert.Options{Dir: "security", To: adr.StyleInline},
+			},
+		},
+		{
+			name: "missing style",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name: "unexpected args",
+			args: []string{"--to", "inline", "foo"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown style",
+			handlerRet: adr.ErrUnknownStyle,
+			args:       []string{"--to", "toml"},
+			wants: want{
+				err:  true,
+				opts: convert.Options{To: "toml"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts convert.Options
+
+			h := func(ctx context.Context, out io.Writer, o convert.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := convertCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/due"
+)
+
+type dueHandler func(ctx context.Context, out io.Writer, opts due.Options) error
+
+func dueCmd(handler dueHandler) *cobra.Command {
+	var opts due.Options
+
+	dueCmd := &cobra.Command{
+		Use:   "due",
+		Short: "Lists the decisions due for review and the stale proposals.",
+		Long: "Lists the accepted records whose review is overdue or falls " +
+			"within the window of --within, along with the records that have " +
+			"been waiting in a status that is neither accepted nor terminal " +
+			"for longer than --stale. A record is due for review on its " +
+			"review-by date, or once the review interval of its directory, " +
+			"such as review: 1y in the state file, has passed since it was " +
+			"accepted. Intervals are a number of days, weeks, months or " +
+			"years, such as 30d, 2w, 6m or 1y. With -o ics, every review date " +
+			"is written as an iCalendar event instead, ready to be imported " +
+			"into a calendar.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("due handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := dueCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVar(&opts.Within, "within", "30d", "list the reviews due within this interval")
+	flags.StringVar(&opts.Stale, "stale", "90d", "list the proposals that have not moved on for this interval")
+	flags.StringVarP(&opts.Format, "output", "o", due.FormatText, "output format: text, json or ics")
+
+	return dueCmd
+}
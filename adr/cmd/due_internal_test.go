@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/due"
+)
+
+func TestDueCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts due.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: due.Options{Within: "30d", Stale: "90d", Format: due.FormatText},
+			},
+		},
+		{
+			name: "calendar of a single dir",
+			args: []string{"--dir", "security", "--within", "1y", "--stale", "", "-o", "ics"},
+			wants: want{
+				opts: due.Options{Dir: "security", Within: "1y", Format: due.FormatICS},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"0007"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown format",
+			handlerRet: due.ErrUnknownFormat,
+			args:       []string{"-o", "xml"},
+			wants: want{
+				err:  true,
+				opts: due.Options{Within: "30d", Stale: "90d", Format: "xml"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts due.Options
+
+			h := func(ctx context.Context, out io.Writer, o due.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := dueCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/graph"
+)
+
+type graphHandler func(ctx context.Context, out io.Writer, opts graph.Options) error
+
+func graphCmd(handler graphHandler) *cobra.Command {
+	var opts graph.Options
+
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Exports the links between decision records as a graph.",
+		Long: "Exports the supersedes, amends and relates to links between the " +
+			"decision records of every ADR directory, or of the directory " +
+			"selected with the --dir flag. The graph is written as Graphviz " +
+			"DOT, a Mermaid flowchart or a JSON list of nodes and edges, with " +
+			"records grouped by directory and coloured by status.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("graph handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	graphCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	graphCmd.Flags().StringVarP(&opts.Format, "output", "o", graph.FormatDOT, "output format: dot, mermaid or json")
+
+	return graphCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/graph"
+)
+
+func TestGraphCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts graph.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: graph.Options{Format: graph.FormatDOT},
+			},
+		},
+		{
+			name: "mermaid of a single dir",
+			args: []string{"--dir", "security", "-o", "mermaid"},
+			wants: want{
+				opts: graph.Options{Dir: "security", Format: graph.FormatMermaid},
+			},
+		},
+		{
+			name: "unexpected args",
+			args: []string{"foo"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: graph.ErrUnknownFormat,
+			args:       []string{"-o", "svg"},
+			wants: want{
+				err:  true,
+				opts: graph.Options{Format: "svg"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts graph.Options
+
+			h := func(ctx context.Context, out io.Writer, o graph.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := graphCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
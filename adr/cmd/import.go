@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/adrtools"
+)
+
+type adrToolsHandler func(ctx context.Context, out io.Writer, opts adrtools.Options) error
+
+func importCmd(adrTools adrToolsHandler) *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports decision records managed by other tools.",
+		Long:  "Imports decision records managed by other tools as ADR directories.",
+	}
+
+	importCmd.AddCommand(adrToolsCmd(adrTools))
+
+	return importCmd
+}
+
+func adrToolsCmd(handler adrToolsHandler) *cobra.Command {
+	var opts adrtools.Options
+
+	adrToolsCmd := &cobra.Command{
+		Use:   "adr-tools [path]",
+		Short: "Imports a directory managed by adr-tools.",
+		Long: "Registers a directory managed by adr-tools as a sequential ADR " +
+			"directory. The path is the directory holding the .adr-dir file, " +
+			"or the directory of the records if there is no such file, and " +
+			"defaults to the current directory. The records and their links " +
+			"are reported, and can be rewritten into the docula layout with " +
+			"the --rewrite flag.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Path = args[0]
+			}
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("import handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	adrToolsCmd.Flags().StringVar(&opts.Name, "name", "", "name of the ADR directory")
+	adrToolsCmd.Flags().BoolVar(&opts.Rewrite, "rewrite", false, "rewrite the records into the docula layout")
+
+	return adrToolsCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/adrtools"
+)
+
+func TestImportAdrToolsCmd(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  bool
+		opts adrtools.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "current dir",
+			args: []string{"adr-tools"},
+		},
+		{
+			name: "path with flags",
+			args: []string{"adr-tools", "services/billing", "--name", "billing", "--rewrite"},
+			wants: want{
+				opts: adrtools.Options{Path: "services/billing", Name: "billing", Rewrite: true},
+			},
+		},
+		{
+			name: "too many args",
+			args: []string{"adr-tools", "foo", "bar"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{"adr-tools", "foo"},
+			wants: want{
+				err:  true,
+				opts: adrtools.Options{Path: "foo"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts adrtools.Options
+
+			h := func(ctx context.Context, out io.Writer, o adrtools.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := importCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/toc"
+)
+
+type indexHandler func(ctx context.Context, out io.Writer, opts toc.Options) error
+
+func indexCmd(handler indexHandler) *cobra.Command {
+	var opts toc.Options
+
+	indexCmd := &cobra.Command{
+		Use:   "index",
+		Short: "Generates the table of contents of each ADR directory.",
+		Long: "Generates a table of contents of the decision records into the " +
+			"README of each ADR directory, or of the directory selected with " +
+			"the --dir flag. Only the region between the docula:index markers " +
+			"is replaced. With --check, the READMEs are left untouched and the " +
+			"command fails if any of them are out of date.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("index handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	indexCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	indexCmd.Flags().BoolVar(&opts.Check, "check", false, "fail if a README is out of date instead of writing it")
+
+	return indexCmd
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/toc"
+)
+
+func TestIndexCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts toc.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+		},
+		{
+			name: "check a single dir",
+			args: []string{"--dir", "security", "--check"},
+			wants: want{
+				opts: toc.Options{Dir: "security", Check: true},
+			},
+		},
+		{
+			name: "unexpected args",
+			args: []string{"foo"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "stale index",
+			handlerRet: toc.ErrStale,
+			args:       []string{"--check"},
+			wants: want{
+				err:  true,
+				opts: toc.Options{Check: true},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts toc.Options
+
+			h := func(ctx context.Context, out io.Writer, o toc.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := indexCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
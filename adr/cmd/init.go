@@ -3,11 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 )
 
-type initHandler func(ctx context.Context, path string) error
+type initHandler func(ctx context.Context, out io.Writer, path string) error
 
 func initCmd(handler initHandler) *cobra.Command {
 	initCmd := &cobra.Command{
@@ -15,12 +16,16 @@ func initCmd(handler initHandler) *cobra.Command {
 		Short: "Sets up a directory as an ADR directory.",
 		Long: "Sets up a directory as an ADR directory. " +
 			"If the directory does not exist, then this command will create " +
-			"the directory for the user.",
+			"the directory for the user. The template of its records is one " +
+			"of the built-in templates, or a custom template within the " +
+			"project. If the directory already holds " +
+			"records, then their index type and template are suggested, and " +
+			"their metadata can be normalized.",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
-			if err := handler(cmd.Context(), path); err != nil {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), path); err != nil {
 				return fmt.Errorf("init handler: %w", err)
 			}
 
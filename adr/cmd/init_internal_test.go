@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,7 +54,7 @@ func TestInitCmd(t *testing.T) {
 		tt := tt
 
 		t.Run(tt.name, func(t *testing.T) {
-			h := func(ctx context.Context, path string) error {
+			h := func(ctx context.Context, out io.Writer, path string) error {
 				return tt.handlerRet
 			}
 
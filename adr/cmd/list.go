@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/list"
+)
+
+type listHandler func(ctx context.Context, out io.Writer, opts list.Options) error
+
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(adr.DateFormat, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse date %q: %w", value, err)
+	}
+
+	return date, nil
+}
+
+func listCmd(handler listHandler) *cobra.Command {
+	var (
+		opts         list.Options
+		since, until string
+	)
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the decision records.",
+		Long: "Lists the decision records of every ADR directory, or of the " +
+			"directory selected with the --dir flag. Records can be filtered " +
+			"by status, tag and date, and printed as a table, JSON or YAML.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			if opts.Since, err = parseDate(since); err != nil {
+				return err
+			}
+
+			if opts.Until, err = parseDate(until); err != nil {
+				return err
+			}
+
+			if err = handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("list handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := listCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringSliceVar(&opts.Statuses, "status", nil, "only list records with any of these statuses")
+	flags.StringSliceVar(&opts.Tags, "tag", nil, "only list records with any of these tags")
+	flags.StringVar(&since, "since", "", "only list records dated on or after this date (YYYY-MM-DD)")
+	flags.StringVar(&until, "until", "", "only list records dated on or before this date (YYYY-MM-DD)")
+	flags.StringVar(&opts.Sort, "sort", list.SortID, "field to sort by: id, title, status or date")
+	flags.BoolVar(&opts.Reverse, "reverse", false, "reverse the sort order")
+	flags.StringVarP(&opts.Format, "output", "o", list.FormatTable, "output format: table, json, yaml or ids")
+
+	return listCmd
+}
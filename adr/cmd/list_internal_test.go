@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/list"
+)
+
+func TestListCmd(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  bool
+		opts list.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: list.Options{
+					Sort:   list.SortID,
+					Format: list.FormatTable,
+				},
+			},
+		},
+		{
+			name: "with filters",
+			args: []string{
+				"--dir", "security",
+				"--status", "proposed,accepted",
+				"--tag", "db", "--tag", "storage",
+				"--since", "2022-01-01",
+				"--until", "2022-12-31",
+				"--sort", "date",
+				"--reverse",
+				"-o", "json",
+			},
+			wants: want{
+				opts: list.Options{
+					Dir:      "security",
+					Statuses: []string{"proposed", "accepted"},
+					Tags:     []string{"db", "storage"},
+					Since:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+					Until:    time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+					Sort:     list.SortDate,
+					Reverse:  true,
+					Format:   list.FormatJSON,
+				},
+			},
+		},
+		{
+			name: "bad date",
+			args: []string{"--since", "yesterday"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name: "unexpected args",
+			args: []string{"foo"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{},
+			wants: want{
+				err: true,
+				opts: list.Options{
+					Sort:   list.SortID,
+					Format: list.FormatTable,
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts list.Options
+
+			h := func(ctx context.Context, out io.Writer, o list.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := listCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
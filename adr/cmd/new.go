@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/create"
+	"github.com/docula-io/docula/adr/template"
+)
+
+type newHandler func(ctx context.Context, out io.Writer, opts create.Options) error
+
+func newCmd(handler newHandler) *cobra.Command {
+	var opts create.Options
+
+	newCmd := &cobra.Command{
+		Use:   "new <title>",
+		Short: "Creates a new decision record.",
+		Long: "Creates a new decision record in an ADR directory. " +
+			"The directory can be selected by name or path using the --dir " +
+			"flag, otherwise the only configured directory is used. The " +
+			"record is rendered from the template of the directory unless " +
+			"the --template flag names a built-in or custom template. The " +
+			"deciders, consulted and informed people or teams of the decision " +
+			"are given as comma separated lists, or asked for when the " +
+			"--interactive flag is set. Existing records that resemble the " +
+			"new one are reported on stderr before it is written.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Title = strings.Join(args, " ")
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("new handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	newCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	newCmd.Flags().StringVar(&opts.Template, "template", "",
+		fmt.Sprintf("template to use: %s, or the path of a custom template", strings.Join(template.Names(), ", ")),
+	)
+
+	newCmd.Flags().StringSliceVar(&opts.People.Deciders, "deciders", nil, "people or teams that make the decision")
+	newCmd.Flags().StringSliceVar(&opts.People.Consulted, "consulted", nil, "people or teams whose opinions are sought")
+	newCmd.Flags().StringSliceVar(&opts.People.Informed, "informed", nil, "people or teams kept up to date on the decision")
+	newCmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "ask for the people involved in the decision")
+
+	return newCmd
+}
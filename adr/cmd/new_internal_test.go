@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/create"
+)
+
+func TestNewCmd(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		opts create.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "happy path",
+			args: []string{"Use PostgreSQL"},
+			wants: want{
+				opts: create.Options{Title: "Use PostgreSQL"},
+			},
+		},
+		{
+			name: "unquoted title with dir",
+			args: []string{"Use", "PostgreSQL", "--dir", "platform", "--template", "madr"},
+			wants: want{
+				opts: create.Options{Title: "Use PostgreSQL", Dir: "platform", Template: "madr"},
+			},
+		},
+		{
+			name: "people",
+			args: []string{"Use PostgreSQL", "--deciders", "@jane,@john", "--consulted", "@dba", "--informed", "@sre", "-i"},
+			wants: want{
+				opts: create.Options{
+					Title: "Use PostgreSQL",
+					People: create.People{
+						Deciders:  []string{"@jane", "@john"},
+						Consulted: []string{"@dba"},
+						Informed:  []string{"@sre"},
+					},
+					Interactive: true,
+				},
+			},
+		},
+		{
+			name: "bad args",
+			args: []string{},
+			wants: want{
+				err: errors.New(""),
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{"foo"},
+			wants: want{
+				err:  errBoom,
+				opts: create.Options{Title: "foo"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts create.Options
+
+			h := func(ctx context.Context, out io.Writer, o create.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := newCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/pending"
+)
+
+type pendingHandler func(ctx context.Context, out io.Writer, opts pending.Options) error
+
+func pendingCmd(handler pendingHandler) *cobra.Command {
+	var opts pending.Options
+
+	pendingCmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Lists the proposals that are waiting on sign-offs.",
+		Long: "Lists the decision records that are neither accepted nor in a " +
+			"terminal status, along with their approvals against the quorum " +
+			"of their directory, the deciders that have not approved yet and " +
+			"any open objections.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("pending handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := pendingCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVarP(&opts.Format, "output", "o", pending.FormatTable, "output format: table or json")
+
+	return pendingCmd
+}
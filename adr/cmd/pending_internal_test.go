@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/pending"
+)
+
+func TestPendingCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts pending.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: pending.Options{Format: pending.FormatTable},
+			},
+		},
+		{
+			name: "json for a single dir",
+			args: []string{"--dir", "security", "-o", "json"},
+			wants: want{
+				opts: pending.Options{Dir: "security", Format: pending.FormatJSON},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"12"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown format",
+			handlerRet: pending.ErrUnknownFormat,
+			args:       []string{"-o", "xml"},
+			wants: want{
+				err:  true,
+				opts: pending.Options{Format: "xml"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts pending.Options
+
+			h := func(ctx context.Context, out io.Writer, o pending.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := pendingCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
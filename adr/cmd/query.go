@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/list"
+)
+
+func queryCmd(handler listHandler) *cobra.Command {
+	var opts list.Options
+
+	queryCmd := &cobra.Command{
+		Use:   "query <expr>",
+		Short: "Selects decision records with a query expression.",
+		Long: "Selects the decision records matching a query expression, such " +
+			"as \"status = accepted and tag in (db, storage) and date > " +
+			"today - 90d\". Fields are compared with =, !=, <, <=, >, >=, in " +
+			"and contains, and combined with and, or, not and parentheses. " +
+			"Any field that is not built in is read from the custom fields " +
+			"of a record. Dates are written as YYYY-MM-DD or today, and can " +
+			"be shifted by days, weeks, months or years, as in today - 6m. " +
+			"With -o ids, the qualified identifier of each record is printed " +
+			"on its own line, so the selection can be piped into other " +
+			"commands, for example with xargs -n1 adr deprecate.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Query = args[0]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("query handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := queryCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVar(&opts.Sort, "sort", list.SortID, "field to sort by: id, title, status or date")
+	flags.BoolVar(&opts.Reverse, "reverse", false, "reverse the sort order")
+	flags.StringVarP(&opts.Format, "output", "o", list.FormatTable, "output format: table, json, yaml or ids")
+
+	return queryCmd
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/list"
+	"github.com/docula-io/docula/adr/query"
+)
+
+func TestQueryCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts list.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{"status = accepted"},
+			wants: want{
+				opts: list.Options{
+					Query:  "status = accepted",
+					Sort:   list.SortID,
+					Format: list.FormatTable,
+				},
+			},
+		},
+		{
+			name: "ids of a single dir by date",
+			args: []string{"tag in (db, storage)", "--dir", "security", "--sort", "date", "--reverse", "-o", "ids"},
+			wants: want{
+				opts: list.Options{
+					Dir:     "security",
+					Query:   "tag in (db, storage)",
+					Sort:    list.SortDate,
+					Reverse: true,
+					Format:  list.FormatIDs,
+				},
+			},
+		},
+		{
+			name: "missing expression",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "invalid expression",
+			handlerRet: query.ErrSyntax,
+			args:       []string{"status ="},
+			wants: want{
+				err: true,
+				opts: list.Options{
+					Query:  "status =",
+					Sort:   list.SortID,
+					Format: list.FormatTable,
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts list.Options
+
+			h := func(ctx context.Context, out io.Writer, o list.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := queryCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
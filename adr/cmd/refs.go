@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/refs"
+)
+
+type refsHandler func(ctx context.Context, out io.Writer, opts refs.Options) error
+
+func refsCmd(handler refsHandler) *cobra.Command {
+	var opts refs.Options
+
+	refsCmd := &cobra.Command{
+		Use:   "refs",
+		Short: "Lists the code that cites each decision record.",
+		Long: "Scans the project for citations of decision records, such as " +
+			"\"// see ADR-0007\", and lists the code locations that cite each " +
+			"record. Hidden files, vendor, testdata and node_modules directories, " +
+			"paths ignored by the .gitignore next to the state file, and the ADR " +
+			"directories themselves are skipped. Citations of superseded, " +
+			"rejected or missing records are reported as warnings. With " +
+			"--write, a \"Referenced from code\" section listing the citing code " +
+			"is kept up to date in each record.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("refs handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := refsCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVarP(&opts.Format, "output", "o", refs.FormatText, "output format: text or json")
+	flags.BoolVar(&opts.Write, "write", false, "write a section listing the citing code into each record")
+
+	return refsCmd
+}
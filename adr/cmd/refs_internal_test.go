@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/refs"
+)
+
+func TestRefsCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts refs.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: refs.Options{Format: refs.FormatText},
+			},
+		},
+		{
+			name: "write json for a single dir",
+			args: []string{"--dir", "security", "-o", "json", "--write"},
+			wants: want{
+				opts: refs.Options{Dir: "security", Format: refs.FormatJSON, Write: true},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"0007"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown format",
+			handlerRet: refs.ErrUnknownFormat,
+			args:       []string{"-o", "xml"},
+			wants: want{
+				err:  true,
+				opts: refs.Options{Format: "xml"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts refs.Options
+
+			h := func(ctx context.Context, out io.Writer, o refs.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := refsCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -3,7 +3,26 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/docula-io/docula/adr/handler/adrtools"
+	"github.com/docula-io/docula/adr/handler/approval"
+	"github.com/docula-io/docula/adr/handler/check"
+	"github.com/docula-io/docula/adr/handler/codeowners"
+	"github.com/docula-io/docula/adr/handler/convert"
+	"github.com/docula-io/docula/adr/handler/create"
+	"github.com/docula-io/docula/adr/handler/deps"
+	"github.com/docula-io/docula/adr/handler/due"
+	"github.com/docula-io/docula/adr/handler/graph"
 	"github.com/docula-io/docula/adr/handler/initialize"
+	"github.com/docula-io/docula/adr/handler/list"
+	"github.com/docula-io/docula/adr/handler/pending"
+	"github.com/docula-io/docula/adr/handler/refs"
+	"github.com/docula-io/docula/adr/handler/score"
+	"github.com/docula-io/docula/adr/handler/similar"
+	"github.com/docula-io/docula/adr/handler/status"
+	"github.com/docula-io/docula/adr/handler/supersede"
+	"github.com/docula-io/docula/adr/handler/toc"
+	"github.com/docula-io/docula/adr/handler/todo"
+	"github.com/docula-io/docula/adr/handler/who"
 )
 
 // RootCmd produces the root for the adr command tree.
@@ -17,7 +36,47 @@ func RootCmd() *cobra.Command {
 
 	initHandler := initialize.New()
 
+	newHandler := create.New()
+	listHandler := list.New()
+	statusHandler := status.New()
+	supersedeHandler := supersede.New()
+	indexHandler := toc.New()
+	graphHandler := graph.New()
+	adrToolsHandler := adrtools.New()
+	convertHandler := convert.New()
+	refsHandler := refs.New()
+	checkHandler := check.New()
+	checkDepsHandler := deps.New()
+	dueHandler := due.New()
+	whoHandler := who.New()
+	codeownersHandler := codeowners.New()
+	approvalHandler := approval.New()
+	pendingHandler := pending.New()
+	scoreHandler := score.New()
+	todoHandler := todo.New()
+	similarHandler := similar.New()
+
 	rootCmd.AddCommand(initCmd(initHandler.Handle))
+	rootCmd.AddCommand(newCmd(newHandler.Handle))
+	rootCmd.AddCommand(listCmd(listHandler.Handle))
+	rootCmd.AddCommand(queryCmd(listHandler.Handle))
+	rootCmd.AddCommand(statusCmds(statusHandler.Handle)...)
+	rootCmd.AddCommand(supersedeCmd(supersedeHandler.Handle))
+	rootCmd.AddCommand(indexCmd(indexHandler.Handle))
+	rootCmd.AddCommand(graphCmd(graphHandler.Handle))
+	rootCmd.AddCommand(importCmd(adrToolsHandler.Handle))
+	rootCmd.AddCommand(convertCmd(convertHandler.Handle))
+	rootCmd.AddCommand(refsCmd(refsHandler.Handle))
+	rootCmd.AddCommand(checkCmd(checkHandler.Handle))
+	rootCmd.AddCommand(checkDepsCmd(checkDepsHandler.Handle))
+	rootCmd.AddCommand(dueCmd(dueHandler.Handle))
+	rootCmd.AddCommand(whoCmd(whoHandler.Handle))
+	rootCmd.AddCommand(codeownersCmd(codeownersHandler.Handle))
+	rootCmd.AddCommand(approvalCmds(approvalHandler.Handle)...)
+	rootCmd.AddCommand(pendingCmd(pendingHandler.Handle))
+	rootCmd.AddCommand(scoreCmd(scoreHandler.Handle))
+	rootCmd.AddCommand(todoCmd(todoHandler.Handle))
+	rootCmd.AddCommand(similarCmd(similarHandler.Handle))
 
 	return rootCmd
 }
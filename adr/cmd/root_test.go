@@ -20,6 +20,144 @@ func TestRootCommand(t *testing.T) {
 				"init", "--help",
 			},
 		},
+		{
+			name: "should have a new command",
+			args: []string{
+				"new", "--help",
+			},
+		},
+		{
+			name: "should have a list command",
+			args: []string{
+				"list", "--help",
+			},
+		},
+		{
+			name: "should have an accept command",
+			args: []string{
+				"accept", "--help",
+			},
+		},
+		{
+			name: "should have a deprecate command",
+			args: []string{
+				"deprecate", "--help",
+			},
+		},
+		{
+			name: "should have a transition command",
+			args: []string{
+				"transition", "--help",
+			},
+		},
+		{
+			name: "should have a supersede command",
+			args: []string{
+				"supersede", "--help",
+			},
+		},
+		{
+			name: "should have an index command",
+			args: []string{
+				"index", "--help",
+			},
+		},
+		{
+			name: "should have a graph command",
+			args: []string{
+				"graph", "--help",
+			},
+		},
+		{
+			name: "should have an import adr-tools command",
+			args: []string{
+				"import", "adr-tools", "--help",
+			},
+		},
+		{
+			name: "should have a convert command",
+			args: []string{
+				"convert", "--help",
+			},
+		},
+		{
+			name: "should have a refs command",
+			args: []string{
+				"refs", "--help",
+			},
+		},
+		{
+			name: "should have a check command",
+			args: []string{
+				"check", "--help",
+			},
+		},
+		{
+			name: "should have a check-deps command",
+			args: []string{
+				"check-deps", "--help",
+			},
+		},
+		{
+			name: "should have a due command",
+			args: []string{
+				"due", "--help",
+			},
+		},
+		{
+			name: "should have a who command",
+			args: []string{
+				"who", "--help",
+			},
+		},
+		{
+			name: "should have a codeowners command",
+			args: []string{
+				"codeowners", "--help",
+			},
+		},
+		{
+			name: "should have an approve command",
+			args: []string{
+				"approve", "--help",
+			},
+		},
+		{
+			name: "should have an object command",
+			args: []string{
+				"object", "--help",
+			},
+		},
+		{
+			name: "should have a pending command",
+			args: []string{
+				"pending", "--help",
+			},
+		},
+		{
+			name: "should have a score command",
+			args: []string{
+				"score", "--help",
+			},
+		},
+		{
+			name: "should have a todo command",
+			args: []string{
+				"todo", "--help",
+			},
+		},
+		{
+			name: "should have a similar command",
+			args: []string{
+				"similar", "--help",
+			},
+		},
+		{
+			name: "should have a query command",
+			args: []string{
+				"query", "--help",
+			},
+		},
 		{
 			name: "should not have a foobar command",
 			args: []string{
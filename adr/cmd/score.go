@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/score"
+)
+
+type scoreHandler func(ctx context.Context, out io.Writer, opts score.Options) error
+
+func scoreCmd(handler scoreHandler) *cobra.Command {
+	var opts score.Options
+
+	scoreCmd := &cobra.Command{
+		Use:   "score <id>",
+		Short: "Computes the weighted decision matrix of a record.",
+		Long: "Computes the weighted totals of the decision matrix of a " +
+			"record and ranks its options. The matrix is read from a " +
+			"```yaml docula:matrix code block, or from a markdown table " +
+			"surrounded by <!-- docula:matrix --> comments, with a " +
+			"criterion on every row, its weight in the second column and " +
+			"an option in every other column. Every score must fall within " +
+			"the scale of the matrix, 1-5 unless declared otherwise. The " +
+			"matrix is then rendered into the record as a normalized table " +
+			"holding the totals, or only checked with the --check flag.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ID = args[0]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("score handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := scoreCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.BoolVar(&opts.Check, "check", false, "fail if the matrix table is out of date instead of updating it")
+
+	return scoreCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/score"
+)
+
+func TestScoreCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts score.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{"12"},
+			wants: want{
+				opts: score.Options{ID: "12"},
+			},
+		},
+		{
+			name: "check a single dir",
+			args: []string{"12", "--dir", "security", "--check"},
+			wants: want{
+				opts: score.Options{ID: "12", Dir: "security", Check: true},
+			},
+		},
+		{
+			name: "missing id",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "outdated table",
+			handlerRet: score.ErrOutdated,
+			args:       []string{"12", "--check"},
+			wants: want{
+				err:  true,
+				opts: score.Options{ID: "12", Check: true},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts score.Options
+
+			h := func(ctx context.Context, out io.Writer, o score.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := scoreCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
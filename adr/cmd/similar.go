@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/similar"
+)
+
+type similarHandler func(ctx context.Context, out io.Writer, opts similar.Options) error
+
+func similarCmd(handler similarHandler) *cobra.Command {
+	var opts similar.Options
+
+	similarCmd := &cobra.Command{
+		Use:   "similar <text|id>",
+		Short: "Lists the decision records that resemble a text or record.",
+		Long: "Ranks the decision records of every ADR directory, or of the " +
+			"directory selected with the --dir flag, by how similar their " +
+			"titles and contents are to the given text. When the argument " +
+			"identifies a record, such as 12 or security:12, the records are " +
+			"compared with that record instead. The comparison uses TF-IDF " +
+			"weights of the words of the records and runs entirely offline.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Query = strings.Join(args, " ")
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("similar handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := similarCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.IntVar(&opts.Limit, "limit", 5, "maximum number of records listed, or 0 for all")
+	flags.IntVar(&opts.Min, "min", 10, "lowest similarity listed, as a percentage")
+	flags.StringVarP(&opts.Format, "output", "o", similar.FormatTable, "output format: table or json")
+
+	return similarCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/similar"
+)
+
+func TestSimilarCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts similar.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{"Cache", "sessions"},
+			wants: want{
+				opts: similar.Options{Query: "Cache sessions", Limit: 5, Min: 10, Format: similar.FormatTable},
+			},
+		},
+		{
+			name: "record as json for a single dir",
+			args: []string{"security:12", "--dir", "platform", "--limit", "0", "--min", "25", "-o", "json"},
+			wants: want{
+				opts: similar.Options{Query: "security:12", Dir: "platform", Min: 25, Format: similar.FormatJSON},
+			},
+		},
+		{
+			name: "missing argument",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown format",
+			handlerRet: similar.ErrUnknownFormat,
+			args:       []string{"12", "-o", "csv"},
+			wants: want{
+				err:  true,
+				opts: similar.Options{Query: "12", Limit: 5, Min: 10, Format: "csv"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts similar.Options
+
+			h := func(ctx context.Context, out io.Writer, o similar.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := similarCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/status"
+)
+
+type statusHandler func(ctx context.Context, out io.Writer, opts status.Options) error
+
+// statusCmd produces a command that moves a record to the given status.
+func statusCmd(use string, to string, handler statusHandler) *cobra.Command {
+	opts := status.Options{Status: to}
+
+	statusCmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s <id>", use),
+		Short: fmt.Sprintf("Marks a decision record as %s.", strings.ToLower(to)),
+		Long: fmt.Sprintf("Marks a decision record as %s. ", strings.ToLower(to)) +
+			"The change is appended to the status history of the record, " +
+			"attributed to the git user unless the --by flag is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ID = args[0]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("%s handler: %w", use, err)
+			}
+
+			return nil
+		},
+	}
+
+	statusCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	statusCmd.Flags().StringVar(&opts.By, "by", "", "person making the change")
+
+	return statusCmd
+}
+
+// transitionCmd produces a command that moves a record to any status of the
+// workflow of its directory.
+func transitionCmd(handler statusHandler) *cobra.Command {
+	var opts status.Options
+
+	transitionCmd := &cobra.Command{
+		Use:   "transition <id> <status>",
+		Short: "Moves a decision record to a status of its workflow.",
+		Long: "Moves a decision record to a status of its workflow. This is " +
+			"used for statuses that are declared by the workflow of an ADR " +
+			"directory, such as an additional review step.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ID = args[0]
+			opts.Status = args[1]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("transition handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	transitionCmd.Flags().StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	transitionCmd.Flags().StringVar(&opts.By, "by", "", "person making the change")
+
+	return transitionCmd
+}
+
+func statusCmds(handler statusHandler) []*cobra.Command {
+	return []*cobra.Command{
+		statusCmd("propose", adr.StatusProposed, handler),
+		statusCmd("accept", adr.StatusAccepted, handler),
+		statusCmd("reject", adr.StatusRejected, handler),
+		statusCmd("deprecate", adr.StatusDeprecated, handler),
+		transitionCmd(handler),
+	}
+}
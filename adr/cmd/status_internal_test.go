@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/status"
+)
+
+func TestStatusCmds(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  bool
+		opts status.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "propose",
+			args: []string{"propose", "12"},
+			wants: want{
+				opts: status.Options{ID: "12", Status: "Proposed"},
+			},
+		},
+		{
+			name: "accept with flags",
+			args: []string{"accept", "12", "--dir", "security", "--by", "Jane Doe"},
+			wants: want{
+				opts: status.Options{ID: "12", Dir: "security", Status: "Accepted", By: "Jane Doe"},
+			},
+		},
+		{
+			name: "reject",
+			args: []string{"reject", "0003"},
+			wants: want{
+				opts: status.Options{ID: "0003", Status: "Rejected"},
+			},
+		},
+		{
+			name: "deprecate",
+			args: []string{"deprecate", "4"},
+			wants: want{
+				opts: status.Options{ID: "4", Status: "Deprecated"},
+			},
+		},
+		{
+			name: "transition",
+			args: []string{"transition", "4", "under-review", "--by", "Jane Doe"},
+			wants: want{
+				opts: status.Options{ID: "4", Status: "under-review", By: "Jane Doe"},
+			},
+		},
+		{
+			name: "transition without status",
+			args: []string{"transition", "4"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name: "missing id",
+			args: []string{"accept"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{"accept", "1"},
+			wants: want{
+				err:  true,
+				opts: status.Options{ID: "1", Status: "Accepted"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts status.Options
+
+			h := func(ctx context.Context, out io.Writer, o status.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			root := RootCmd()
+			root.ResetCommands()
+			root.AddCommand(statusCmds(h)...)
+
+			root.SetArgs(tt.args)
+
+			err := root.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/supersede"
+)
+
+type supersedeHandler func(ctx context.Context, out io.Writer, opts supersede.Options) error
+
+func supersedeCmd(handler supersedeHandler) *cobra.Command {
+	var opts supersede.Options
+
+	supersedeCmd := &cobra.Command{
+		Use:   "supersede <old-id> <new-id>",
+		Short: "Marks a decision record as superseded by another.",
+		Long: "Marks a decision record as superseded by another record, and " +
+			"links the new record back to the one it supersedes. Identifiers " +
+			"can be qualified with an ADR directory, such as security:12.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Old = args[0]
+			opts.New = args[1]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("supersede handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	supersedeCmd.Flags().StringVar(&opts.By, "by", "", "person making the change")
+
+	return supersedeCmd
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/supersede"
+)
+
+func TestSupersedeCmd(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  bool
+		opts supersede.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "happy path",
+			args: []string{"4", "security:12", "--by", "Jane Doe"},
+			wants: want{
+				opts: supersede.Options{Old: "4", New: "security:12", By: "Jane Doe"},
+			},
+		},
+		{
+			name: "missing new id",
+			args: []string{"4"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "handler error",
+			handlerRet: errBoom,
+			args:       []string{"4", "12"},
+			wants: want{
+				err:  true,
+				opts: supersede.Options{Old: "4", New: "12"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts supersede.Options
+
+			h := func(ctx context.Context, out io.Writer, o supersede.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := supersedeCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
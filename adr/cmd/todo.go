@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/todo"
+)
+
+type todoHandler func(ctx context.Context, out io.Writer, opts todo.Options) error
+
+func todoCmd(handler todoHandler) *cobra.Command {
+	var opts todo.Options
+
+	todoCmd := &cobra.Command{
+		Use:   "todo",
+		Short: "Lists the open follow-ups of the accepted decisions.",
+		Long: "Collects the unchecked task list items, such as \"- [ ] Add " +
+			"alerts @sre\", of the accepted decision records of every ADR " +
+			"directory, or of the directory selected with the --dir flag. " +
+			"The items are grouped by decision, or with --group owner by " +
+			"the @mentions they hold. Decisions that were accepted longer " +
+			"ago than the --older interval are flagged.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("todo handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := todoCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVar(&opts.Older, "older", "90d", "flag decisions accepted longer ago than this interval")
+	flags.StringVar(&opts.Group, "group", todo.GroupDecision, "grouping of the text output: decision or owner")
+	flags.StringVarP(&opts.Format, "output", "o", todo.FormatText, "output format: text or json")
+
+	return todoCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/todo"
+)
+
+func TestTodoCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts todo.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			wants: want{
+				opts: todo.Options{Older: "90d", Group: todo.GroupDecision, Format: todo.FormatText},
+			},
+		},
+		{
+			name: "json by owner for a single dir",
+			args: []string{"--dir", "security", "--older", "2w", "--group", "owner", "-o", "json"},
+			wants: want{
+				opts: todo.Options{Dir: "security", Older: "2w", Group: todo.GroupOwner, Format: todo.FormatJSON},
+			},
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"12"},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown grouping",
+			handlerRet: todo.ErrUnknownGroup,
+			args:       []string{"--group", "team"},
+			wants: want{
+				err:  true,
+				opts: todo.Options{Older: "90d", Group: "team", Format: todo.FormatText},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts todo.Options
+
+			h := func(ctx context.Context, out io.Writer, o todo.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := todoCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
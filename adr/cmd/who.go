@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/adr/handler/who"
+)
+
+type whoHandler func(ctx context.Context, out io.Writer, opts who.Options) error
+
+func whoCmd(handler whoHandler) *cobra.Command {
+	var opts who.Options
+
+	whoCmd := &cobra.Command{
+		Use:   "who <person>",
+		Short: "Lists the decisions a person or team is involved in.",
+		Long: "Lists the decision records that name a person or team as one " +
+			"of their deciders, or as consulted or informed, along with the " +
+			"roles they have. Names are compared without regard to case or a " +
+			"leading @, so jane matches @Jane.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Person = args[0]
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("who handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	flags := whoCmd.Flags()
+
+	flags.StringVar(&opts.Dir, "dir", "", "name or path of the ADR directory")
+	flags.StringVarP(&opts.Format, "output", "o", who.FormatTable, "output format: table or json")
+
+	return whoCmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/handler/who"
+)
+
+func TestWhoCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts who.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "defaults",
+			args: []string{"@jane"},
+			wants: want{
+				opts: who.Options{Person: "@jane", Format: who.FormatTable},
+			},
+		},
+		{
+			name: "json for a single dir",
+			args: []string{"@org/sre", "--dir", "security", "-o", "json"},
+			wants: want{
+				opts: who.Options{Person: "@org/sre", Dir: "security", Format: who.FormatJSON},
+			},
+		},
+		{
+			name: "missing person",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "unknown format",
+			handlerRet: who.ErrUnknownFormat,
+			args:       []string{"jane", "-o", "xml"},
+			wants: want{
+				err:  true,
+				opts: who.Options{Person: "jane", Format: "xml"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts who.Options
+
+			h := func(ctx context.Context, out io.Writer, o who.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := whoCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
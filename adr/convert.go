@@ -0,0 +1,302 @@
+package adr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Convert rewrites the record into the given metadata style. The markdown
+// of the record is kept, apart from the metadata that moves between the
+// frontmatter and the fields and status section below the title. Records
+// already written in the style are returned unchanged.
+func Convert(data []byte, rec Record, style string) ([]byte, error) {
+	_, _, hasFrontmatter := splitFrontmatter(data)
+
+	switch {
+	case style != StyleInline && style != StyleFrontmatter:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStyle, style)
+	case hasFrontmatter == (style == StyleFrontmatter):
+		return data, nil
+	case style == StyleFrontmatter:
+		return toFrontmatter(data, rec)
+	}
+
+	return toInline(data, rec)
+}
+
+// toFrontmatter moves the fields below the title, the blocks of rules and
+// the status section of the markdown into a frontmatter. Links found
+// elsewhere are left in place.
+func toFrontmatter(data []byte, rec Record) ([]byte, error) {
+	lines := strings.Split(string(data), "\n")
+
+	var (
+		kept    []string
+		links   []Link
+		section string
+		title   bool
+		history bool
+		signOff bool
+		block   bool
+	)
+
+	statusLine := findStatusLine(lines)
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		_, opens := blockFence(trimmed)
+
+		switch {
+		case trimmed == historyStart:
+			history = true
+			continue
+		case trimmed == historyEnd:
+			history = false
+			continue
+		case history:
+			continue
+		case trimmed == signOffStart:
+			signOff = true
+			continue
+		case trimmed == signOffEnd:
+			signOff = false
+			continue
+		case signOff:
+			continue
+		case block:
+			block = trimmed != "```"
+			continue
+		case opens:
+			block = true
+			continue
+		case i == statusLine:
+			continue
+		case strings.HasPrefix(trimmed, "# ") && !title:
+			title = true
+			kept = append(kept, "# "+numberedTitle.ReplaceAllString(strings.TrimSpace(trimmed[2:]), ""))
+
+			continue
+		case strings.HasPrefix(trimmed, "## "):
+			section = strings.ToLower(strings.TrimSpace(trimmed[3:]))
+		case section == "" && isField(trimmed):
+			continue
+		case section == "status" || section == "":
+			if link, ok := parseLink(trimmed); ok {
+				links = append(links, link)
+				continue
+			}
+		}
+
+		kept = append(kept, line)
+	}
+
+	// The status section is dropped entirely if nothing but blank lines are
+	// left within it.
+	for i := 0; i < len(kept); i++ {
+		if !strings.EqualFold(strings.TrimSpace(kept[i]), "## status") {
+			continue
+		}
+
+		end := i + 1
+		for end < len(kept) && strings.TrimSpace(kept[end]) == "" {
+			end++
+		}
+
+		if end == len(kept) || strings.HasPrefix(strings.TrimSpace(kept[end]), "## ") {
+			kept = append(kept[:i], kept[end:]...)
+		}
+
+		break
+	}
+
+	front, err := marshalFrontmatter(rec, links)
+	if err != nil {
+		return nil, fmt.Errorf("marshal frontmatter: %w", err)
+	}
+
+	body := strings.TrimLeft(squeeze(kept), "\n")
+
+	return joinFrontmatter(front, []byte("\n"+body)), nil
+}
+
+// isField reports whether the line is a metadata field of the preamble.
+func isField(line string) bool {
+	if inlineStatus.MatchString(line) {
+		return true
+	}
+
+	key, value, found := strings.Cut(strings.TrimLeft(line, "*- "), ":")
+	if !found || strings.TrimSpace(value) == "" {
+		return false
+	}
+
+	return fieldKey.MatchString(strings.TrimSpace(key))
+}
+
+// toInline writes the metadata of the frontmatter as fields below the title
+// of the markdown, along with blocks of the rules, followed by a status
+// section holding the status, the status history and the links of the
+// frontmatter.
+func toInline(data []byte, rec Record) ([]byte, error) {
+	front, body, _ := splitFrontmatter(data)
+
+	var parsed Record
+
+	if err := applyFrontmatter(&parsed, front); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimLeft(string(body), "\n"), "\n")
+
+	titleLine := -1
+
+	for i, line := range lines {
+		if strings.HasPrefix(line, "# ") {
+			titleLine = i
+			break
+		}
+	}
+
+	if titleLine < 0 {
+		lines = append([]string{"# " + rec.Title, ""}, lines...)
+		titleLine = 0
+	}
+
+	meta := []string{""}
+
+	if !rec.Date.IsZero() {
+		meta = append(meta, fmt.Sprintf("Date: %s", rec.Date.Format(DateFormat)))
+	}
+
+	if !rec.ReviewBy.IsZero() {
+		meta = append(meta, fmt.Sprintf("Review-by: %s", rec.ReviewBy.Format(DateFormat)))
+	}
+
+	if len(rec.Deciders) > 0 {
+		meta = append(meta, fmt.Sprintf("Deciders: %s", strings.Join(rec.Deciders, ", ")))
+	}
+
+	if len(rec.Consulted) > 0 {
+		meta = append(meta, fmt.Sprintf("Consulted: %s", strings.Join(rec.Consulted, ", ")))
+	}
+
+	if len(rec.Informed) > 0 {
+		meta = append(meta, fmt.Sprintf("Informed: %s", strings.Join(rec.Informed, ", ")))
+	}
+
+	if len(rec.Tags) > 0 {
+		meta = append(meta, fmt.Sprintf("Tags: %s", strings.Join(rec.Tags, ", ")))
+	}
+
+	keys := make([]string, 0, len(rec.Fields))
+	for key := range rec.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		meta = append(meta, fmt.Sprintf("%s: %s", key, rec.Fields[key]))
+	}
+
+	blocks, err := renderBlocks(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	meta = append(meta, blocks...)
+
+	if rec.Status != "" {
+		status := []string{"", rec.Status}
+
+		if len(rec.History) > 0 {
+			status = append(status, "", historyStart)
+
+			for _, c := range rec.History {
+				status = append(status, c.String())
+			}
+
+			status = append(status, historyEnd)
+		}
+
+		if len(rec.SignOffs) > 0 {
+			status = append(status, "", signOffStart)
+
+			for _, s := range rec.SignOffs {
+				status = append(status, s.String())
+			}
+
+			status = append(status, signOffEnd)
+		}
+
+		var links []string
+
+		for _, l := range frontmatterLinks(parsed) {
+			links = append(links, l.String())
+		}
+
+		if len(links) > 0 {
+			status = append(append(status, ""), links...)
+		}
+
+		// A status section that was kept for the rest of its content, such
+		// as a matrix, has the status written back under its heading.
+		if heading := findHeading(lines, "status"); heading > titleLine {
+			lines = insert(lines, heading+1, append(status, "")...)
+		} else {
+			meta = append(append(meta, "", "## Status"), status...)
+		}
+	}
+
+	lines = insert(lines, titleLine+1, append(meta, "")...)
+
+	return []byte(squeeze(lines)), nil
+}
+
+// findHeading returns the index of the section heading of the given name,
+// matched without regard to case, or -1 if there is none.
+func findHeading(lines []string, name string) int {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") && strings.EqualFold(strings.TrimSpace(trimmed[3:]), name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// frontmatterLinks returns the links that are listed in the frontmatter of
+// the record, leaving out the link held by the status.
+func frontmatterLinks(rec Record) []Link {
+	if _, ok := parseLink(rec.Status); ok {
+		return rec.Links[1:]
+	}
+
+	return rec.Links
+}
+
+// squeeze joins the lines, collapsing runs of blank lines outside of code
+// fences into a single blank line.
+func squeeze(lines []string) string {
+	res := make([]string, 0, len(lines))
+
+	var fenced bool
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+		}
+
+		if !fenced && trimmed == "" && i > 0 && len(res) > 0 && strings.TrimSpace(res[len(res)-1]) == "" {
+			continue
+		}
+
+		res = append(res, line)
+	}
+
+	return strings.Join(res, "\n")
+}
@@ -0,0 +1,265 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestConvert(t *testing.T) {
+	inline := `# 4. Use Kafka
+
+Date: 2022-09-01
+Review-by: 2023-09-01
+Tags: messaging, infra
+Consulted: @dba
+
+## Status
+
+Superseded by [0005. Use NATS](0005-use-nats.md)
+
+<!-- docula:status-history -->
+- 2022-09-01: Accepted by Jane Doe
+- 2022-10-01: Superseded
+<!-- /docula:status-history -->
+
+<!-- docula:sign-offs -->
+- 2022-08-30: Approved by Jane Doe <jane@example.com>
+- 2022-08-31: Objected by John: too early, wait
+<!-- /docula:sign-offs -->
+
+Amends [0002. Use PostgreSQL](0002-use-postgresql.md)
+
+## Context
+
+See [0003. Use gRPC](0003-use-grpc.md) for the transport.
+
+` + "```" + `
+
+
+kept
+` + "```" + `
+`
+
+	rec, err := adr.ParseRecord("0004-use-kafka.md", []byte(inline))
+	assert.NoError(t, err)
+
+	front, err := adr.Convert([]byte(inline), rec, adr.StyleFrontmatter)
+	assert.NoError(t, err)
+	assert.Equal(t, `---
+title: Use Kafka
+status: Superseded by [0005. Use NATS](0005-use-nats.md)
+date: "2022-09-01"
+review-by: "2023-09-01"
+consulted:
+  - '@dba'
+tags:
+  - messaging
+  - infra
+links:
+  - type: Amends
+    title: 0002. Use PostgreSQL
+    target: 0002-use-postgresql.md
+history:
+  - date: "2022-09-01"
+    status: Accepted
+    by: Jane Doe
+  - date: "2022-10-01"
+    status: Superseded
+sign-offs:
+  - date: "2022-08-30"
+    verdict: Approved
+    by: Jane Doe <jane@example.com>
+  - date: "2022-08-31"
+    verdict: Objected
+    by: John
+    note: too early, wait
+---
+
+# Use Kafka
+
+## Context
+
+See [0003. Use gRPC](0003-use-grpc.md) for the transport.
+
+`+"```"+`
+
+
+kept
+`+"```"+`
+`, string(front))
+
+	converted, err := adr.ParseRecord("0004-use-kafka.md", front)
+	assert.NoError(t, err)
+	assert.Equal(t, adr.StyleFrontmatter, converted.Style)
+	converted.Style = adr.StyleInline
+	assert.Equal(t, rec, converted)
+
+	same, err := adr.Convert(front, converted, adr.StyleFrontmatter)
+	assert.NoError(t, err)
+	assert.Equal(t, string(front), string(same))
+
+	back, err := adr.Convert(front, converted, adr.StyleInline)
+	assert.NoError(t, err)
+
+	restored, err := adr.ParseRecord("0004-use-kafka.md", back)
+	assert.NoError(t, err)
+	assert.Equal(t, rec, restored)
+
+	_, err = adr.Convert(front, converted, "toml")
+	assert.ErrorIs(t, err, adr.ErrUnknownStyle)
+}
+
+func TestConvertRules(t *testing.T) {
+	inline := "# Keep billing apart\n" +
+		"\n" +
+		"## Status\n" +
+		"\n" +
+		"Accepted\n" +
+		"\n" +
+		"```yaml docula:imports\n" +
+		"- from: internal/billing/...\n" +
+		"  deny: [internal/auth/...]\n" +
+		"```\n" +
+		"\n" +
+		"```yaml docula:modules\n" +
+		"- {module: github.com/lib/pq, policy: banned}\n" +
+		"```\n" +
+		"\n" +
+		"## Context\n"
+
+	rec, err := adr.ParseRecord("0007-keep-billing-apart.md", []byte(inline))
+	assert.NoError(t, err)
+	assert.Equal(t, []adr.ImportRule{{From: "internal/billing/...", Deny: []string{"internal/auth/..."}}}, rec.Imports)
+	assert.Equal(t, []adr.ModulePolicy{{Module: "github.com/lib/pq", Policy: adr.PolicyBanned}}, rec.Modules)
+
+	front, err := adr.Convert([]byte(inline), rec, adr.StyleFrontmatter)
+	assert.NoError(t, err)
+	assert.Equal(t, "---\n"+
+		"title: Keep billing apart\n"+
+		"status: Accepted\n"+
+		"imports:\n"+
+		"  - from: internal/billing/...\n"+
+		"    deny:\n"+
+		"      - internal/auth/...\n"+
+		"modules:\n"+
+		"  - module: github.com/lib/pq\n"+
+		"    policy: banned\n"+
+		"---\n"+
+		"\n"+
+		"# Keep billing apart\n"+
+		"\n"+
+		"## Context\n", string(front))
+
+	converted, err := adr.ParseRecord("0007-keep-billing-apart.md", front)
+	assert.NoError(t, err)
+	assert.Equal(t, rec.Imports, converted.Imports)
+	assert.Equal(t, rec.Modules, converted.Modules)
+
+	back, err := adr.Convert(front, rec, adr.StyleInline)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Keep billing apart\n"+
+		"\n"+
+		"```yaml docula:imports\n"+
+		"- from: internal/billing/...\n"+
+		"  deny:\n"+
+		"    - internal/auth/...\n"+
+		"```\n"+
+		"\n"+
+		"```yaml docula:modules\n"+
+		"- module: github.com/lib/pq\n"+
+		"  policy: banned\n"+
+		"```\n"+
+		"\n"+
+		"## Status\n"+
+		"\n"+
+		"Accepted\n"+
+		"\n"+
+		"## Context\n", string(back))
+
+	restored, err := adr.ParseRecord("0007-keep-billing-apart.md", back)
+	assert.NoError(t, err)
+	assert.Equal(t, rec, restored)
+}
+
+func TestConvertFields(t *testing.T) {
+	front := "---\n" +
+		"title: Use Kafka\n" +
+		"status: Accepted\n" +
+		"owner: 2025-02-03\n" +
+		"approved: true\n" +
+		"budget: 1200.50\n" +
+		"ticket: 'PLAT-7: brokers'\n" +
+		"---\n" +
+		"\n" +
+		"# Use Kafka\n"
+
+	fields := map[string]string{
+		"owner":    "2025-02-03",
+		"approved": "true",
+		"budget":   "1200.50",
+		"ticket":   "PLAT-7: brokers",
+	}
+
+	rec, err := adr.ParseRecord("0004-use-kafka.md", []byte(front))
+	assert.NoError(t, err)
+	assert.Equal(t, fields, rec.Fields)
+
+	inline, err := adr.Convert([]byte(front), rec, adr.StyleInline)
+	assert.NoError(t, err)
+
+	fromInline, err := adr.ParseRecord("0004-use-kafka.md", inline)
+	assert.NoError(t, err)
+	assert.Equal(t, fields, fromInline.Fields)
+
+	back, err := adr.Convert(inline, fromInline, adr.StyleFrontmatter)
+	assert.NoError(t, err)
+	assert.Equal(t, "---\n"+
+		"title: Use Kafka\n"+
+		"status: Accepted\n"+
+		"approved: true\n"+
+		"budget: 1200.50\n"+
+		"owner: 2025-02-03\n"+
+		"ticket: 'PLAT-7: brokers'\n"+
+		"---\n"+
+		"\n"+
+		"# Use Kafka\n", string(back))
+
+	restored, err := adr.ParseRecord("0004-use-kafka.md", back)
+	assert.NoError(t, err)
+	assert.Equal(t, fields, restored.Fields)
+}
+
+func TestConvertStatusSection(t *testing.T) {
+	inline := "# Use PostgreSQL\n" +
+		"\n" +
+		"Date: 2022-09-01\n" +
+		"\n" +
+		"## Status\n" +
+		"\n" +
+		"Proposed\n" +
+		"\n" +
+		"```yaml docula:matrix\n" +
+		"criteria:\n" +
+		"  - {name: Cost}\n" +
+		"options:\n" +
+		"  - {name: PostgreSQL, scores: {Cost: 4}}\n" +
+		"```\n" +
+		"\n" +
+		"## Context\n"
+
+	rec, err := adr.ParseRecord("0012-use-postgresql.md", []byte(inline))
+	assert.NoError(t, err)
+
+	front, err := adr.Convert([]byte(inline), rec, adr.StyleFrontmatter)
+	assert.NoError(t, err)
+
+	converted, err := adr.ParseRecord("0012-use-postgresql.md", front)
+	assert.NoError(t, err)
+
+	back, err := adr.Convert(front, converted, adr.StyleInline)
+	assert.NoError(t, err)
+	assert.Equal(t, inline, string(back))
+}
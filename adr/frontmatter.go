@@ -0,0 +1,429 @@
+package adr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The metadata styles a record can be written in.
+const (
+	// StyleInline records hold their metadata within the markdown, as
+	// fields below the title and a status section.
+	StyleInline = "inline"
+	// StyleFrontmatter records hold their metadata in a YAML frontmatter
+	// block at the top of the file.
+	StyleFrontmatter = "frontmatter"
+)
+
+const frontmatterDelim = "---"
+
+var (
+	// ErrInvalidFrontmatter is returned when the frontmatter of a record is
+	// not a YAML mapping.
+	ErrInvalidFrontmatter = errors.New("invalid frontmatter")
+
+	// ErrUnknownStyle is returned when a record is converted to a metadata
+	// style that docula does not support.
+	ErrUnknownStyle = errors.New("unknown metadata style")
+)
+
+// Styles returns all of the supported metadata styles.
+func Styles() []string {
+	return []string{StyleInline, StyleFrontmatter}
+}
+
+// list is a list of values that can be written in YAML either as a sequence
+// or as a single comma separated string.
+type list []string
+
+func (l *list) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = splitList(value.Value)
+		return nil
+	}
+
+	var values []string
+
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+
+	*l = values
+
+	return nil
+}
+
+func splitList(value string) []string {
+	var values []string
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+type frontmatterLink struct {
+	Type   string `yaml:"type"`
+	Title  string `yaml:"title,omitempty"`
+	Target string `yaml:"target"`
+}
+
+type frontmatterSignOff struct {
+	Date    string `yaml:"date"`
+	Verdict string `yaml:"verdict"`
+	By      string `yaml:"by"`
+	Note    string `yaml:"note,omitempty"`
+}
+
+type frontmatterChange struct {
+	Date   string `yaml:"date"`
+	Status string `yaml:"status"`
+	By     string `yaml:"by,omitempty"`
+}
+
+// frontmatter represents the metadata of a record as it is written in YAML.
+// Any keys that are not known are kept as the custom fields of the record.
+type frontmatter struct {
+	Title     string               `yaml:"title,omitempty"`
+	Status    string               `yaml:"status,omitempty"`
+	Date      string               `yaml:"date,omitempty"`
+	ReviewBy  string               `yaml:"review-by,omitempty"`
+	Deciders  list                 `yaml:"deciders,omitempty"`
+	Consulted list                 `yaml:"consulted,omitempty"`
+	Informed  list                 `yaml:"informed,omitempty"`
+	Tags      list                 `yaml:"tags,omitempty"`
+	Links     []frontmatterLink    `yaml:"links,omitempty"`
+	History   []frontmatterChange  `yaml:"history,omitempty"`
+	SignOffs  []frontmatterSignOff `yaml:"sign-offs,omitempty"`
+	Imports   []ImportRule         `yaml:"imports,omitempty"`
+	Modules   []ModulePolicy       `yaml:"modules,omitempty"`
+	// Fields holds the nodes of the custom fields, so that their values are
+	// kept as written rather than as the types YAML resolves them to.
+	Fields map[string]yaml.Node `yaml:",inline"`
+}
+
+// splitFrontmatter separates the frontmatter of a record from the markdown
+// that follows it. The last value reports whether there is a frontmatter.
+func splitFrontmatter(data []byte) ([]byte, []byte, bool) {
+	content := string(data)
+
+	if !strings.HasPrefix(content, frontmatterDelim+"\n") {
+		return nil, data, false
+	}
+
+	rest := content[len(frontmatterDelim)+1:]
+
+	if strings.HasPrefix(rest, frontmatterDelim+"\n") || rest == frontmatterDelim {
+		return []byte{}, []byte(strings.TrimPrefix(rest[len(frontmatterDelim):], "\n")), true
+	}
+
+	end := strings.Index(rest, "\n"+frontmatterDelim+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n"+frontmatterDelim) {
+			return nil, data, false
+		}
+
+		end = len(rest) - len(frontmatterDelim) - 1
+	}
+
+	body := rest[end+len(frontmatterDelim)+1:]
+
+	return []byte(rest[:end+1]), []byte(strings.TrimPrefix(body, "\n")), true
+}
+
+// applyFrontmatter reads the frontmatter into the record. Values that are
+// set in the frontmatter take precedence over those found in the markdown.
+func applyFrontmatter(rec *Record, data []byte) error {
+	var fm frontmatter
+
+	if err := yaml.Unmarshal(data, &fm); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidFrontmatter, err)
+	}
+
+	rec.Style = StyleFrontmatter
+
+	if fm.Title != "" {
+		rec.Title = fm.Title
+	}
+
+	if fm.Status != "" {
+		rec.Status = fm.Status
+
+		if link, ok := parseLink(fm.Status); ok {
+			rec.Links = append(rec.Links, link)
+		}
+	}
+
+	if date, err := parseDate(fm.Date); err == nil {
+		rec.Date = date
+	}
+
+	if date, err := parseDate(fm.ReviewBy); err == nil {
+		rec.ReviewBy = date
+	}
+
+	if len(fm.Deciders) > 0 {
+		rec.Deciders = fm.Deciders
+	}
+
+	if len(fm.Consulted) > 0 {
+		rec.Consulted = fm.Consulted
+	}
+
+	if len(fm.Informed) > 0 {
+		rec.Informed = fm.Informed
+	}
+
+	if len(fm.Tags) > 0 {
+		rec.Tags = fm.Tags
+	}
+
+	for _, l := range fm.Links {
+		linkType := l.Type
+
+		if link, ok := parseLink(fmt.Sprintf("%s [%s](%s)", l.Type, l.Title, l.Target)); ok {
+			linkType = link.Type
+		}
+
+		rec.Links = append(rec.Links, Link{Type: linkType, Title: l.Title, Target: l.Target})
+	}
+
+	if len(fm.History) > 0 {
+		rec.History = nil
+	}
+
+	for _, c := range fm.History {
+		date, err := parseDate(c.Date)
+		if err != nil {
+			continue
+		}
+
+		rec.History = append(rec.History, StatusChange{Date: date, Status: c.Status, By: c.By})
+	}
+
+	for _, s := range fm.SignOffs {
+		date, err := parseDate(s.Date)
+		if err != nil {
+			continue
+		}
+
+		verdict := VerdictApproved
+		if strings.EqualFold(s.Verdict, VerdictObjected) {
+			verdict = VerdictObjected
+		}
+
+		rec.SignOffs = append(rec.SignOffs, SignOff{Date: date, Verdict: verdict, By: s.By, Note: s.Note})
+	}
+
+	if len(fm.Imports) > 0 {
+		rec.Imports = fm.Imports
+	}
+
+	if len(fm.Modules) > 0 {
+		rec.Modules = fm.Modules
+	}
+
+	for key, node := range fm.Fields {
+		if node.Kind != yaml.ScalarNode || node.ShortTag() == "!!null" {
+			continue
+		}
+
+		if rec.Fields == nil {
+			rec.Fields = map[string]string{}
+		}
+
+		rec.Fields[key] = node.Value
+	}
+
+	return nil
+}
+
+// marshalFrontmatter produces the frontmatter of the record. Only the links
+// that are given are written, as links within the markdown are kept there.
+func marshalFrontmatter(rec Record, links []Link) ([]byte, error) {
+	fm := frontmatter{
+		Title:     rec.Title,
+		Status:    rec.Status,
+		Deciders:  rec.Deciders,
+		Consulted: rec.Consulted,
+		Informed:  rec.Informed,
+		Tags:      rec.Tags,
+		Imports:   rec.Imports,
+		Modules:   rec.Modules,
+	}
+
+	if !rec.Date.IsZero() {
+		fm.Date = rec.Date.Format(DateFormat)
+	}
+
+	if !rec.ReviewBy.IsZero() {
+		fm.ReviewBy = rec.ReviewBy.Format(DateFormat)
+	}
+
+	for _, l := range links {
+		fm.Links = append(fm.Links, frontmatterLink{Type: l.Type, Title: l.Title, Target: l.Target})
+	}
+
+	for _, c := range rec.History {
+		fm.History = append(fm.History, frontmatterChange{
+			Date:   c.Date.Format(DateFormat),
+			Status: c.Status,
+			By:     c.By,
+		})
+	}
+
+	for _, s := range rec.SignOffs {
+		fm.SignOffs = append(fm.SignOffs, frontmatterSignOff{
+			Date:    s.Date.Format(DateFormat),
+			Verdict: s.Verdict,
+			By:      s.By,
+			Note:    s.Note,
+		})
+	}
+
+	if len(rec.Fields) > 0 {
+		fm.Fields = map[string]yaml.Node{}
+
+		for key, value := range rec.Fields {
+			fm.Fields[key] = yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		}
+	}
+
+	return encodeYAML(&fm)
+}
+
+func encodeYAML(value interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func joinFrontmatter(front []byte, body []byte) []byte {
+	res := []byte(frontmatterDelim + "\n")
+	res = append(res, front...)
+	res = append(res, frontmatterDelim+"\n"...)
+
+	return append(res, body...)
+}
+
+// editFrontmatter applies the edit to the YAML mapping that makes up the
+// frontmatter of the record. The order of the existing keys is kept.
+func editFrontmatter(data []byte, edit func(mapping *yaml.Node)) ([]byte, error) {
+	front, body, _ := splitFrontmatter(data)
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(front, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFrontmatter, err)
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, ErrInvalidFrontmatter
+	}
+
+	edit(mapping)
+
+	out, err := encodeYAML(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return joinFrontmatter(out, body), nil
+}
+
+// lookup returns the value of the key within the mapping, or nil if the key
+// is not set.
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setScalar sets the key of the mapping to the given string, adding the key
+// if it is not set.
+func setScalar(mapping *yaml.Node, key string, value string) {
+	if node := lookup(mapping, key); node != nil {
+		node.Kind, node.Tag, node.Value, node.Style, node.Content = yaml.ScalarNode, "!!str", value, 0, nil
+		return
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
+
+// sequence returns the sequence held by the key of the mapping, adding an
+// empty one if the key is not set.
+func sequence(mapping *yaml.Node, key string) *yaml.Node {
+	if node := lookup(mapping, key); node != nil && node.Kind == yaml.SequenceNode {
+		return node
+	}
+
+	node := &yaml.Node{Kind: yaml.SequenceNode}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = node
+			return node
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+
+	return node
+}
+
+// entry produces a mapping node of the given key and value pairs, leaving
+// out those with an empty value.
+func entry(pairs ...string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: pairs[i]},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pairs[i+1]},
+		)
+	}
+
+	return node
+}
+
+func changeEntry(c StatusChange) *yaml.Node {
+	return entry("date", c.Date.Format(DateFormat), "status", c.Status, "by", c.By)
+}
+
+func linkEntry(l Link) *yaml.Node {
+	return entry("type", l.Type, "title", l.Title, "target", l.Target)
+}
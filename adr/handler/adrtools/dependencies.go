@@ -0,0 +1,32 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=adrtools -mock_names FileSystem=mockFileSystem,RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package adrtools
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	Save(state.State) error
+	StateDir() (string, error)
+}
+
+// FileSystem represents a type that is able to manipulate the filesystem.
+// This interface is typically a wrapper around the os package methods and
+// is used to allow for improved testing.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+// RecordStore represents a type that is able to read and write the records
+// of an adr directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+	Apply(changes ...store.Change) error
+}
@@ -0,0 +1,3 @@
+// Package adrtools provides handler functionality for importing directories
+// that are managed by adr-tools.
+package adrtools
@@ -0,0 +1,9 @@
+package adrtools
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
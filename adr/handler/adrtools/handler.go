@@ -0,0 +1,214 @@
+package adrtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrAlreadyRegistered is returned when the dir, or its name, is already
+	// used by an adr dir of the state file.
+	ErrAlreadyRegistered = errors.New("adr dir already registered")
+
+	// ErrInvalidConfig is returned when the .adr-dir file does not name a
+	// dir within the project.
+	ErrInvalidConfig = errors.New("invalid .adr-dir file")
+)
+
+// Handler describes a type that is used to handle the import command for
+// adr-tools directories.
+type Handler struct {
+	stateManager StateManager
+	fs           FileSystem
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		fs:           &defaultFileSystem{},
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// recordDir returns the path of the records relative to the state dir. The
+// path is read from the .adr-dir file if there is one, otherwise the given
+// path is the dir of the records.
+func (h *Handler) recordDir(stateDir string, from string) (string, error) {
+	if from == "" {
+		from = "."
+	}
+
+	config, err := h.stateManager.NormalizePath(path.Join(from, ConfigName))
+	if err != nil {
+		return "", fmt.Errorf("normalize path: %w", err)
+	}
+
+	base := path.Dir(config)
+
+	data, err := h.fs.ReadFile(stateDir + config)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return base, nil
+	case err != nil:
+		return "", fmt.Errorf("read %s: %w", config, err)
+	}
+
+	dir := path.Join(base, strings.TrimSpace(string(data)))
+
+	if strings.TrimSpace(string(data)) == "" || path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidConfig, config)
+	}
+
+	return dir, nil
+}
+
+func checkRegistered(s state.State, dir adr.Directory) error {
+	for _, d := range s.ADR.Directories {
+		if d.Path == dir.Path || d.Name == dir.Name {
+			return fmt.Errorf("%w: %s", ErrAlreadyRegistered, d.Name)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) rewriteAll(stateDir string, records []adr.Record) (int, error) {
+	var changes []store.Change
+
+	for _, rec := range records {
+		data, err := h.store.Read(stateDir + rec.Path)
+		if err != nil {
+			return 0, err
+		}
+
+		updated, err := adr.Normalize(data, rec)
+		if errors.Is(err, adr.ErrNoStatus) {
+			continue
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("rewrite %s: %w", rec.Path, err)
+		}
+
+		if string(updated) != string(data) {
+			changes = append(changes, store.Change{Path: stateDir + rec.Path, Data: updated})
+		}
+	}
+
+	if err := h.store.Apply(changes...); err != nil {
+		return 0, fmt.Errorf("write records: %w", err)
+	}
+
+	return len(changes), nil
+}
+
+// report writes each of the imported records along with the records they
+// link to.
+func report(out io.Writer, records []adr.Record) {
+	byPath := map[string]adr.Record{}
+	for _, rec := range records {
+		byPath[rec.Path] = rec
+	}
+
+	for _, rec := range records {
+		status := adr.BaseStatus(rec.Status)
+		if status == "" {
+			status = "no status"
+		}
+
+		fmt.Fprintf(out, "%s: %s\n", rec.Path, status)
+
+		for _, l := range rec.Links {
+			target, ok := byPath[l.Resolve(rec.Path)]
+			if !ok {
+				fmt.Fprintf(out, "  %s %s (not found)\n", l.Type, l.Target)
+				continue
+			}
+
+			fmt.Fprintf(out, "  %s %s\n", l.Type, target.Label())
+		}
+	}
+}
+
+// Handle is the main Handler function. This function registers a dir that
+// is managed by adr-tools as a sequential adr dir, and reports the records
+// and links that were found in it. The records are converted to the docula
+// layout when asked to.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	dirPath, err := h.recordDir(stateDir, opts.Path)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = path.Base(dirPath)
+	}
+
+	dir := adr.Directory{
+		Path:  dirPath,
+		Name:  name,
+		Index: adr.IndexSequential,
+	}
+
+	if err = checkRegistered(s, dir); err != nil {
+		return err
+	}
+
+	records, err := h.store.List(stateDir, dir)
+	if err != nil {
+		return fmt.Errorf("list records of %s: %w", dir.Path, err)
+	}
+
+	report(out, records)
+
+	if opts.Rewrite {
+		count, err := h.rewriteAll(stateDir, records)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "rewrote %s\n", adr.Plural(count, "record"))
+	}
+
+	s.ADR.Directories = append(s.ADR.Directories, dir)
+
+	if err = h.stateManager.Save(s); err != nil {
+		return fmt.Errorf("saving state: %w", err)
+	}
+
+	fmt.Fprintf(out, "imported %s from %s as %s\n", adr.Plural(len(records), "record"), dir.Path, dir.Name)
+
+	return nil
+}
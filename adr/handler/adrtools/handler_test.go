@@ -0,0 +1,358 @@
+package adrtools_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/adrtools"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	toolsDir = adr.Directory{Path: "doc/architecture/decisions", Name: "decisions", Index: adr.IndexSequential}
+
+	toolsRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Use PostgreSQL",
+			Status: "Superseded by [2. Use Redis](0002-use-redis.md)",
+			Date:   time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC),
+			Links: []adr.Link{
+				{Type: adr.LinkSupersededBy, Title: "2. Use Redis", Target: "0002-use-redis.md"},
+			},
+			Dir:  "decisions",
+			Path: "doc/architecture/decisions/0001-use-postgresql.md",
+		},
+		{
+			ID:     "0002",
+			Title:  "Use Redis",
+			Status: "Accepted",
+			Date:   time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC),
+			Links: []adr.Link{
+				{Type: adr.LinkSupersedes, Title: "1. Use PostgreSQL", Target: "0001-use-postgresql.md"},
+				{Type: adr.LinkAmends, Title: "7. Gone", Target: "0007-gone.md"},
+			},
+			Dir:  "decisions",
+			Path: "doc/architecture/decisions/0002-use-redis.md",
+		},
+	}
+
+	toolsReport = "doc/architecture/decisions/0001-use-postgresql.md: Superseded\n" +
+		"  Superseded by 0002. Use Redis\n" +
+		"doc/architecture/decisions/0002-use-redis.md: Accepted\n" +
+		"  Supersedes 0001. Use PostgreSQL\n" +
+		"  Amends 0007-gone.md (not found)\n"
+
+	// spelledRecords are written the way adr-tools writes them, which spells
+	// supersede with a c.
+	spelledRecords = map[string]string{
+		"0001-use-postgresql.md": "# 1. Use PostgreSQL\n\nDate: 2016-02-12\n\n## Status\n\n" +
+			"Superceded by [2. Use Redis](0002-use-redis.md)\n\n## Context\n",
+		"0002-use-redis.md": "# 2. Use Redis\n\nDate: 2016-03-01\n\n## Status\n\nAccepted\n\n" +
+			"Supercedes [1. Use PostgreSQL](0001-use-postgresql.md)\n\n## Context\n",
+	}
+
+	existingDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexTimestamp}
+)
+
+// parseSpelled parses the records written the way adr-tools writes them, as
+// the record store would.
+func parseSpelled(t *testing.T) []adr.Record {
+	var records []adr.Record
+
+	for _, name := range []string{"0001-use-postgresql.md", "0002-use-redis.md"} {
+		rec, err := adr.ParseRecord(name, []byte(spelledRecords[name]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rec.Dir = toolsDir.Name
+		rec.Path = toolsDir.Path + "/" + name
+
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) adrtools.StateManager
+		fs           func(ctrl *gomock.Controller) adrtools.FileSystem
+		store        func(ctrl *gomock.Controller) adrtools.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	configFile := func(ctrl *gomock.Controller) adrtools.FileSystem {
+		fs := adrtools.NewmockFileSystem(ctrl)
+		fs.EXPECT().ReadFile("/project/.adr-dir").Return([]byte("doc/architecture/decisions\n"), nil)
+		return fs
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input adrtools.Options
+		wants want
+	}{
+		{
+			name: "dir named by .adr-dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{
+						ADR: adr.State{Directories: []adr.Directory{existingDir}},
+					}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					s.EXPECT().Save(state.State{
+						ADR: adr.State{Directories: []adr.Directory{existingDir, toolsDir}},
+					}).Return(nil)
+					return s
+				},
+				fs: configFile,
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					s := adrtools.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/project/", toolsDir).Return(toolsRecords, nil)
+					return s
+				},
+			},
+			wants: want{
+				output: toolsReport + "imported 2 records from doc/architecture/decisions as decisions\n",
+			},
+		},
+		{
+			name: "dir of records without a state file",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath("doc/adr/.adr-dir").Return("doc/adr/.adr-dir", nil)
+					s.EXPECT().Save(state.State{
+						ADR: adr.State{Directories: []adr.Directory{
+							{Path: "doc/adr", Name: "legacy", Index: adr.IndexSequential},
+						}},
+					}).Return(nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) adrtools.FileSystem {
+					fs := adrtools.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile("/project/doc/adr/.adr-dir").Return(nil, os.ErrNotExist)
+					return fs
+				},
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					s := adrtools.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/project/", adr.Directory{Path: "doc/adr", Name: "legacy", Index: adr.IndexSequential}).Return(nil, nil)
+					return s
+				},
+			},
+			input: adrtools.Options{Path: "doc/adr", Name: "legacy"},
+			wants: want{
+				output: "imported 0 records from doc/adr as legacy\n",
+			},
+		},
+		{
+			name: "rewriting records",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					s.EXPECT().Save(state.State{
+						ADR: adr.State{Directories: []adr.Directory{toolsDir}},
+					}).Return(nil)
+					return s
+				},
+				fs: configFile,
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					s := adrtools.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/project/", toolsDir).Return(toolsRecords, nil)
+					s.EXPECT().Read("/project/doc/architecture/decisions/0001-use-postgresql.md").Return([]byte(
+						"# 1. Use PostgreSQL\n\nDate: 2016-02-12\n\n## Status\n\n"+
+							"Superseded by [2. Use Redis](0002-use-redis.md)\n\n## Context\n",
+					), nil)
+					s.EXPECT().Read("/project/doc/architecture/decisions/0002-use-redis.md").Return([]byte(
+						"# Use Redis\n\n## Status\n\nAccepted\n\n<!-- docula:status-history -->\n"+
+							"- 2016-03-01: Accepted\n<!-- /docula:status-history -->\n",
+					), nil)
+					s.EXPECT().Apply(store.Change{
+						Path: "/project/doc/architecture/decisions/0001-use-postgresql.md",
+						Data: []byte(
+							"# Use PostgreSQL\n\nDate: 2016-02-12\n\n## Status\n\n" +
+								"Superseded by [2. Use Redis](0002-use-redis.md)\n\n" +
+								"<!-- docula:status-history -->\n- 2016-02-12: Accepted\n<!-- /docula:status-history -->\n\n" +
+								"## Context\n",
+						),
+					}).Return(nil)
+					return s
+				},
+			},
+			input: adrtools.Options{Rewrite: true},
+			wants: want{
+				output: toolsReport +
+					"rewrote 1 record\n" +
+					"imported 2 records from doc/architecture/decisions as decisions\n",
+			},
+		},
+		{
+			name: "records spelling supersede with a c",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					s.EXPECT().Save(state.State{
+						ADR: adr.State{Directories: []adr.Directory{toolsDir}},
+					}).Return(nil)
+					return s
+				},
+				fs: configFile,
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					s := adrtools.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/project/", toolsDir).Return(parseSpelled(t), nil)
+					s.EXPECT().Read("/project/doc/architecture/decisions/0001-use-postgresql.md").Return(
+						[]byte(spelledRecords["0001-use-postgresql.md"]), nil,
+					)
+					s.EXPECT().Read("/project/doc/architecture/decisions/0002-use-redis.md").Return(
+						[]byte(spelledRecords["0002-use-redis.md"]), nil,
+					)
+					s.EXPECT().Apply(
+						store.Change{
+							Path: "/project/doc/architecture/decisions/0001-use-postgresql.md",
+							Data: []byte(
+								"# Use PostgreSQL\n\nDate: 2016-02-12\n\n## Status\n\n" +
+									"Superseded by [2. Use Redis](0002-use-redis.md)\n\n" +
+									"<!-- docula:status-history -->\n- 2016-02-12: Accepted\n<!-- /docula:status-history -->\n\n" +
+									"## Context\n",
+							),
+						},
+						store.Change{
+							Path: "/project/doc/architecture/decisions/0002-use-redis.md",
+							Data: []byte(
+								"# Use Redis\n\nDate: 2016-03-01\n\n## Status\n\nAccepted\n\n" +
+									"<!-- docula:status-history -->\n- 2016-03-01: Accepted\n<!-- /docula:status-history -->\n\n" +
+									"Supersedes [1. Use PostgreSQL](0001-use-postgresql.md)\n\n## Context\n",
+							),
+						},
+					).Return(nil)
+					return s
+				},
+			},
+			input: adrtools.Options{Rewrite: true},
+			wants: want{
+				output: "doc/architecture/decisions/0001-use-postgresql.md: Superseded\n" +
+					"  Superseded by 0002. Use Redis\n" +
+					"doc/architecture/decisions/0002-use-redis.md: Accepted\n" +
+					"  Supersedes 0001. Use PostgreSQL\n" +
+					"rewrote 2 records\n" +
+					"imported 2 records from doc/architecture/decisions as decisions\n",
+			},
+		},
+		{
+			name: "dir already registered",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{
+						ADR: adr.State{Directories: []adr.Directory{toolsDir}},
+					}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					return s
+				},
+				fs: configFile,
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					return adrtools.NewmockRecordStore(ctrl)
+				},
+			},
+			wants: want{
+				err: adrtools.ErrAlreadyRegistered,
+			},
+		},
+		{
+			name: ".adr-dir outside of the project",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) adrtools.FileSystem {
+					fs := adrtools.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile("/project/.adr-dir").Return([]byte("../elsewhere\n"), nil)
+					return fs
+				},
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					return adrtools.NewmockRecordStore(ctrl)
+				},
+			},
+			wants: want{
+				err: adrtools.ErrInvalidConfig,
+			},
+		},
+		{
+			name: "failing to list records",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) adrtools.StateManager {
+					s := adrtools.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/project/", nil)
+					s.EXPECT().NormalizePath(".adr-dir").Return(".adr-dir", nil)
+					return s
+				},
+				fs: configFile,
+				store: func(ctrl *gomock.Controller) adrtools.RecordStore {
+					s := adrtools.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/project/", toolsDir).Return(nil, os.ErrNotExist)
+					return s
+				},
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := adrtools.New(
+				adrtools.WithStateManager(tt.setup.stateManager(ctrl)),
+				adrtools.WithFileSystem(tt.setup.fs(ctrl)),
+				adrtools.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wants.output, out.String())
+			}
+		})
+	}
+}
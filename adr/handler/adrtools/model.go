@@ -0,0 +1,17 @@
+package adrtools
+
+// ConfigName is the name of the file adr-tools uses to store the location of
+// its records, relative to the dir the file is in.
+const ConfigName = ".adr-dir"
+
+// Options represents the input of the import command.
+type Options struct {
+	// Path is either the dir holding the .adr-dir file, or the dir of the
+	// records when there is no such file.
+	Path string
+	// Name is the name of the registered dir. It defaults to the last
+	// element of the path of the records.
+	Name string
+	// Rewrite converts the records into the layout docula writes.
+	Rewrite bool
+}
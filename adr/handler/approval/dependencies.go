@@ -0,0 +1,31 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=approval -mock_names Identity=mockIdentity,RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package approval
+
+import (
+	"context"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to find, read and write the
+// records of the adr directories.
+type RecordStore interface {
+	Find(stateDir string, dirs []adr.Directory, id string) (adr.Record, error)
+	Read(path string) ([]byte, error)
+	Write(path string, data []byte) error
+}
+
+// Identity represents a type that is able to identify the current user.
+type Identity interface {
+	UserName(ctx context.Context) (string, error)
+	UserEmail(ctx context.Context) (string, error)
+}
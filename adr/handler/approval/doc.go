@@ -0,0 +1,3 @@
+// Package approval provides handler functionality for the commands that sign
+// off on a proposed decision record, namely approve and object.
+package approval
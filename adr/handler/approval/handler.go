@@ -0,0 +1,155 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/git"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrUnknownVerdict is returned when the verdict is neither approved
+	// nor objected.
+	ErrUnknownVerdict = errors.New("unknown verdict")
+
+	// ErrNotPending is returned when signing off on a record that is
+	// already accepted, or in a terminal status of its workflow.
+	ErrNotPending = errors.New("record is not pending a decision")
+)
+
+// Handler describes a type that is used to handle the approve and object
+// commands.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	identity     Identity
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		identity:     git.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// signer returns the git user signing off, such as "Jane Doe
+// <jane@example.com>". Unlike status changes, a sign-off is never
+// unattributed, so a git user name is required.
+func (h *Handler) signer(ctx context.Context) (string, error) {
+	name, err := h.identity.UserName(ctx)
+	if err != nil {
+		return "", fmt.Errorf("identify signer: %w", err)
+	}
+
+	email, err := h.identity.UserEmail(ctx)
+	if err != nil {
+		return name, nil
+	}
+
+	return fmt.Sprintf("%s <%s>", name, email), nil
+}
+
+// Handle is the main Handler function. This function records an approval of,
+// or an objection to, a record that is pending a decision, signed off by the
+// git user. The standing of the record against the quorum of its directory is
+// reported afterwards.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	if opts.Verdict != adr.VerdictApproved && opts.Verdict != adr.VerdictObjected {
+		return fmt.Errorf("%w: %s", ErrUnknownVerdict, opts.Verdict)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	rec, err := h.store.Find(stateDir, dirs, opts.ID)
+	if err != nil {
+		return fmt.Errorf("find record: %w", err)
+	}
+
+	dir, _ := s.ADR.FindDirectory(rec.Dir)
+
+	if rec.HasStatus(adr.StatusAccepted) || dir.StatusWorkflow().IsTerminal(rec.Status) {
+		return fmt.Errorf("%w: %s is %s", ErrNotPending, rec.Path, rec.Status)
+	}
+
+	by, err := h.signer(ctx)
+	if err != nil {
+		return err
+	}
+
+	signOff := adr.SignOff{
+		Date:    h.now(),
+		Verdict: opts.Verdict,
+		By:      by,
+		Note:    opts.Note,
+	}
+
+	path := stateDir + rec.Path
+
+	data, err := h.store.Read(path)
+	if err != nil {
+		return err
+	}
+
+	data, err = adr.AddSignOff(data, signOff)
+	if err != nil {
+		return fmt.Errorf("sign off on %s: %w", rec.Path, err)
+	}
+
+	if err = h.store.Write(path, data); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+
+	rec.SignOffs = append(rec.SignOffs, signOff)
+
+	fmt.Fprintf(out, "%s: %s by %s%s\n", rec.Path, strings.ToLower(opts.Verdict), by, standing(dir, rec))
+
+	return nil
+}
+
+// standing describes the tally of the record against the quorum of its
+// directory, or nothing if the directory has no quorum.
+func standing(dir adr.Directory, rec adr.Record) string {
+	if dir.Quorum == nil {
+		return ""
+	}
+
+	tally := dir.Tally(rec)
+	if tally.Met {
+		return fmt.Sprintf(" (%s, quorum met)", adr.Plural(len(tally.Approvers), "approval"))
+	}
+
+	return fmt.Sprintf(" (%s)", tally.Shortfall())
+}
@@ -0,0 +1,384 @@
+package approval_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/approval"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/git"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Quorum: &adr.Quorum{Approvals: 2}}
+	securityDir = adr.Directory{Path: "security", Name: "security"}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	proposed = adr.Record{
+		ID:       "0012",
+		Title:    "Use PostgreSQL",
+		Status:   "Proposed",
+		Deciders: []string{"Jane Doe", "@jsmith"},
+		Dir:      "platform",
+		Path:     "docs/adr/0012-use-postgresql.md",
+	}
+)
+
+const proposedRecord = `# Use PostgreSQL
+
+Date: 2022-07-01
+Deciders: Jane Doe, @jsmith
+
+## Status
+
+Proposed
+
+## Context
+`
+
+const approvedRecord = `# Use PostgreSQL
+
+Date: 2022-07-01
+Deciders: Jane Doe, @jsmith
+
+## Status
+
+Proposed
+
+<!-- docula:sign-offs -->
+- 2022-07-14: Approved by Jane Doe <jane@example.com>
+<!-- /docula:sign-offs -->
+
+## Context
+`
+
+func clock() time.Time {
+	return time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) approval.StateManager
+		store        func(ctrl *gomock.Controller) approval.RecordStore
+		identity     func(ctrl *gomock.Controller) approval.Identity
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	defaultStateManager := func(ctrl *gomock.Controller) approval.StateManager {
+		s := approval.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	gitUser := func(ctrl *gomock.Controller) approval.Identity {
+		i := approval.NewmockIdentity(ctrl)
+		i.EXPECT().UserName(gomock.Any()).Return("Jane Doe", nil)
+		i.EXPECT().UserEmail(gomock.Any()).Return("jane@example.com", nil)
+		return i
+	}
+
+	noIdentity := func(ctrl *gomock.Controller) approval.Identity {
+		return approval.NewmockIdentity(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input approval.Options
+		wants want
+	}{
+		{
+			name: "approving a proposed record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", []byte(approvedRecord)).Return(nil)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: approved by Jane Doe <jane@example.com> (1 of 2 approvals)\n",
+			},
+		},
+		{
+			name: "approval that meets the quorum",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					rec := proposed
+					rec.SignOffs = []adr.SignOff{{Date: clock(), Verdict: adr.VerdictApproved, By: "John Smith <jsmith@example.com>"}}
+
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).Return(nil)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: approved by Jane Doe <jane@example.com> (2 approvals, quorum met)\n",
+			},
+		},
+		{
+			name: "approval beyond the quorum",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					rec := proposed
+					rec.Deciders = append(rec.Deciders, "Alice Jones")
+					rec.SignOffs = []adr.SignOff{
+						{Date: clock(), Verdict: adr.VerdictApproved, By: "John Smith <jsmith@example.com>"},
+						{Date: clock(), Verdict: adr.VerdictApproved, By: "Alice Jones <alice@example.com>"},
+					}
+
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).Return(nil)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: approved by Jane Doe <jane@example.com> (3 approvals, quorum met)\n",
+			},
+		},
+		{
+			name: "objecting with a note",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).DoAndReturn(
+						func(path string, data []byte) error {
+							assert.Contains(t, string(data), "- 2022-07-14: Objected by Jane Doe <jane@example.com>: too costly\n")
+							return nil
+						},
+					)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictObjected, Note: "too costly"},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: objected by Jane Doe <jane@example.com> " +
+					"(0 of 2 approvals, open objection by Jane Doe <jane@example.com>)\n",
+			},
+		},
+		{
+			name: "directory without a quorum",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) approval.StateManager {
+					s := approval.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					rec := proposed
+					rec.Dir = "security"
+					rec.Path = "security/0012-use-postgresql.md"
+
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", []adr.Directory{securityDir}, "12").Return(rec, nil)
+					s.EXPECT().Read("/security/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/security/0012-use-postgresql.md", gomock.Any()).Return(nil)
+					return s
+				},
+				identity: func(ctrl *gomock.Controller) approval.Identity {
+					i := approval.NewmockIdentity(ctrl)
+					i.EXPECT().UserName(gomock.Any()).Return("Jane Doe", nil)
+					i.EXPECT().UserEmail(gomock.Any()).Return("", git.ErrNoIdentity)
+					return i
+				},
+			},
+			input: approval.Options{ID: "12", Dir: "security", Verdict: adr.VerdictApproved},
+			wants: want{
+				output: "security/0012-use-postgresql.md: approved by Jane Doe\n",
+			},
+		},
+		{
+			name: "accepted record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					rec := proposed
+					rec.Status = adr.StatusAccepted
+
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				err: approval.ErrNotPending,
+			},
+		},
+		{
+			name: "rejected record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					rec := proposed
+					rec.Status = adr.StatusRejected
+
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictObjected},
+			wants: want{
+				err: approval.ErrNotPending,
+			},
+		},
+		{
+			name: "no git user",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					return s
+				},
+				identity: func(ctrl *gomock.Controller) approval.Identity {
+					i := approval.NewmockIdentity(ctrl)
+					i.EXPECT().UserName(gomock.Any()).Return("", git.ErrNoIdentity)
+					return i
+				},
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				err: git.ErrNoIdentity,
+			},
+		},
+		{
+			name: "unknown verdict",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) approval.StateManager {
+					return approval.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					return approval.NewmockRecordStore(ctrl)
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "12", Verdict: "Abstained"},
+			wants: want{
+				err: approval.ErrUnknownVerdict,
+			},
+		},
+		{
+			name: "unknown record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					s := approval.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "99").Return(adr.Record{}, store.ErrRecordNotFound)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "99", Verdict: adr.VerdictApproved},
+			wants: want{
+				err: store.ErrRecordNotFound,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) approval.StateManager {
+					s := approval.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					return approval.NewmockRecordStore(ctrl)
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "12", Dir: "nope", Verdict: adr.VerdictApproved},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) approval.StateManager {
+					s := approval.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) approval.RecordStore {
+					return approval.NewmockRecordStore(ctrl)
+				},
+				identity: noIdentity,
+			},
+			input: approval.Options{ID: "12", Verdict: adr.VerdictApproved},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := approval.New(
+				approval.WithStateManager(tt.setup.stateManager(ctrl)),
+				approval.WithRecordStore(tt.setup.store(ctrl)),
+				approval.WithIdentity(tt.setup.identity(ctrl)),
+				approval.WithClock(clock),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
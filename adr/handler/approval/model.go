@@ -0,0 +1,15 @@
+package approval
+
+// Options represents the sign-off that is requested by the user.
+type Options struct {
+	// ID is the identifier of the record to sign off on.
+	ID string
+	// Dir optionally restricts the lookup of the record to a single adr
+	// dir, selected by name or path.
+	Dir string
+	// Verdict is the verdict of the sign-off, either approved or objected.
+	Verdict string
+	// Note optionally explains the verdict, such as the reason of an
+	// objection.
+	Note string
+}
@@ -0,0 +1,32 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=check -mock_names FileSystem=mockFileSystem,RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package check
+
+import (
+	"os"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to list the records of an adr
+// directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+}
+
+// FileSystem provides an interface that can interact with the file system.
+// This interface is primarily used for testing. All of these methods are
+// found in the `os` package.
+type FileSystem interface {
+	ReadDir(name string) ([]os.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Stat(name string) (os.FileInfo, error)
+}
@@ -0,0 +1,4 @@
+// Package check provides handler functionality for the adr check command,
+// which enforces the import rules declared by accepted decision records
+// against the Go packages of the project.
+package check
@@ -0,0 +1,169 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrViolations is returned when any of the import rules is violated.
+var ErrViolations = errors.New("import rules violated")
+
+// Handler describes a type that is used to handle the check command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// rule represents an import rule along with the record declaring it.
+type rule struct {
+	adr.ImportRule
+
+	Record adr.Record
+}
+
+// violation represents an import that a rule denies.
+type violation struct {
+	spec importSpec
+	from string
+	rule rule
+}
+
+// rules collects the import rules of the accepted records of the dirs. Rules
+// of records that are not accepted, such as superseded ones, are retired.
+func (h *Handler) rules(stateDir string, dirs []adr.Directory) ([]rule, error) {
+	var rules []rule
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return nil, fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range records {
+			if !rec.HasStatus(adr.StatusAccepted) {
+				continue
+			}
+
+			for _, r := range rec.Imports {
+				if err = r.Validate(); err != nil {
+					return nil, fmt.Errorf("%s: %w", rec.Path, err)
+				}
+
+				rules = append(rules, rule{ImportRule: r, Record: rec})
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// violations returns the imports of the packages that are denied by any of
+// the rules, ordered by file and line.
+func violations(pkgs []pkg, rules []rule) []violation {
+	var found []violation
+
+	for _, p := range pkgs {
+		for _, r := range rules {
+			if !adr.MatchPackage(r.From, p.ImportPath, p.Module.Path) {
+				continue
+			}
+
+			for _, spec := range p.Imports {
+				for _, deny := range r.Deny {
+					if adr.MatchPackage(deny, spec.Path, p.Module.Path) {
+						found = append(found, violation{spec: spec, from: p.ImportPath, rule: r})
+						break
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].spec.File != found[j].spec.File {
+			return found[i].spec.File < found[j].spec.File
+		}
+
+		return found[i].spec.Line < found[j].spec.Line
+	})
+
+	return found
+}
+
+// Handle is the main Handler function. This function loads the Go packages
+// of the project, and reports every import that is denied by an import rule
+// of an accepted record. If any import is denied, then ErrViolations is
+// returned.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	rules, err := h.rules(stateDir, dirs)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		fmt.Fprintln(out, "no import rules declared by accepted records")
+		return nil
+	}
+
+	pkgs, err := h.packages(stateDir)
+	if err != nil {
+		return err
+	}
+
+	found := violations(pkgs, rules)
+
+	if len(found) == 0 {
+		fmt.Fprintf(out, "no violations of %s across %s\n", adr.Plural(len(rules), "import rule"), adr.Plural(len(pkgs), "package"))
+		return nil
+	}
+
+	for _, v := range found {
+		fmt.Fprintf(out, "%s:%d: %s imports %s, denied by %s:%s %s\n",
+			v.spec.File, v.spec.Line, v.from, v.spec.Path, v.rule.Record.Dir, v.rule.Record.ID, v.rule.Record.Title)
+	}
+
+	fmt.Fprintln(out, adr.Plural(len(found), "violation"))
+
+	return fmt.Errorf("%w: %s", ErrViolations, adr.Plural(len(found), "violation"))
+}
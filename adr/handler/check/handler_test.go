@@ -0,0 +1,294 @@
+package check_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/check"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:      "0007",
+			Title:   "Keep billing apart",
+			Status:  "Accepted",
+			Imports: []adr.ImportRule{{From: "internal/billing/...", Deny: []string{"internal/auth/..."}}},
+			Dir:     "platform",
+			Path:    "docs/adr/0007-keep-billing-apart.md",
+		},
+		{
+			ID:      "0008",
+			Title:   "Isolate tools",
+			Status:  "Accepted",
+			Imports: []adr.ImportRule{{From: "gen/...", Deny: []string{"example.com/shop/internal/..."}}},
+			Dir:     "platform",
+			Path:    "docs/adr/0008-isolate-tools.md",
+		},
+		{
+			ID:      "0009",
+			Title:   "Keep auth apart",
+			Status:  "Superseded by [0007. Keep billing apart](0007-keep-billing-apart.md)",
+			Imports: []adr.ImportRule{{From: "internal/auth/...", Deny: []string{"internal/billing"}}},
+			Dir:     "platform",
+			Path:    "docs/adr/0009-keep-auth-apart.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:      "0001",
+			Title:   "Proposed boundary",
+			Status:  "Proposed",
+			Imports: []adr.ImportRule{{From: "...", Deny: []string{"fmt"}}},
+			Dir:     "security",
+			Path:    "security/0001-proposed-boundary.md",
+		},
+	}
+
+	project = fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop // the shop\n\ngo 1.18\n")},
+		"internal/billing/invoice.go": {Data: []byte("package billing\n\nimport (\n\t\"fmt\"\n" +
+			"\t\"example.com/shop/internal/auth\"\n)\n")},
+		"internal/billing/invoice_test.go": {Data: []byte("package billing\n\nimport \"example.com/shop/internal/auth/token\"\n")},
+		"internal/billing/legacy.go": {Data: []byte("//go:build ignore\n\npackage billing\n\n" +
+			"import \"example.com/shop/internal/auth\"\n")},
+		"internal/auth/auth.go":               {Data: []byte("package auth\n")},
+		"internal/auth/token/token.go":        {Data: []byte("package token\n\nimport \"example.com/shop/internal/billing\"\n")},
+		"vendor/lib/lib.go":                   {Data: []byte("package lib\n\nimport \"example.com/shop/internal/auth\"\n")},
+		"tools/go.mod":                        {Data: []byte("module \"example.com/shop/tools\"\n")},
+		"tools/gen/gen.go":                    {Data: []byte("package gen\n\nimport \"example.com/shop/internal/auth\"\n")},
+		"docs/adr/0007-keep-billing-apart.md": {Data: []byte("# Keep billing apart\n")},
+	}
+)
+
+// filesOf produces a file system that reads the given files, which are
+// relative to the state dir.
+func filesOf(files fstest.MapFS) func(ctrl *gomock.Controller) check.FileSystem {
+	name := func(p string) string {
+		if p = strings.Trim(p, "/"); p == "" {
+			return "."
+		}
+
+		return p
+	}
+
+	return func(ctrl *gomock.Controller) check.FileSystem {
+		fsys := check.NewmockFileSystem(ctrl)
+
+		fsys.EXPECT().ReadDir(gomock.Any()).DoAndReturn(func(p string) ([]os.DirEntry, error) {
+			return fs.ReadDir(files, name(p))
+		}).AnyTimes()
+
+		fsys.EXPECT().ReadFile(gomock.Any()).DoAndReturn(func(p string) ([]byte, error) {
+			return fs.ReadFile(files, name(p))
+		}).AnyTimes()
+
+		fsys.EXPECT().Stat(gomock.Any()).DoAndReturn(func(p string) (os.FileInfo, error) {
+			return fs.Stat(files, name(p))
+		}).AnyTimes()
+
+		return fsys
+	}
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) check.StateManager
+		store        func(ctrl *gomock.Controller) check.RecordStore
+		fs           func(ctrl *gomock.Controller) check.FileSystem
+	}
+
+	type want struct {
+		err    error
+		anyErr bool
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) check.StateManager {
+		sm := check.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) check.RecordStore {
+		rs := check.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	testCases := []struct {
+		name  string
+		opts  check.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           filesOf(project),
+			},
+			wants: want{
+				err: check.ErrViolations,
+				output: "internal/billing/invoice.go:5: example.com/shop/internal/billing imports " +
+					"example.com/shop/internal/auth, denied by platform:0007 Keep billing apart\n" +
+					"internal/billing/invoice_test.go:3: example.com/shop/internal/billing imports " +
+					"example.com/shop/internal/auth/token, denied by platform:0007 Keep billing apart\n" +
+					"tools/gen/gen.go:3: example.com/shop/tools/gen imports " +
+					"example.com/shop/internal/auth, denied by platform:0008 Isolate tools\n" +
+					"3 violations\n",
+			},
+		},
+		{
+			name: "no violations",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: filesOf(fstest.MapFS{
+					"go.mod":                      project["go.mod"],
+					"internal/billing/invoice.go": {Data: []byte("package billing\n\nimport \"fmt\"\n")},
+					"internal/auth/auth.go":       project["internal/auth/auth.go"],
+				}),
+			},
+			wants: want{
+				output: "no violations of 2 import rules across 2 packages\n",
+			},
+		},
+		{
+			name: "no rules",
+			opts: check.Options{Dir: "security"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           filesOf(project),
+			},
+			wants: want{
+				output: "no import rules declared by accepted records\n",
+			},
+		},
+		{
+			name: "invalid rule",
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) check.RecordStore {
+					rs := check.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return([]adr.Record{{
+						ID:      "0010",
+						Status:  "Accepted",
+						Imports: []adr.ImportRule{{From: "internal/..."}},
+						Path:    "docs/adr/0010-broken.md",
+					}}, nil)
+
+					return rs
+				},
+				fs: filesOf(project),
+			},
+			wants: want{
+				err: adr.ErrInvalidImportRule,
+			},
+		},
+		{
+			name: "unparsable file",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: filesOf(fstest.MapFS{
+					"go.mod":                      project["go.mod"],
+					"internal/billing/invoice.go": {Data: []byte("package billing\n\nimport (\n")},
+				}),
+			},
+			wants: want{
+				anyErr: true,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: check.Options{Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) check.StateManager {
+					sm := check.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+				fs:    filesOf(project),
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) check.StateManager {
+					sm := check.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+				fs:    filesOf(project),
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := check.New(
+				check.WithStateManager(tt.setup.stateManager(ctrl)),
+				check.WithRecordStore(tt.setup.store(ctrl)),
+				check.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+
+			switch {
+			case tt.wants.anyErr:
+				assert.Error(t, err)
+			case tt.wants.err != nil:
+				assert.ErrorIs(t, err, tt.wants.err)
+			default:
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package check
+
+// Options represents the input of the check command.
+type Options struct {
+	// Dir limits the check to the rules of the records of a single adr
+	// directory, by name or path.
+	Dir string
+}
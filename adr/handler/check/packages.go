@@ -0,0 +1,207 @@
+package check
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/gomod"
+)
+
+// module represents a Go module of the project.
+type module struct {
+	// Path is the module path declared by its go.mod file.
+	Path string
+	// Dir is the root of the module, relative to the state dir.
+	Dir string
+}
+
+// pkg represents a Go package along with the imports of its files.
+type pkg struct {
+	ImportPath string
+	Module     module
+	Imports    []importSpec
+}
+
+// importSpec represents a single import of a Go file.
+type importSpec struct {
+	Path string
+	// File is the location of the importing file, relative to the state dir.
+	File string
+	Line int
+}
+
+// context produces a build context that reads the project through the
+// file system of the handler.
+func (h *Handler) context() build.Context {
+	ctxt := build.Default
+
+	ctxt.ReadDir = func(dir string) ([]fs.FileInfo, error) {
+		entries, err := h.fs.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		infos := make([]fs.FileInfo, 0, len(entries))
+
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			infos = append(infos, info)
+		}
+
+		return infos, nil
+	}
+
+	ctxt.OpenFile = func(name string) (io.ReadCloser, error) {
+		data, err := h.fs.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	ctxt.IsDir = func(name string) bool {
+		info, err := h.fs.Stat(name)
+		return err == nil && info.IsDir()
+	}
+
+	return ctxt
+}
+
+// packages walks the project for Go packages. A dir belongs to the module
+// of the closest go.mod file above it, so nested modules are loaded with
+// their own module path. Dirs outside of any module are skipped.
+func (h *Handler) packages(stateDir string) ([]pkg, error) {
+	var pkgs []pkg
+
+	ctxt := h.context()
+
+	var walk func(rel string, mod *module) error
+
+	walk = func(rel string, mod *module) error {
+		dir := stateDir + rel
+
+		entries, err := h.fs.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("read dir %s: %w", rel, err)
+		}
+
+		for _, entry := range entries {
+			if entry.Name() != gomod.FileName || entry.IsDir() {
+				continue
+			}
+
+			data, err := h.fs.ReadFile(path.Join(dir, gomod.FileName))
+			if err != nil {
+				return fmt.Errorf("read %s: %w", path.Join(rel, gomod.FileName), err)
+			}
+
+			mod = &module{Path: gomod.Parse(data).Module, Dir: rel}
+		}
+
+		if mod != nil {
+			p, err := h.load(ctxt, stateDir, rel, *mod)
+			if err != nil {
+				return err
+			}
+
+			if p != nil {
+				pkgs = append(pkgs, *p)
+			}
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+
+			if !entry.IsDir() || adr.SkipDir(name) || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				continue
+			}
+
+			if err = walk(path.Join(rel, name), mod); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk("", nil); err != nil {
+		return nil, err
+	}
+
+	return pkgs, nil
+}
+
+// load reads the imports of the package within the dir, including those of
+// its tests. Files excluded by build constraints are skipped. A dir without
+// Go files yields no package.
+func (h *Handler) load(ctxt build.Context, stateDir string, rel string, mod module) (*pkg, error) {
+	bp, err := ctxt.ImportDir(stateDir+rel, 0)
+
+	var noGo *build.NoGoError
+
+	switch {
+	case errors.As(err, &noGo):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("load package %s: %w", rel, err)
+	}
+
+	importPath := mod.Path
+	if sub := strings.TrimPrefix(strings.TrimPrefix(rel, mod.Dir), "/"); sub != "" {
+		importPath = path.Join(mod.Path, sub)
+	}
+
+	p := &pkg{ImportPath: importPath, Module: mod}
+	fset := token.NewFileSet()
+
+	var files []string
+
+	files = append(files, bp.GoFiles...)
+	files = append(files, bp.CgoFiles...)
+	files = append(files, bp.TestGoFiles...)
+	files = append(files, bp.XTestGoFiles...)
+
+	for _, name := range files {
+		filePath := path.Join(rel, name)
+
+		data, err := h.fs.ReadFile(stateDir + filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", filePath, err)
+		}
+
+		f, err := parser.ParseFile(fset, filePath, data, parser.ImportsOnly)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filePath, err)
+		}
+
+		for _, spec := range f.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+
+			p.Imports = append(p.Imports, importSpec{
+				Path: importPath,
+				File: filePath,
+				Line: fset.Position(spec.Pos()).Line,
+			})
+		}
+	}
+
+	return p, nil
+}
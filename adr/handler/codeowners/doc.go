@@ -0,0 +1,4 @@
+// Package codeowners provides handler functionality for the adr codeowners
+// command, which generates CODEOWNERS entries so that changes to the adr
+// directories and their records request review from the deciders.
+package codeowners
@@ -0,0 +1,22 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+)
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// WriteFile writes the file, creating its parent directories, such as
+// .github, if they do not exist.
+func (f *defaultFileSystem) WriteFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, data, 0o644)
+}
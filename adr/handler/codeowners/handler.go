@@ -0,0 +1,195 @@
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// owner matches the owners that CODEOWNERS accepts: a user, a team of an
+// organization, or an email address.
+var owner = regexp.MustCompile(`^(@[\w.-]+(/[\w.-]+)?|[^@\s]+@[^@\s]+\.[^@\s]+)$`)
+
+// Handler describes a type that is used to handle the codeowners command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// pattern produces the CODEOWNERS pattern of a path relative to the root of
+// the repository.
+func pattern(path string) string {
+	return "/" + strings.ReplaceAll(path, " ", `\ `)
+}
+
+// entries holds the generated lines, noting any deciders that cannot be
+// used as owners.
+type entries struct {
+	lines []string
+	count int
+}
+
+func (e *entries) add(pattern string, label string, deciders []string) {
+	var owners, skipped []string
+
+	for _, d := range deciders {
+		if owner.MatchString(d) {
+			owners = append(owners, d)
+		} else {
+			skipped = append(skipped, d)
+		}
+	}
+
+	if len(skipped) > 0 {
+		e.lines = append(e.lines, fmt.Sprintf("# %s: skipped %s, not a @user, @org/team or email",
+			label, strings.Join(skipped, ", ")))
+	}
+
+	if len(owners) > 0 {
+		e.lines = append(e.lines, fmt.Sprintf("%s %s", pattern, strings.Join(owners, " ")))
+		e.count++
+	}
+}
+
+// markers returns the markers surrounding the entries of the directory, so
+// that the entries of each directory can be kept up to date on their own.
+func markers(dir adr.Directory) (string, string) {
+	return fmt.Sprintf("%s %q", sectionStart, dir.Name), fmt.Sprintf("%s %q", sectionEnd, dir.Name)
+}
+
+// generate produces the section of entries of the directory. The entry of
+// the directory is owned by the deciders of its accepted records, and is
+// followed by an entry for every record with deciders, as later entries take
+// precedence.
+func (h *Handler) generate(stateDir string, dir adr.Directory) (string, int, error) {
+	records, err := h.store.List(stateDir, dir)
+	if err != nil {
+		return "", 0, fmt.Errorf("list records of %s: %w", dir.Name, err)
+	}
+
+	var (
+		deciders []string
+		seen     = map[string]bool{}
+	)
+
+	for _, rec := range records {
+		if !rec.HasStatus(adr.StatusAccepted) {
+			continue
+		}
+
+		for _, d := range rec.Deciders {
+			if key := strings.ToLower(d); !seen[key] {
+				seen[key] = true
+				deciders = append(deciders, d)
+			}
+		}
+	}
+
+	start, end := markers(dir)
+
+	e := &entries{lines: []string{start, "# Generated by docula adr codeowners, do not edit."}}
+	e.add(pattern(dir.Path+"/"), dir.Name, deciders)
+
+	for _, rec := range records {
+		if len(rec.Deciders) > 0 {
+			e.add(pattern(rec.Path), fmt.Sprintf("%s:%s", rec.Dir, rec.ID), rec.Deciders)
+		}
+	}
+
+	e.lines = append(e.lines, end)
+
+	return strings.Join(e.lines, "\n"), e.count, nil
+}
+
+// Handle is the main Handler function. This function prints the CODEOWNERS
+// entries of the adr directories and their records, or keeps them up to
+// date within the CODEOWNERS file of the options. Only the entries of the
+// selected directories are written, leaving those of the other directories
+// as they are.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	sections := make([]string, len(dirs))
+	count := 0
+
+	for i, dir := range dirs {
+		section, n, err := h.generate(stateDir, dir)
+		if err != nil {
+			return err
+		}
+
+		sections[i] = section
+		count += n
+	}
+
+	if opts.Write == "" {
+		fmt.Fprintln(out, strings.Join(sections, "\n\n"))
+		return nil
+	}
+
+	path, err := h.stateManager.NormalizePath(opts.Write)
+	if err != nil {
+		return fmt.Errorf("normalize path: %w", err)
+	}
+
+	data, err := h.fs.ReadFile(stateDir + path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	for i, dir := range dirs {
+		start, end := markers(dir)
+
+		if data, err = adr.ReplaceRegion(data, start, end, sections[i]); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err = h.fs.WriteFile(stateDir+path, data); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "wrote %s to %s\n", adr.Plural(count, "entry"), path)
+
+	return nil
+}
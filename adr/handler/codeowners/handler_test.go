@@ -0,0 +1,306 @@
+package codeowners_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/codeowners"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security decisions", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Use PostgreSQL",
+			Status:   "Accepted",
+			Deciders: []string{"@jane", "@org/dba"},
+			Dir:      "platform",
+			Path:     "docs/adr/0001-use-postgresql.md",
+		},
+		{
+			ID:       "0002",
+			Title:    "Use Kafka",
+			Status:   "Proposed",
+			Deciders: []string{"@john"},
+			Dir:      "platform",
+			Path:     "docs/adr/0002-use-kafka.md",
+		},
+		{
+			ID:       "0003",
+			Title:    "Use gRPC",
+			Status:   "Accepted",
+			Deciders: []string{"@JANE", "Bob Smith", "bob@example.com"},
+			Dir:      "platform",
+			Path:     "docs/adr/0003-use-grpc.md",
+		},
+		{
+			ID:     "0004",
+			Title:  "Use NATS",
+			Status: "Accepted",
+			Dir:    "platform",
+			Path:   "docs/adr/0004-use-nats.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Rotate keys",
+			Status:   "Proposed",
+			Deciders: []string{"Mallory"},
+			Dir:      "security",
+			Path:     "security decisions/0001-rotate-keys.md",
+		},
+	}
+
+	platformSection = "# docula:codeowners \"platform\"\n" +
+		"# Generated by docula adr codeowners, do not edit.\n" +
+		"# platform: skipped Bob Smith, not a @user, @org/team or email\n" +
+		"/docs/adr/ @jane @org/dba bob@example.com\n" +
+		"/docs/adr/0001-use-postgresql.md @jane @org/dba\n" +
+		"/docs/adr/0002-use-kafka.md @john\n" +
+		"# platform:0003: skipped Bob Smith, not a @user, @org/team or email\n" +
+		"/docs/adr/0003-use-grpc.md @JANE bob@example.com\n" +
+		"# /docula:codeowners \"platform\""
+
+	securitySection = "# docula:codeowners \"security\"\n" +
+		"# Generated by docula adr codeowners, do not edit.\n" +
+		"# security:0001: skipped Mallory, not a @user, @org/team or email\n" +
+		"# /docula:codeowners \"security\""
+
+	generated = platformSection + "\n\n" + securitySection
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) codeowners.StateManager
+		store        func(ctrl *gomock.Controller) codeowners.RecordStore
+		fs           func(ctrl *gomock.Controller) codeowners.FileSystem
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) codeowners.StateManager {
+		sm := codeowners.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+		sm.EXPECT().NormalizePath(".github/CODEOWNERS").Return(".github/CODEOWNERS", nil).AnyTimes()
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) codeowners.RecordStore {
+		rs := codeowners.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	noFiles := func(ctrl *gomock.Controller) codeowners.FileSystem {
+		return codeowners.NewmockFileSystem(ctrl)
+	}
+
+	file := func(existing []byte, err error, wants []byte) func(ctrl *gomock.Controller) codeowners.FileSystem {
+		return func(ctrl *gomock.Controller) codeowners.FileSystem {
+			fs := codeowners.NewmockFileSystem(ctrl)
+			fs.EXPECT().ReadFile("/.github/CODEOWNERS").Return(existing, err)
+
+			if wants != nil {
+				fs.EXPECT().WriteFile("/.github/CODEOWNERS", wants).Return(nil)
+			}
+
+			return fs
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		opts  codeowners.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           noFiles,
+			},
+			wants: want{
+				output: generated + "\n",
+			},
+		},
+		{
+			name: "single dir",
+			opts: codeowners.Options{Dir: "security"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           noFiles,
+			},
+			wants: want{
+				output: securitySection + "\n",
+			},
+		},
+		{
+			name: "write a new file",
+			opts: codeowners.Options{Write: ".github/CODEOWNERS"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           file(nil, os.ErrNotExist, []byte(generated+"\n")),
+			},
+			wants: want{
+				output: "wrote 4 entries to .github/CODEOWNERS\n",
+			},
+		},
+		{
+			name: "append to a file",
+			opts: codeowners.Options{Write: ".github/CODEOWNERS"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           file([]byte("* @org/core\n"), nil, []byte("* @org/core\n\n"+generated+"\n")),
+			},
+			wants: want{
+				output: "wrote 4 entries to .github/CODEOWNERS\n",
+			},
+		},
+		{
+			name: "replace the entries of a file",
+			opts: codeowners.Options{Write: ".github/CODEOWNERS"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: file(
+					[]byte("* @org/core\n\n# docula:codeowners \"platform\"\n/docs/adr/ @old\n# /docula:codeowners \"platform\"\n\n/web/ @org/web\n"), nil,
+					[]byte("* @org/core\n\n"+platformSection+"\n\n/web/ @org/web\n\n"+securitySection+"\n"),
+				),
+			},
+			wants: want{
+				output: "wrote 4 entries to .github/CODEOWNERS\n",
+			},
+		},
+		{
+			name: "replace the entries of a single dir",
+			opts: codeowners.Options{Dir: "platform", Write: ".github/CODEOWNERS"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) codeowners.StateManager {
+					sm := codeowners.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().StateDir().Return("/", nil)
+					sm.EXPECT().NormalizePath(".github/CODEOWNERS").Return(".github/CODEOWNERS", nil)
+
+					return sm
+				},
+				store: func(ctrl *gomock.Controller) codeowners.RecordStore {
+					rs := codeowners.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+
+					return rs
+				},
+				fs: file(
+					[]byte("# docula:codeowners \"platform\"\n/docs/adr/ @old\n# /docula:codeowners \"platform\"\n\n"+
+						"# docula:codeowners \"security\"\n/security\\ decisions/ @org/security\n# /docula:codeowners \"security\"\n"), nil,
+					[]byte(platformSection+"\n\n"+
+						"# docula:codeowners \"security\"\n/security\\ decisions/ @org/security\n# /docula:codeowners \"security\"\n"),
+				),
+			},
+			wants: want{
+				output: "wrote 4 entries to .github/CODEOWNERS\n",
+			},
+		},
+		{
+			name: "unbalanced markers",
+			opts: codeowners.Options{Write: ".github/CODEOWNERS"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           file([]byte("# /docula:codeowners \"platform\"\n"), nil, nil),
+			},
+			wants: want{
+				err: adr.ErrUnbalancedMarkers,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: codeowners.Options{Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) codeowners.StateManager {
+					sm := codeowners.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+				fs:    noFiles,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) codeowners.StateManager {
+					sm := codeowners.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+				fs:    noFiles,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := codeowners.New(
+				codeowners.WithStateManager(tt.setup.stateManager(ctrl)),
+				codeowners.WithRecordStore(tt.setup.store(ctrl)),
+				codeowners.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
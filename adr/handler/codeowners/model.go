@@ -0,0 +1,20 @@
+package codeowners
+
+// The markers that surround the generated entries of each directory, followed
+// by the quoted name of the directory, so that they can be kept up to date
+// within a CODEOWNERS file holding other entries.
+const (
+	sectionStart = "# docula:codeowners"
+	sectionEnd   = "# /docula:codeowners"
+)
+
+// Options represents the input of the codeowners command.
+type Options struct {
+	// Dir limits the entries to a single adr directory, by name or path.
+	// The entries of the other directories are left as they are when
+	// writing.
+	Dir string
+	// Write is the path of the CODEOWNERS file to keep the entries up to
+	// date in. The entries are printed when it is empty.
+	Write string
+}
@@ -0,0 +1,4 @@
+// Package convert provides handler functionality for the convert command,
+// which moves the records of adr directories from one metadata style to the
+// other.
+package convert
@@ -0,0 +1,102 @@
+package convert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// Handler describes a type that is used to handle the convert command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle is the main Handler function. This function converts every record
+// of the selected adr dirs to the requested metadata style. The records are
+// written together, so either all of them are converted or none are.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	if opts.To != adr.StyleInline && opts.To != adr.StyleFrontmatter {
+		return fmt.Errorf("%w: %s", adr.ErrUnknownStyle, opts.To)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	var (
+		changes []store.Change
+		paths   []string
+	)
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range records {
+			data, err := h.store.Read(stateDir + rec.Path)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", rec.Path, err)
+			}
+
+			converted, err := adr.Convert(data, rec, opts.To)
+			if err != nil {
+				return fmt.Errorf("convert %s: %w", rec.Path, err)
+			}
+
+			if bytes.Equal(data, converted) {
+				continue
+			}
+
+			changes = append(changes, store.Change{Path: stateDir + rec.Path, Data: converted})
+			paths = append(paths, rec.Path)
+		}
+	}
+
+	if err = h.store.Apply(changes...); err != nil {
+		return fmt.Errorf("write records: %w", err)
+	}
+
+	for _, p := range paths {
+		fmt.Fprintf(out, "%s: converted\n", p)
+	}
+
+	fmt.Fprintf(out, "converted %s to %s\n", adr.Plural(len(paths), "record"), opts.To)
+
+	return nil
+}
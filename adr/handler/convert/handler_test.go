@@ -0,0 +1,195 @@
+package convert_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/convert"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	errDiskFull = errors.New("disk full")
+
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	inlineRecord = "# Use PostgreSQL\n\n## Status\n\nAccepted\n\n## Context\n"
+	frontRecord  = "---\ntitle: Use PostgreSQL\nstatus: Accepted\n---\n\n# Use PostgreSQL\n\n## Context\n"
+
+	platformRecord = adr.Record{
+		ID:     "0001",
+		Title:  "Use PostgreSQL",
+		Status: "Accepted",
+		Dir:    "platform",
+		Path:   "docs/adr/0001-use-postgresql.md",
+		Style:  adr.StyleInline,
+	}
+
+	securityRecord = adr.Record{
+		ID:     "0001",
+		Title:  "Use PostgreSQL",
+		Status: "Accepted",
+		Dir:    "security",
+		Path:   "security/0001-use-postgresql.md",
+		Style:  adr.StyleFrontmatter,
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) convert.StateManager
+		store        func(ctrl *gomock.Controller) convert.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	defaultStateManager := func(ctrl *gomock.Controller) convert.StateManager {
+		s := convert.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input convert.Options
+		wants want
+	}{
+		{
+			name: "converts to frontmatter",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) convert.RecordStore {
+					s := convert.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return([]adr.Record{platformRecord}, nil)
+					s.EXPECT().Read("/docs/adr/0001-use-postgresql.md").Return([]byte(inlineRecord), nil)
+					s.EXPECT().List("/", securityDir).Return([]adr.Record{securityRecord}, nil)
+					s.EXPECT().Read("/security/0001-use-postgresql.md").Return([]byte(frontRecord), nil)
+					s.EXPECT().Apply(store.Change{
+						Path: "/docs/adr/0001-use-postgresql.md",
+						Data: []byte(frontRecord),
+					}).Return(nil)
+					return s
+				},
+			},
+			input: convert.Options{To: adr.StyleFrontmatter},
+			wants: want{
+				output: "docs/adr/0001-use-postgresql.md: converted\nconverted 1 record to frontmatter\n",
+			},
+		},
+		{
+			name: "converts a single dir to inline",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) convert.RecordStore {
+					s := convert.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", securityDir).Return([]adr.Record{securityRecord}, nil)
+					s.EXPECT().Read("/security/0001-use-postgresql.md").Return([]byte(frontRecord), nil)
+					s.EXPECT().Apply(store.Change{
+						Path: "/security/0001-use-postgresql.md",
+						Data: []byte(inlineRecord),
+					}).Return(nil)
+					return s
+				},
+			},
+			input: convert.Options{Dir: "security", To: adr.StyleInline},
+			wants: want{
+				output: "security/0001-use-postgresql.md: converted\nconverted 1 record to inline\n",
+			},
+		},
+		{
+			name: "failing to write",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) convert.RecordStore {
+					s := convert.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", securityDir).Return([]adr.Record{securityRecord}, nil)
+					s.EXPECT().Read("/security/0001-use-postgresql.md").Return([]byte(frontRecord), nil)
+					s.EXPECT().Apply(gomock.Any()).Return(errDiskFull)
+					return s
+				},
+			},
+			input: convert.Options{Dir: "security", To: adr.StyleInline},
+			wants: want{
+				err: errDiskFull,
+			},
+		},
+		{
+			name: "unknown style",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) convert.StateManager {
+					return convert.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) convert.RecordStore {
+					return convert.NewmockRecordStore(ctrl)
+				},
+			},
+			input: convert.Options{To: "toml"},
+			wants: want{
+				err: adr.ErrUnknownStyle,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) convert.StateManager {
+					s := convert.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) convert.RecordStore {
+					return convert.NewmockRecordStore(ctrl)
+				},
+			},
+			input: convert.Options{Dir: "nope", To: adr.StyleInline},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := convert.New(
+				convert.WithStateManager(tt.setup.stateManager(ctrl)),
+				convert.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
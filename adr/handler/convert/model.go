@@ -0,0 +1,9 @@
+package convert
+
+// Options represents the input of the convert command.
+type Options struct {
+	// Dir limits the command to a single adr directory, by name or path.
+	Dir string
+	// To is the metadata style the records are converted to.
+	To string
+}
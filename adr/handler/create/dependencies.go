@@ -0,0 +1,52 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=create -mock_names Allocator=mockAllocator,FileSystem=mockFileSystem,RecordStore=mockRecordStore,Renderer=mockRenderer,StateManager=mockStateManager,Survey=mockSurvey
+
+package create
+
+import (
+	"context"
+
+	survey "github.com/AlecAivazis/survey/v2"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// FileSystem represents a type that is able to manipulate the filesystem.
+// This interface is typically a wrapper around the os package methods and
+// is used to allow for improved testing.
+type FileSystem interface {
+	WriteFile(name string, data []byte) error
+}
+
+// Survey represents a type that is able to get various inputs from stdin.
+type Survey interface {
+	SelectDir(names []string, opts ...survey.AskOpt) (string, error)
+	AskPeople(defaults People, opts ...survey.AskOpt) (People, error)
+}
+
+// Allocator represents a type that is able to allocate the identifier of a
+// new record. The write function is called with the identifier while it is
+// reserved for the caller.
+type Allocator interface {
+	Allocate(ctx context.Context, stateDir string, dir adr.Directory, write func(id string) error) error
+}
+
+// Renderer represents a type that is able to render a record from a template.
+type Renderer interface {
+	Render(stateDir string, name string, data template.Data) ([]byte, error)
+}
+
+// RecordStore represents a type that is able to list and read the records
+// of an adr directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+}
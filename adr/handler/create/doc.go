@@ -0,0 +1,2 @@
+// Package create provides handler functionality for the new command.
+package create
@@ -0,0 +1,23 @@
+package create
+
+import "os"
+
+type defaultFileSystem struct{}
+
+// WriteFile creates the named file and writes data to it. The file must not
+// already exist, which prevents an existing record from being overwritten.
+func (f *defaultFileSystem) WriteFile(name string, data []byte) error {
+	const filePerms = os.FileMode(0644)
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerms)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
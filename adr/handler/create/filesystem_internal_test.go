@@ -0,0 +1,27 @@
+package create
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultWriteFile(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(tmp)
+
+	fs := defaultFileSystem{}
+	name := filepath.Join(tmp, "0001-foo.md")
+
+	assert.NoError(t, fs.WriteFile(name, []byte("foo")))
+
+	data, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("foo"), data)
+
+	assert.ErrorIs(t, fs.WriteFile(name, []byte("bar")), os.ErrExist)
+}
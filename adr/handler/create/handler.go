@@ -0,0 +1,273 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/index"
+	"github.com/docula-io/docula/adr/similarity"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrNoDirs is returned when there are no adr dirs configured in the
+	// docula state.
+	ErrNoDirs = errors.New("no adr dirs initialized")
+
+	// ErrInvalidTitle is returned when the title cannot be used to produce
+	// a record file name.
+	ErrInvalidTitle = errors.New("invalid title")
+)
+
+var slugReplacer = regexp.MustCompile(`[^a-z0-9]+`)
+
+const (
+	// similarScore is the similarity, as a percentage, from which an
+	// existing record is reported as a similar decision.
+	similarScore = 15
+	// similarLimit is the maximum number of similar decisions reported.
+	similarLimit = 3
+)
+
+// Handler describes a type that is used to handle the new command.
+type Handler struct {
+	stateManager StateManager
+	fs           FileSystem
+	survey       Survey
+	allocator    Allocator
+	renderer     Renderer
+	store        RecordStore
+	warn         io.Writer
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		fs:           &defaultFileSystem{},
+		survey:       &defaultSurvey{},
+		allocator:    index.New(),
+		renderer:     template.New(),
+		store:        store.New(),
+		warn:         os.Stderr,
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// resolveDir finds the adr dir that matches the given name or path. If no
+// name is given and more than one adr dir exists, the user is asked to
+// choose one.
+func (h *Handler) resolveDir(s state.State, name string) (adr.Directory, error) {
+	dirs := s.ADR.Directories
+
+	if len(dirs) == 0 {
+		return adr.Directory{}, ErrNoDirs
+	}
+
+	if name == "" {
+		if len(dirs) == 1 {
+			return dirs[0], nil
+		}
+
+		names := make([]string, 0, len(dirs))
+		for _, dir := range dirs {
+			names = append(names, dir.Name)
+		}
+
+		selected, err := h.survey.SelectDir(names)
+		if err != nil {
+			return adr.Directory{}, fmt.Errorf("selecting dir: %w", err)
+		}
+
+		name = selected
+	}
+
+	return s.ADR.LookupDirectory(name, h.stateManager.NormalizePath)
+}
+
+// boilerplate returns the lines of the templates the records of the adr dirs
+// can have been written from. Templates that cannot be rendered are left
+// out.
+func (h *Handler) boilerplate(stateDir string, dirs []adr.Directory) similarity.Boilerplate {
+	var rendered [][]byte
+
+	for _, name := range template.Used(dirs) {
+		if data, err := h.renderer.Render(stateDir, name, template.Data{}); err == nil {
+			rendered = append(rendered, data)
+		}
+	}
+
+	return similarity.NewBoilerplate(rendered...)
+}
+
+// warnSimilar warns about the records of the adr dirs whose titles and
+// contents resemble the title of the new record, leaving out the text they
+// share with their templates. The warning is advisory, so records that
+// cannot be read are left out.
+func (h *Handler) warnSimilar(s state.State, stateDir string, title string) {
+	records := map[string]adr.Record{}
+
+	var docs []similarity.Document
+
+	for _, dir := range s.ADR.Directories {
+		list, err := h.store.List(stateDir, dir)
+		if err != nil {
+			continue
+		}
+
+		for _, rec := range list {
+			data, err := h.store.Read(stateDir + rec.Path)
+			if err != nil {
+				continue
+			}
+
+			records[rec.Path] = rec
+			docs = append(docs, similarity.Document{ID: rec.Path, Title: rec.Title, Text: string(data)})
+		}
+	}
+
+	if len(docs) == 0 {
+		return
+	}
+
+	b := h.boilerplate(stateDir, s.ADR.Directories)
+
+	for i := range docs {
+		docs[i].Text = b.Strip(docs[i].Text)
+	}
+
+	var similar []string
+
+	for _, m := range similarity.NewIndex(docs).Search(title, "") {
+		score := int(math.Round(m.Score * 100))
+
+		if score < similarScore || len(similar) == similarLimit {
+			break
+		}
+
+		rec := records[m.ID]
+		similar = append(similar, fmt.Sprintf("  %s:%s %s (%s, %d%% similar)", rec.Dir, rec.ID, rec.Title, rec.Status, score))
+	}
+
+	if len(similar) == 0 {
+		return
+	}
+
+	fmt.Fprintln(h.warn, "warning: similar decisions already exist:")
+
+	for _, line := range similar {
+		fmt.Fprintln(h.warn, line)
+	}
+}
+
+func slugify(title string) string {
+	slug := slugReplacer.ReplaceAllString(strings.ToLower(title), "-")
+	return strings.Trim(slug, "-")
+}
+
+// Handle is the main Handler function. This function is used to write a new
+// decision record into an adr dir, printing the path of the record to out.
+// Existing records that resemble the new one are reported as a warning.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	title := strings.TrimSpace(opts.Title)
+
+	slug := slugify(title)
+	if slug == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidTitle, title)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dir, err := h.resolveDir(s, opts.Dir)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	var recordPath string
+
+	tmpl := dir.Template
+
+	switch {
+	case opts.Template == "":
+	case template.IsBuiltin(opts.Template):
+		tmpl = opts.Template
+	default:
+		// A custom template given for the record is relative to the working
+		// dir, while the renderer reads it relative to the state dir.
+		if tmpl, err = h.stateManager.NormalizePath(opts.Template); err != nil {
+			return fmt.Errorf("normalize path: %w", err)
+		}
+	}
+
+	people := opts.People
+
+	if opts.Interactive {
+		if people, err = h.survey.AskPeople(people); err != nil {
+			return fmt.Errorf("asking for people: %w", err)
+		}
+	}
+
+	h.warnSimilar(s, stateDir, title)
+
+	now := h.now()
+
+	err = h.allocator.Allocate(ctx, stateDir, dir, func(id string) error {
+		data, err := h.renderer.Render(stateDir, tmpl, template.Data{
+			Dir:    dir,
+			ID:     id,
+			Title:  title,
+			Status: dir.StatusWorkflow().InitialStatus(),
+			Date:   now,
+
+			Deciders:  people.Deciders,
+			Consulted: people.Consulted,
+			Informed:  people.Informed,
+		})
+		if err != nil {
+			return fmt.Errorf("render record: %w", err)
+		}
+
+		recordPath = fmt.Sprintf("%s%s/%s-%s.md", stateDir, dir.Path, id, slug)
+
+		if err = h.fs.WriteFile(recordPath, data); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("allocate index: %w", err)
+	}
+
+	fmt.Fprintln(out, recordPath)
+
+	return nil
+}
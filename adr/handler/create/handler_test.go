@@ -0,0 +1,635 @@
+package create_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/create"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+func allocate(dir adr.Directory, id string) func(ctrl *gomock.Controller) create.Allocator {
+	return func(ctrl *gomock.Controller) create.Allocator {
+		a := create.NewmockAllocator(ctrl)
+		a.EXPECT().Allocate(gomock.Any(), gomock.Any(), dir, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, stateDir string, dir adr.Directory, write func(string) error) error {
+				return write(id)
+			},
+		)
+
+		return a
+	}
+}
+
+func noAllocate(ctrl *gomock.Controller) create.Allocator {
+	return create.NewmockAllocator(ctrl)
+}
+
+func noRecords(ctrl *gomock.Controller) create.RecordStore {
+	rs := create.NewmockRecordStore(ctrl)
+	rs.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	return rs
+}
+
+var defaultState = state.State{
+	ADR: adr.State{
+		Directories: []adr.Directory{
+			{
+				Path:  "docs/adr",
+				Name:  "default",
+				Index: "sequential",
+			},
+		},
+	},
+}
+
+var multiState = state.State{
+	ADR: adr.State{
+		Directories: []adr.Directory{
+			{
+				Path:  "docs/adr",
+				Name:  "default",
+				Index: "sequential",
+			},
+			{
+				Path:  "security/decisions",
+				Name:  "security",
+				Index: "timestamp",
+			},
+		},
+	},
+}
+
+func clock() time.Time {
+	return time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func TestHandler(t *testing.T) {
+	type input struct {
+		title    string
+		dir      string
+		template string
+	}
+
+	type want struct {
+		err  error
+		path string
+	}
+
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) create.StateManager
+		fs           func(ctrl *gomock.Controller) create.FileSystem
+		survey       func(ctrl *gomock.Controller) create.Survey
+		allocator    func(ctrl *gomock.Controller) create.Allocator
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input input
+		wants want
+	}{
+		{
+			name: "first record in the only dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/home/me/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					fs := create.NewmockFileSystem(ctrl)
+					fs.EXPECT().WriteFile("/home/me/docs/adr/0001-use-postgresql.md", gomock.Any()).Return(nil)
+					return fs
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: allocate(defaultState.ADR.Directories[0], "0001"),
+			},
+			input: input{
+				title: "Use PostgreSQL",
+			},
+			wants: want{
+				path: "/home/me/docs/adr/0001-use-postgresql.md",
+			},
+		},
+		{
+			name: "selected by name",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					fs := create.NewmockFileSystem(ctrl)
+					fs.EXPECT().WriteFile("/docs/adr/0013-drop-rest-api.md", gomock.Any()).Return(nil)
+					return fs
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: allocate(defaultState.ADR.Directories[0], "0013"),
+			},
+			input: input{
+				title: "Drop REST API!",
+				dir:   "default",
+			},
+			wants: want{
+				path: "/docs/adr/0013-drop-rest-api.md",
+			},
+		},
+		{
+			name: "timestamp index selected by path",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(multiState, nil)
+					s.EXPECT().NormalizePath("./security/decisions").Return("security/decisions", nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					fs := create.NewmockFileSystem(ctrl)
+					fs.EXPECT().WriteFile("/security/decisions/20220714093000-rotate-keys.md", gomock.Any()).Return(nil)
+					return fs
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: allocate(multiState.ADR.Directories[1], "20220714093000"),
+			},
+			input: input{
+				title: "Rotate keys",
+				dir:   "./security/decisions",
+			},
+			wants: want{
+				path: "/security/decisions/20220714093000-rotate-keys.md",
+			},
+		},
+		{
+			name: "asks for a dir when ambiguous",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(multiState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					fs := create.NewmockFileSystem(ctrl)
+					fs.EXPECT().WriteFile("/security/decisions/20220714093000-rotate-keys.md", gomock.Any()).Return(nil)
+					return fs
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					s := create.NewmockSurvey(ctrl)
+					s.EXPECT().SelectDir([]string{"default", "security"}).Return("security", nil)
+					return s
+				},
+				allocator: allocate(multiState.ADR.Directories[1], "20220714093000"),
+			},
+			input: input{
+				title: "Rotate keys",
+			},
+			wants: want{
+				path: "/security/decisions/20220714093000-rotate-keys.md",
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(multiState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					return create.NewmockFileSystem(ctrl)
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: noAllocate,
+			},
+			input: input{
+				title: "Rotate keys",
+				dir:   "nope",
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "no adr dirs",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					return create.NewmockFileSystem(ctrl)
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: noAllocate,
+			},
+			input: input{
+				title: "Rotate keys",
+			},
+			wants: want{
+				err: create.ErrNoDirs,
+			},
+		},
+		{
+			name: "invalid title",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					return create.NewmockStateManager(ctrl)
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					return create.NewmockFileSystem(ctrl)
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: noAllocate,
+			},
+			input: input{
+				title: " ?! ",
+			},
+			wants: want{
+				err: create.ErrInvalidTitle,
+			},
+		},
+		{
+			name: "failing to load the state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					return create.NewmockFileSystem(ctrl)
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: noAllocate,
+			},
+			input: input{
+				title: "Rotate keys",
+			},
+			wants: want{
+				err: state.ErrNotFound,
+			},
+		},
+		{
+			name: "failing to write the record",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) create.StateManager {
+					s := create.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) create.FileSystem {
+					fs := create.NewmockFileSystem(ctrl)
+					fs.EXPECT().WriteFile("/docs/adr/0001-rotate-keys.md", gomock.Any()).Return(os.ErrExist)
+					return fs
+				},
+				survey: func(ctrl *gomock.Controller) create.Survey {
+					return create.NewmockSurvey(ctrl)
+				},
+				allocator: allocate(defaultState.ADR.Directories[0], "0001"),
+			},
+			input: input{
+				title: "Rotate keys",
+			},
+			wants: want{
+				err: os.ErrExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := create.New(
+				create.WithFileSystem(tt.setup.fs(ctrl)),
+				create.WithStateManager(tt.setup.stateManager(ctrl)),
+				create.WithSurvey(tt.setup.survey(ctrl)),
+				create.WithAllocator(tt.setup.allocator(ctrl)),
+				create.WithRecordStore(noRecords(ctrl)),
+				create.WithClock(clock),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, create.Options{
+				Title:    tt.input.title,
+				Dir:      tt.input.dir,
+				Template: tt.input.template,
+			})
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wants.path, strings.TrimSpace(out.String()))
+			}
+		})
+	}
+}
+
+func TestHandlerRendersRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sm := create.NewmockStateManager(ctrl)
+	sm.EXPECT().Load().Return(defaultState, nil)
+	sm.EXPECT().StateDir().Return("/", nil)
+
+	var written []byte
+
+	fs := create.NewmockFileSystem(ctrl)
+	fs.EXPECT().WriteFile("/docs/adr/0001-use-postgresql.md", gomock.Any()).DoAndReturn(
+		func(name string, data []byte) error {
+			written = data
+			return nil
+		},
+	)
+
+	h := create.New(
+		create.WithFileSystem(fs),
+		create.WithStateManager(sm),
+		create.WithSurvey(create.NewmockSurvey(ctrl)),
+		create.WithAllocator(allocate(defaultState.ADR.Directories[0], "0001")(ctrl)),
+		create.WithRecordStore(noRecords(ctrl)),
+		create.WithClock(clock),
+	)
+
+	err := h.Handle(context.Background(), &bytes.Buffer{}, create.Options{Title: "Use PostgreSQL"})
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(written), "# Use PostgreSQL\n\nDate: 2022-07-14\n\n## Status\n\nProposed\n"))
+}
+
+func TestHandlerWarnsAboutSimilarDecisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sm := create.NewmockStateManager(ctrl)
+	sm.EXPECT().Load().Return(multiState, nil)
+	sm.EXPECT().StateDir().Return("/", nil)
+
+	fs := create.NewmockFileSystem(ctrl)
+	fs.EXPECT().WriteFile("/docs/adr/0003-cache-sessions-in-memcached.md", gomock.Any()).Return(nil)
+
+	rs := create.NewmockRecordStore(ctrl)
+	rs.EXPECT().List("/", multiState.ADR.Directories[0]).Return([]adr.Record{
+		{ID: "0001", Title: "Use PostgreSQL", Status: "Accepted", Dir: "default", Path: "docs/adr/0001-use-postgresql.md"},
+		{ID: "0002", Title: "Cache sessions in Redis", Status: "Accepted", Dir: "default", Path: "docs/adr/0002-cache-sessions-in-redis.md"},
+	}, nil)
+	rs.EXPECT().List("/", multiState.ADR.Directories[1]).Return(nil, os.ErrNotExist)
+	rs.EXPECT().Read("/docs/adr/0001-use-postgresql.md").Return([]byte("# Use PostgreSQL\n\nBilling needs transactions.\n"), nil)
+	rs.EXPECT().Read("/docs/adr/0002-cache-sessions-in-redis.md").Return([]byte("# Cache sessions in Redis\n\nSessions are read on every request.\n"), nil)
+
+	warnings := &bytes.Buffer{}
+
+	h := create.New(
+		create.WithFileSystem(fs),
+		create.WithStateManager(sm),
+		create.WithSurvey(create.NewmockSurvey(ctrl)),
+		create.WithAllocator(allocate(multiState.ADR.Directories[0], "0003")(ctrl)),
+		create.WithRecordStore(rs),
+		create.WithWarnings(warnings),
+		create.WithClock(clock),
+	)
+
+	out := &bytes.Buffer{}
+
+	err := h.Handle(context.Background(), out, create.Options{Title: "Cache sessions in Memcached", Dir: "default"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/docs/adr/0003-cache-sessions-in-memcached.md\n", out.String())
+	assert.Equal(t, "warning: similar decisions already exist:\n"+
+		"  default:0002 Cache sessions in Redis (Accepted, 51% similar)\n", warnings.String())
+}
+
+func TestHandlerTemplates(t *testing.T) {
+	custom := adr.Directory{
+		Path:     "docs/adr",
+		Name:     "default",
+		Template: "docs/templates/decision.md",
+		Workflow: &adr.Workflow{
+			Statuses: []string{"draft", "accepted"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		dir        adr.Directory
+		override   string
+		normalized string
+		wants      string
+	}{
+		{
+			name:  "default template",
+			dir:   defaultState.ADR.Directories[0],
+			wants: "",
+		},
+		{
+			name:  "template of the dir",
+			dir:   custom,
+			wants: "docs/templates/decision.md",
+		},
+		{
+			name:     "overridden template",
+			dir:      custom,
+			override: "madr",
+			wants:    "madr",
+		},
+		{
+			name:       "custom template relative to the working dir",
+			dir:        custom,
+			override:   "../templates/other.md",
+			normalized: "docs/templates/other.md",
+			wants:      "docs/templates/other.md",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sm := create.NewmockStateManager(ctrl)
+			sm.EXPECT().Load().Return(state.State{
+				ADR: adr.State{Directories: []adr.Directory{tt.dir}},
+			}, nil)
+			sm.EXPECT().StateDir().Return("/", nil)
+
+			if tt.normalized != "" {
+				sm.EXPECT().NormalizePath(tt.override).Return(tt.normalized, nil)
+			}
+
+			fs := create.NewmockFileSystem(ctrl)
+			fs.EXPECT().WriteFile("/docs/adr/0001-use-postgresql.md", []byte("rendered")).Return(nil)
+
+			renderer := create.NewmockRenderer(ctrl)
+			renderer.EXPECT().Render("/", tt.wants, template.Data{
+				Dir:    tt.dir,
+				ID:     "0001",
+				Title:  "Use PostgreSQL",
+				Status: tt.dir.StatusWorkflow().InitialStatus(),
+				Date:   clock(),
+			}).Return([]byte("rendered"), nil)
+
+			h := create.New(
+				create.WithFileSystem(fs),
+				create.WithStateManager(sm),
+				create.WithSurvey(create.NewmockSurvey(ctrl)),
+				create.WithAllocator(allocate(tt.dir, "0001")(ctrl)),
+				create.WithRecordStore(noRecords(ctrl)),
+				create.WithRenderer(renderer),
+				create.WithClock(clock),
+			)
+
+			err := h.Handle(context.Background(), &bytes.Buffer{}, create.Options{
+				Title:    "Use PostgreSQL",
+				Template: tt.override,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerPeople(t *testing.T) {
+	type want struct {
+		err  error
+		data template.Data
+	}
+
+	flagged := create.People{Deciders: []string{"@jane"}, Informed: []string{"@org/sre"}}
+	answered := create.People{
+		Deciders:  []string{"@jane", "@john"},
+		Consulted: []string{"@dba"},
+		Informed:  []string{"@org/sre"},
+	}
+
+	testCases := []struct {
+		name   string
+		opts   create.Options
+		survey func(ctrl *gomock.Controller) create.Survey
+		wants  want
+	}{
+		{
+			name: "given people",
+			opts: create.Options{Title: "Use PostgreSQL", People: flagged},
+			survey: func(ctrl *gomock.Controller) create.Survey {
+				return create.NewmockSurvey(ctrl)
+			},
+			wants: want{
+				data: template.Data{Deciders: []string{"@jane"}, Informed: []string{"@org/sre"}},
+			},
+		},
+		{
+			name: "asked for people",
+			opts: create.Options{Title: "Use PostgreSQL", People: flagged, Interactive: true},
+			survey: func(ctrl *gomock.Controller) create.Survey {
+				s := create.NewmockSurvey(ctrl)
+				s.EXPECT().AskPeople(flagged).Return(answered, nil)
+
+				return s
+			},
+			wants: want{
+				data: template.Data{
+					Deciders:  answered.Deciders,
+					Consulted: answered.Consulted,
+					Informed:  answered.Informed,
+				},
+			},
+		},
+		{
+			name: "failing to ask for people",
+			opts: create.Options{Title: "Use PostgreSQL", Interactive: true},
+			survey: func(ctrl *gomock.Controller) create.Survey {
+				s := create.NewmockSurvey(ctrl)
+				s.EXPECT().AskPeople(create.People{}).Return(create.People{}, os.ErrClosed)
+
+				return s
+			},
+			wants: want{
+				err: os.ErrClosed,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := defaultState.ADR.Directories[0]
+
+			sm := create.NewmockStateManager(ctrl)
+			sm.EXPECT().Load().Return(defaultState, nil)
+			sm.EXPECT().StateDir().Return("/", nil)
+
+			fs := create.NewmockFileSystem(ctrl)
+			renderer := create.NewmockRenderer(ctrl)
+			allocator := noAllocate(ctrl)
+
+			if tt.wants.err == nil {
+				data := tt.wants.data
+				data.Dir, data.ID, data.Title, data.Status, data.Date = dir, "0001", "Use PostgreSQL", adr.StatusProposed, clock()
+
+				renderer.EXPECT().Render("/", "", data).Return([]byte("rendered"), nil)
+				fs.EXPECT().WriteFile("/docs/adr/0001-use-postgresql.md", []byte("rendered")).Return(nil)
+				allocator = allocate(dir, "0001")(ctrl)
+			}
+
+			h := create.New(
+				create.WithFileSystem(fs),
+				create.WithStateManager(sm),
+				create.WithSurvey(tt.survey(ctrl)),
+				create.WithAllocator(allocator),
+				create.WithRecordStore(noRecords(ctrl)),
+				create.WithRenderer(renderer),
+				create.WithClock(clock),
+			)
+
+			err := h.Handle(context.Background(), &bytes.Buffer{}, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
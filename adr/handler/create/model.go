@@ -1,5 +1,24 @@
 package create
 
+// Options represents the record that is requested by the user.
 type Options struct {
-    Dir adr.Directory
+	Title string
+	// Dir is the name or path of the adr dir to write the record into.
+	Dir string
+	// Template overrides the template of the adr dir. It is either one of the
+	// built-in templates, or the path of a custom template relative to the
+	// working dir.
+	Template string
+	// People holds the people or teams involved in the decision.
+	People People
+	// Interactive asks for the people involved in the decision, using the
+	// given people as the defaults.
+	Interactive bool
+}
+
+// People represents the people or teams involved in a decision.
+type People struct {
+	Deciders  []string
+	Consulted []string
+	Informed  []string
 }
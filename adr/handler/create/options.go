@@ -0,0 +1,68 @@
+package create
+
+import (
+	"io"
+	"time"
+)
+
+// Option represents a type that is able to override the default resources of
+// the handler. These options are mainly used in a testing capacity.
+type Option func(h *Handler)
+
+// WithFileSystem is used to override the internal FileSystem of the handler.
+func WithFileSystem(fs FileSystem) Option {
+	return func(h *Handler) {
+		h.fs = fs
+	}
+}
+
+// WithStateManager is used to override the internal StateManager of the handler.
+func WithStateManager(sm StateManager) Option {
+	return func(h *Handler) {
+		h.stateManager = sm
+	}
+}
+
+// WithSurvey is used to override the internal Survey of the handler.
+func WithSurvey(survey Survey) Option {
+	return func(h *Handler) {
+		h.survey = survey
+	}
+}
+
+// WithClock is used to override the function the handler uses to obtain the
+// current time.
+func WithClock(now func() time.Time) Option {
+	return func(h *Handler) {
+		h.now = now
+	}
+}
+
+// WithAllocator is used to override the internal Allocator of the handler.
+func WithAllocator(allocator Allocator) Option {
+	return func(h *Handler) {
+		h.allocator = allocator
+	}
+}
+
+// WithRenderer is used to override the internal Renderer of the handler.
+func WithRenderer(renderer Renderer) Option {
+	return func(h *Handler) {
+		h.renderer = renderer
+	}
+}
+
+// WithRecordStore is used to override the internal RecordStore of the handler.
+func WithRecordStore(store RecordStore) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
+
+// WithWarnings is used to override the writer the handler warns about
+// similar decisions on.
+func WithWarnings(w io.Writer) Option {
+	return func(h *Handler) {
+		h.warn = w
+	}
+}
@@ -0,0 +1,84 @@
+package create
+
+import (
+	"fmt"
+	"strings"
+
+	survey "github.com/AlecAivazis/survey/v2"
+)
+
+type defaultSurvey struct{}
+
+func (s *defaultSurvey) SelectDir(names []string, opts ...survey.AskOpt) (string, error) {
+	var answer string
+
+	prompt := &survey.Select{
+		Message: "Choose an adr dir",
+		Options: names,
+	}
+
+	if err := survey.AskOne(prompt, &answer, opts...); err != nil {
+		return answer, fmt.Errorf("asking survey: %w", err)
+	}
+
+	return answer, nil
+}
+
+// peopleAnswers holds the answers of the people survey, which are comma
+// separated lists.
+type peopleAnswers struct {
+	Deciders  string `survey:"deciders"`
+	Consulted string `survey:"consulted"`
+	Informed  string `survey:"informed"`
+}
+
+func peopleQuestions(defaults People) []*survey.Question {
+	input := func(message string, values []string) *survey.Input {
+		return &survey.Input{
+			Message: message,
+			Default: strings.Join(values, ", "),
+			Help:    "A comma separated list of people or teams, such as @jane, @org/platform.",
+		}
+	}
+
+	return []*survey.Question{
+		{
+			Name:   "deciders",
+			Prompt: input("Who decides?", defaults.Deciders),
+		},
+		{
+			Name:   "consulted",
+			Prompt: input("Who is consulted?", defaults.Consulted),
+		},
+		{
+			Name:   "informed",
+			Prompt: input("Who is informed?", defaults.Informed),
+		},
+	}
+}
+
+func (s *defaultSurvey) AskPeople(defaults People, opts ...survey.AskOpt) (People, error) {
+	var answers peopleAnswers
+
+	if err := survey.Ask(peopleQuestions(defaults), &answers, opts...); err != nil {
+		return defaults, fmt.Errorf("asking survey: %w", err)
+	}
+
+	return People{
+		Deciders:  splitPeople(answers.Deciders),
+		Consulted: splitPeople(answers.Consulted),
+		Informed:  splitPeople(answers.Informed),
+	}, nil
+}
+
+func splitPeople(value string) []string {
+	var people []string
+
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			people = append(people, p)
+		}
+	}
+
+	return people
+}
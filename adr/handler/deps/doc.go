@@ -0,0 +1,4 @@
+// Package deps provides handler functionality for the adr check-deps
+// command, which checks the modules required by the go.mod files of the
+// project against the module policies declared by accepted decision records.
+package deps
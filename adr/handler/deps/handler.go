@@ -0,0 +1,271 @@
+package deps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/gomod"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrViolations is returned when any of the module policies is violated.
+var ErrViolations = errors.New("module policies violated")
+
+// The severities of the findings.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// Handler describes a type that is used to handle the check-deps command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// policy represents a module policy along with the record declaring it.
+type policy struct {
+	adr.ModulePolicy
+
+	Record adr.Record
+}
+
+func (p policy) cite() string {
+	return fmt.Sprintf("%s:%s %s", p.Record.Dir, p.Record.ID, p.Record.Title)
+}
+
+// modFile represents a go.mod file of the project.
+type modFile struct {
+	gomod.File
+
+	// Path is the location of the file, relative to the state dir.
+	Path string
+}
+
+// finding represents a requirement that violates a policy.
+type finding struct {
+	path     string
+	line     int
+	severity string
+	message  string
+}
+
+// policies collects the module policies of the accepted records of the
+// dirs. Policies of records that are not accepted, such as superseded ones,
+// are retired.
+func (h *Handler) policies(stateDir string, dirs []adr.Directory) ([]policy, error) {
+	var policies []policy
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return nil, fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range records {
+			if !rec.HasStatus(adr.StatusAccepted) {
+				continue
+			}
+
+			for _, p := range rec.Modules {
+				if err = p.Validate(); err != nil {
+					return nil, fmt.Errorf("%s: %w", rec.Path, err)
+				}
+
+				policies = append(policies, policy{ModulePolicy: p, Record: rec})
+			}
+		}
+	}
+
+	return policies, nil
+}
+
+// modFiles walks the project for go.mod files, so that every module of a
+// repository holding several modules is checked.
+func (h *Handler) modFiles(stateDir string, rel string) ([]modFile, error) {
+	entries, err := h.fs.ReadDir(stateDir + rel)
+	if err != nil {
+		return nil, fmt.Errorf("read dir %s: %w", rel, err)
+	}
+
+	var files []modFile
+
+	for _, entry := range entries {
+		name := entry.Name()
+		filePath := path.Join(rel, name)
+
+		switch {
+		case strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+			continue
+		case entry.IsDir():
+			if adr.SkipDir(name) {
+				continue
+			}
+
+			nested, err := h.modFiles(stateDir, filePath)
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, nested...)
+		case name == gomod.FileName:
+			data, err := h.fs.ReadFile(stateDir + filePath)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", filePath, err)
+			}
+
+			files = append(files, modFile{File: gomod.Parse(data), Path: filePath})
+		}
+	}
+
+	return files, nil
+}
+
+// evaluate returns the findings of a requirement against a policy.
+func evaluate(req gomod.Require, p policy) ([]finding, error) {
+	module := req.Path + " " + req.Version
+	if req.Indirect {
+		module += " (indirect)"
+	}
+
+	var findings []finding
+
+	add := func(severity string, format string, args ...interface{}) {
+		findings = append(findings, finding{line: req.Line, severity: severity, message: fmt.Sprintf(format, args...)})
+	}
+
+	if adr.MatchPackage(p.Module, req.Path, "") {
+		matches, err := p.Matches(req.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", req.Path, err)
+		}
+
+		switch {
+		case p.Policy == adr.PolicyBanned && matches:
+			message := fmt.Sprintf("%s is banned by %s", module, p.cite())
+			if p.Version != "" {
+				message = fmt.Sprintf("%s for versions %s", message, p.Version)
+			}
+
+			if p.Instead != "" {
+				message = fmt.Sprintf("%s, use %s instead", message, p.Instead)
+			}
+
+			add(severityError, "%s", message)
+		case p.Policy != adr.PolicyBanned && !matches:
+			add(severityError, "%s does not satisfy %s of %s", module, p.Version, p.cite())
+		}
+	}
+
+	if p.Policy != adr.PolicyPreferred {
+		return findings, nil
+	}
+
+	for _, over := range p.Over {
+		if adr.MatchPackage(over, req.Path, "") {
+			add(severityWarning, "%s is replaced by %s, preferred by %s", module, p.Module, p.cite())
+			break
+		}
+	}
+
+	return findings, nil
+}
+
+// Handle is the main Handler function. This function checks the modules
+// required by every go.mod file of the project against the module policies
+// of the accepted records. If any policy is violated, then ErrViolations is
+// returned.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	policies, err := h.policies(stateDir, dirs)
+	if err != nil {
+		return err
+	}
+
+	if len(policies) == 0 {
+		fmt.Fprintln(out, "no module policies declared by accepted records")
+		return nil
+	}
+
+	files, err := h.modFiles(stateDir, "")
+	if err != nil {
+		return err
+	}
+
+	var errs, warnings, requires int
+
+	for _, f := range files {
+		requires += len(f.Requires)
+
+		for _, req := range f.Requires {
+			for _, p := range policies {
+				findings, err := evaluate(req, p)
+				if err != nil {
+					return fmt.Errorf("%s: %w", f.Path, err)
+				}
+
+				for _, found := range findings {
+					fmt.Fprintf(out, "%s:%d: %s: %s\n", f.Path, found.line, found.severity, found.message)
+
+					if found.severity == severityError {
+						errs++
+					} else {
+						warnings++
+					}
+				}
+			}
+		}
+	}
+
+	if errs+warnings == 0 {
+		fmt.Fprintf(out, "no violations of %s across %s\n", adr.Plural(len(policies), "module policy"), adr.Plural(requires, "requirement"))
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s, %s\n", adr.Plural(errs, "error"), adr.Plural(warnings, "warning"))
+
+	if errs > 0 {
+		return fmt.Errorf("%w: %s", ErrViolations, adr.Plural(errs, "error"))
+	}
+
+	return nil
+}
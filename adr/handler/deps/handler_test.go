@@ -0,0 +1,306 @@
+package deps_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/deps"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0003",
+			Title:  "Use pgx",
+			Status: "Accepted",
+			Modules: []adr.ModulePolicy{
+				{Module: "github.com/jackc/pgx/v5", Policy: adr.PolicyPreferred, Version: ">= v5.4", Over: []string{"github.com/lib/pq"}},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0003-use-pgx.md",
+		},
+		{
+			ID:     "0004",
+			Title:  "Patch yaml",
+			Status: "Accepted",
+			Modules: []adr.ModulePolicy{
+				{Module: "gopkg.in/yaml.v2", Policy: adr.PolicyBanned, Version: "< v2.4.0", Instead: "gopkg.in/yaml.v3"},
+				{Module: "golang.org/x/...", Policy: adr.PolicyAllowed},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0004-patch-yaml.md",
+		},
+		{
+			ID:     "0005",
+			Title:  "Ban logrus",
+			Status: "Superseded by [0006. Log with zap](0006-log-with-zap.md)",
+			Modules: []adr.ModulePolicy{
+				{Module: "github.com/sirupsen/logrus", Policy: adr.PolicyBanned},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0005-ban-logrus.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Proposed ban",
+			Status: "Proposed",
+			Modules: []adr.ModulePolicy{
+				{Module: "golang.org/x/crypto", Policy: adr.PolicyBanned},
+			},
+			Dir:  "security",
+			Path: "security/0001-proposed-ban.md",
+		},
+	}
+
+	project = fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.18\n\nrequire (\n" +
+			"\tgithub.com/jackc/pgx/v5 v5.2.0\n" +
+			"\tgithub.com/lib/pq v1.10.7\n" +
+			"\tgithub.com/sirupsen/logrus v1.9.0\n" +
+			"\tgolang.org/x/crypto v0.1.0 // indirect\n" +
+			")\n")},
+		"tools/go.mod":         {Data: []byte("module example.com/shop/tools\n\nrequire gopkg.in/yaml.v2 v2.2.8 // indirect\n")},
+		"vendor/x/go.mod":      {Data: []byte("module x\n\nrequire gopkg.in/yaml.v2 v2.2.8\n")},
+		".cache/go.mod":        {Data: []byte("module cache\n\nrequire gopkg.in/yaml.v2 v2.2.8\n")},
+		"docs/adr/0003-use.md": {Data: []byte("# Use pgx\n")},
+	}
+)
+
+// filesOf produces a file system that reads the given files, which are
+// relative to the state dir.
+func filesOf(files fstest.MapFS) func(ctrl *gomock.Controller) deps.FileSystem {
+	name := func(p string) string {
+		if p = strings.Trim(p, "/"); p == "" {
+			return "."
+		}
+
+		return p
+	}
+
+	return func(ctrl *gomock.Controller) deps.FileSystem {
+		fsys := deps.NewmockFileSystem(ctrl)
+
+		fsys.EXPECT().ReadDir(gomock.Any()).DoAndReturn(func(p string) ([]os.DirEntry, error) {
+			return fs.ReadDir(files, name(p))
+		}).AnyTimes()
+
+		fsys.EXPECT().ReadFile(gomock.Any()).DoAndReturn(func(p string) ([]byte, error) {
+			return fs.ReadFile(files, name(p))
+		}).AnyTimes()
+
+		return fsys
+	}
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) deps.StateManager
+		store        func(ctrl *gomock.Controller) deps.RecordStore
+		fs           func(ctrl *gomock.Controller) deps.FileSystem
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) deps.StateManager {
+		sm := deps.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) deps.RecordStore {
+		rs := deps.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	testCases := []struct {
+		name  string
+		opts  deps.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           filesOf(project),
+			},
+			wants: want{
+				err: deps.ErrViolations,
+				output: "go.mod:6: error: github.com/jackc/pgx/v5 v5.2.0 does not satisfy >= v5.4 of platform:0003 Use pgx\n" +
+					"go.mod:7: warning: github.com/lib/pq v1.10.7 is replaced by github.com/jackc/pgx/v5, " +
+					"preferred by platform:0003 Use pgx\n" +
+					"tools/go.mod:3: error: gopkg.in/yaml.v2 v2.2.8 (indirect) is banned by platform:0004 Patch yaml " +
+					"for versions < v2.4.0, use gopkg.in/yaml.v3 instead\n" +
+					"2 errors, 1 warning\n",
+			},
+		},
+		{
+			name: "only warnings",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: filesOf(fstest.MapFS{
+					"go.mod": {Data: []byte("module example.com/shop\n\nrequire github.com/lib/pq v1.10.7\n")},
+				}),
+			},
+			wants: want{
+				output: "go.mod:3: warning: github.com/lib/pq v1.10.7 is replaced by github.com/jackc/pgx/v5, " +
+					"preferred by platform:0003 Use pgx\n" +
+					"0 errors, 1 warning\n",
+			},
+		},
+		{
+			name: "no violations",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: filesOf(fstest.MapFS{
+					"go.mod": {Data: []byte("module example.com/shop\n\nrequire (\n" +
+						"\tgithub.com/jackc/pgx/v5 v5.4.3\n" +
+						"\tgopkg.in/yaml.v2 v2.4.0\n" +
+						")\n")},
+				}),
+			},
+			wants: want{
+				output: "no violations of 3 module policies across 2 requirements\n",
+			},
+		},
+		{
+			name: "no policies",
+			opts: deps.Options{Dir: "security"},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs:           filesOf(project),
+			},
+			wants: want{
+				output: "no module policies declared by accepted records\n",
+			},
+		},
+		{
+			name: "invalid policy",
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) deps.RecordStore {
+					rs := deps.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return([]adr.Record{{
+						ID:      "0010",
+						Status:  "Accepted",
+						Modules: []adr.ModulePolicy{{Module: "github.com/lib/pq", Policy: "tolerated"}},
+						Path:    "docs/adr/0010-broken.md",
+					}}, nil)
+
+					return rs
+				},
+				fs: filesOf(project),
+			},
+			wants: want{
+				err: adr.ErrInvalidModulePolicy,
+			},
+		},
+		{
+			name: "invalid required version",
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+				fs: filesOf(fstest.MapFS{
+					"go.mod": {Data: []byte("module example.com/shop\n\nrequire gopkg.in/yaml.v2 latest\n")},
+				}),
+			},
+			wants: want{
+				err: adr.ErrInvalidVersion,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: deps.Options{Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) deps.StateManager {
+					sm := deps.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+				fs:    filesOf(project),
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) deps.StateManager {
+					sm := deps.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+				fs:    filesOf(project),
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := deps.New(
+				deps.WithStateManager(tt.setup.stateManager(ctrl)),
+				deps.WithRecordStore(tt.setup.store(ctrl)),
+				deps.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package deps
+
+// Options represents the input of the check-deps command.
+type Options struct {
+	// Dir limits the check to the policies of the records of a single adr
+	// directory, by name or path.
+	Dir string
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=due -mock_names RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package due
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to list the records of an adr
+// directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+}
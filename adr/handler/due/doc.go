@@ -0,0 +1,4 @@
+// Package due provides handler functionality for the adr due command, which
+// lists the decisions that are due for review and the proposals that have
+// gone stale, or exports the review dates as an iCalendar file.
+package due
@@ -0,0 +1,252 @@
+package due
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrUnknownFormat is returned when the requested output format is not
+// supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// day is the length of a day, used to count the days to a date.
+const day = 24 * time.Hour
+
+// Handler describes a type that is used to handle the due command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// interval parses the interval of an option, which is unset when empty.
+func interval(name string, value string) (adr.Interval, bool, error) {
+	if value == "" {
+		return adr.Interval{}, false, nil
+	}
+
+	i, err := adr.ParseInterval(value)
+	if err != nil {
+		return adr.Interval{}, false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return i, true, nil
+}
+
+func newItem(kind string, rec adr.Record, date time.Time, today time.Time) Item {
+	return Item{
+		Kind:   kind,
+		Dir:    rec.Dir,
+		ID:     rec.ID,
+		Title:  rec.Title,
+		Status: rec.Status,
+		Path:   rec.Path,
+		Date:   date.Format(adr.DateFormat),
+		Days:   int(date.Sub(today) / day),
+	}
+}
+
+// reviews returns an item for the review date of every accepted record of
+// the dirs, and an item for every record that is neither accepted nor in a
+// terminal status, dated when it moved to its status. Both are oldest first.
+func (h *Handler) reviews(stateDir string, dirs []adr.Directory, today time.Time) ([]Item, []Item, error) {
+	var reviews, proposals []Item
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		workflow := dir.StatusWorkflow()
+
+		for _, rec := range records {
+			review, ok, err := dir.ReviewDate(rec)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			switch {
+			case ok:
+				kind := KindUpcoming
+				if review.Before(today) {
+					kind = KindOverdue
+				}
+
+				reviews = append(reviews, newItem(kind, rec, review, today))
+			case !rec.HasStatus(adr.StatusAccepted) && !workflow.IsTerminal(rec.Status) && !rec.StatusDate().IsZero():
+				proposals = append(proposals, newItem(KindStale, rec, rec.StatusDate(), today))
+			}
+		}
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].Date < reviews[j].Date })
+	sort.SliceStable(proposals, func(i, j int) bool { return proposals[i].Date < proposals[j].Date })
+
+	return reviews, proposals, nil
+}
+
+// Handle is the main Handler function. This function lists the reviews that
+// are overdue or fall within the window of the options, followed by the
+// proposals that have not moved on for longer than the stale interval. The
+// ics format instead writes every review date as a calendar event.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatText, FormatJSON, FormatICS:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	within, upcoming, err := interval("within", opts.Within)
+	if err != nil {
+		return err
+	}
+
+	stale, hasStale, err := interval("stale", opts.Stale)
+	if err != nil {
+		return err
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	now := h.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	reviews, proposals, err := h.reviews(stateDir, dirs, today)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatICS {
+		return writeCalendar(out, reviews, now)
+	}
+
+	items := []Item{}
+
+	for _, item := range reviews {
+		if item.Kind == KindOverdue || upcoming && item.Date <= within.After(today).Format(adr.DateFormat) {
+			items = append(items, item)
+		}
+	}
+
+	for _, item := range proposals {
+		if hasStale && item.Date < stale.Before(today).Format(adr.DateFormat) {
+			items = append(items, item)
+		}
+	}
+
+	if opts.Format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(items)
+	}
+
+	return render(out, items, opts)
+}
+
+// sections holds the headings of the text output, by kind.
+var sections = []struct {
+	kind    string
+	heading string
+}{
+	{KindOverdue, "Overdue reviews"},
+	{KindUpcoming, "Upcoming reviews"},
+	{KindStale, "Stale proposals"},
+}
+
+func render(out io.Writer, items []Item, opts Options) error {
+	if len(items) == 0 {
+		message := "nothing is overdue"
+		if opts.Within != "" {
+			message = fmt.Sprintf("nothing is due within %s", opts.Within)
+		}
+
+		_, err := fmt.Fprintln(out, message)
+
+		return err
+	}
+
+	var written bool
+
+	for _, section := range sections {
+		var lines []string
+
+		for _, item := range items {
+			if item.Kind == section.kind {
+				lines = append(lines, fmt.Sprintf("  %s  %s:%s %s (%s)", item.Date, item.Dir, item.ID, item.Title, describe(item)))
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		if written {
+			fmt.Fprintln(out)
+		}
+
+		written = true
+
+		fmt.Fprintf(out, "%s:\n", section.heading)
+
+		for _, line := range lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+
+	return nil
+}
+
+func describe(item Item) string {
+	switch {
+	case item.Kind == KindStale:
+		return fmt.Sprintf("%s for %s", adr.BaseStatus(item.Status), adr.Plural(-item.Days, "day"))
+	case item.Days < 0:
+		return fmt.Sprintf("%s overdue", adr.Plural(-item.Days, "day"))
+	case item.Days == 0:
+		return "due today"
+	}
+
+	return fmt.Sprintf("in %s", adr.Plural(item.Days, "day"))
+}
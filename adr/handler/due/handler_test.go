@@ -0,0 +1,346 @@
+package due_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/due"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential, Review: "1y"}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	now = time.Date(2024, 6, 15, 13, 30, 0, 0, time.UTC)
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Use PostgreSQL",
+			Status: "Accepted",
+			Date:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0001-use-postgresql.md",
+		},
+		{
+			ID:       "0002",
+			Title:    "Use gRPC, not REST",
+			Status:   "Accepted",
+			Date:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			ReviewBy: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			Dir:      "platform",
+			Path:     "docs/adr/0002-use-grpc.md",
+		},
+		{
+			ID:     "0003",
+			Title:  "Use Kafka",
+			Status: "Superseded by [0004. Use NATS](0004-use-nats.md)",
+			Date:   time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0003-use-kafka.md",
+		},
+		{
+			ID:     "0004",
+			Title:  "Use NATS",
+			Status: "Proposed",
+			Date:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			History: []adr.StatusChange{
+				{Date: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), Status: "Draft"},
+				{Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Status: "Proposed"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0004-use-nats.md",
+		},
+		{
+			ID:     "0005",
+			Title:  "Use Redis",
+			Status: "Proposed",
+			Date:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0005-use-redis.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Rotate keys",
+			Status:   "Accepted",
+			Date:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			ReviewBy: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			Dir:      "security",
+			Path:     "security/0001-rotate-keys.md",
+		},
+		{
+			ID:     "0002",
+			Title:  "Use mTLS",
+			Status: "Accepted",
+			Date:   time.Date(2020, 1, 10, 0, 0, 0, 0, time.UTC),
+			Dir:    "security",
+			Path:   "security/0002-use-mtls.md",
+		},
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) due.StateManager
+		store        func(ctrl *gomock.Controller) due.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) due.StateManager {
+		sm := due.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) due.RecordStore {
+		rs := due.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	noState := func(ctrl *gomock.Controller) due.StateManager {
+		return due.NewmockStateManager(ctrl)
+	}
+
+	noStore := func(ctrl *gomock.Controller) due.RecordStore {
+		return due.NewmockRecordStore(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  due.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			opts: due.Options{Within: "30d", Stale: "90d", Format: due.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "Overdue reviews:\n" +
+					"  2024-06-01  platform:0001 Use PostgreSQL (14 days overdue)\n" +
+					"\n" +
+					"Upcoming reviews:\n" +
+					"  2024-07-01  platform:0002 Use gRPC, not REST (in 16 days)\n" +
+					"\n" +
+					"Stale proposals:\n" +
+					"  2024-01-02  platform:0004 Use NATS (Proposed for 165 days)\n",
+			},
+		},
+		{
+			name: "overdue only",
+			opts: due.Options{Format: due.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "Overdue reviews:\n" +
+					"  2024-06-01  platform:0001 Use PostgreSQL (14 days overdue)\n",
+			},
+		},
+		{
+			name: "nothing due",
+			opts: due.Options{Dir: "security", Within: "30d", Format: due.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "nothing is due within 30d\n",
+			},
+		},
+		{
+			name: "json",
+			opts: due.Options{Dir: "platform", Stale: "1w", Format: due.FormatJSON},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: `[
+  {
+    "kind": "overdue",
+    "dir": "platform",
+    "id": "0001",
+    "title": "Use PostgreSQL",
+    "status": "Accepted",
+    "path": "docs/adr/0001-use-postgresql.md",
+    "date": "2024-06-01",
+    "days": -14
+  },
+  {
+    "kind": "stale",
+    "dir": "platform",
+    "id": "0004",
+    "title": "Use NATS",
+    "status": "Proposed",
+    "path": "docs/adr/0004-use-nats.md",
+    "date": "2024-01-02",
+    "days": -165
+  },
+  {
+    "kind": "stale",
+    "dir": "platform",
+    "id": "0005",
+    "title": "Use Redis",
+    "status": "Proposed",
+    "path": "docs/adr/0005-use-redis.md",
+    "date": "2024-06-01",
+    "days": -14
+  }
+]
+`,
+			},
+		},
+		{
+			name: "ics",
+			opts: due.Options{Within: "30d", Format: due.FormatICS},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "BEGIN:VCALENDAR\r\n" +
+					"VERSION:2.0\r\n" +
+					"PRODID:-//docula//adr due//EN\r\n" +
+					"CALSCALE:GREGORIAN\r\n" +
+					"BEGIN:VEVENT\r\n" +
+					"UID:platform-0001-review@docula\r\n" +
+					"DTSTAMP:20240615T133000Z\r\n" +
+					"DTSTART;VALUE=DATE:20240601\r\n" +
+					"DTEND;VALUE=DATE:20240602\r\n" +
+					"SUMMARY:Review platform:0001 Use PostgreSQL\r\n" +
+					"DESCRIPTION:Review the decision recorded in docs/adr/0001-use-postgresql.md\r\n" +
+					" .\r\n" +
+					"END:VEVENT\r\n" +
+					"BEGIN:VEVENT\r\n" +
+					"UID:platform-0002-review@docula\r\n" +
+					"DTSTAMP:20240615T133000Z\r\n" +
+					"DTSTART;VALUE=DATE:20240701\r\n" +
+					"DTEND;VALUE=DATE:20240702\r\n" +
+					"SUMMARY:Review platform:0002 Use gRPC\\, not REST\r\n" +
+					"DESCRIPTION:Review the decision recorded in docs/adr/0002-use-grpc.md.\r\n" +
+					"END:VEVENT\r\n" +
+					"BEGIN:VEVENT\r\n" +
+					"UID:security-0001-review@docula\r\n" +
+					"DTSTAMP:20240615T133000Z\r\n" +
+					"DTSTART;VALUE=DATE:20240901\r\n" +
+					"DTEND;VALUE=DATE:20240902\r\n" +
+					"SUMMARY:Review security:0001 Rotate keys\r\n" +
+					"DESCRIPTION:Review the decision recorded in security/0001-rotate-keys.md.\r\n" +
+					"END:VEVENT\r\n" +
+					"END:VCALENDAR\r\n",
+			},
+		},
+		{
+			name: "invalid window",
+			opts: due.Options{Within: "a month", Format: due.FormatText},
+			setup: setup{
+				stateManager: noState,
+				store:        noStore,
+			},
+			wants: want{
+				err: adr.ErrInvalidInterval,
+			},
+		},
+		{
+			name: "unknown format",
+			opts: due.Options{Format: "csv"},
+			setup: setup{
+				stateManager: noState,
+				store:        noStore,
+			},
+			wants: want{
+				err: due.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: due.Options{Dir: "nope", Format: due.FormatText},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) due.StateManager {
+					sm := due.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			opts: due.Options{Format: due.FormatText},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) due.StateManager {
+					sm := due.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := due.New(
+				due.WithStateManager(tt.setup.stateManager(ctrl)),
+				due.WithRecordStore(tt.setup.store(ctrl)),
+				due.WithClock(func() time.Time { return now }),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package due
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// icsLineLength is the number of octets after which iCalendar lines are
+// folded.
+const icsLineLength = 75
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// calendar writes the iCalendar content lines, terminated by CRLF as the
+// format requires.
+type calendar struct {
+	out io.Writer
+	err error
+}
+
+func (c *calendar) line(name string, value string) {
+	if c.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+
+	// Long lines are folded into continuation lines starting with a space,
+	// without splitting multi-byte characters.
+	for len(line) > icsLineLength {
+		cut := icsLineLength
+		for cut > 0 && !isBoundary(line[cut]) {
+			cut--
+		}
+
+		if _, c.err = fmt.Fprintf(c.out, "%s\r\n", line[:cut]); c.err != nil {
+			return
+		}
+
+		line = " " + line[cut:]
+	}
+
+	_, c.err = fmt.Fprintf(c.out, "%s\r\n", line)
+}
+
+func isBoundary(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// writeCalendar writes an all day event for the review of every item.
+func writeCalendar(out io.Writer, items []Item, now time.Time) error {
+	c := &calendar{out: out}
+
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//docula//adr due//EN")
+	c.line("CALSCALE", "GREGORIAN")
+
+	for _, item := range items {
+		date, err := time.Parse(adr.DateFormat, item.Date)
+		if err != nil {
+			return fmt.Errorf("parse date of %s:%s: %w", item.Dir, item.ID, err)
+		}
+
+		c.line("BEGIN", "VEVENT")
+		c.line("UID", fmt.Sprintf("%s-%s-review@docula", strings.ReplaceAll(item.Dir, " ", "-"), item.ID))
+		c.line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+		c.line("DTSTART;VALUE=DATE", date.Format("20060102"))
+		c.line("DTEND;VALUE=DATE", date.AddDate(0, 0, 1).Format("20060102"))
+		c.line("SUMMARY", icsEscaper.Replace(fmt.Sprintf("Review %s:%s %s", item.Dir, item.ID, item.Title)))
+		c.line("DESCRIPTION", icsEscaper.Replace(fmt.Sprintf("Review the decision recorded in %s.", item.Path)))
+		c.line("END", "VEVENT")
+	}
+
+	c.line("END", "VCALENDAR")
+
+	return c.err
+}
@@ -0,0 +1,48 @@
+package due
+
+// The output formats of the due command. The ics format is an iCalendar file
+// holding an event for every review date, regardless of the window.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatICS  = "ics"
+)
+
+// The kinds of the items that are due.
+const (
+	KindOverdue  = "overdue"
+	KindUpcoming = "upcoming"
+	KindStale    = "stale"
+)
+
+// Options represents the input of the due command.
+type Options struct {
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Within is the interval ahead of today in which reviews are upcoming,
+	// such as 30d. Only overdue reviews are listed when it is empty.
+	Within string
+	// Stale is the interval after which a record that is neither accepted
+	// nor in a terminal status is a stale proposal, such as 90d. Proposals
+	// are not listed when it is empty.
+	Stale string
+	// Format is the output format, either text, json or ics.
+	Format string
+}
+
+// Item represents a record that is due for review, or a stale proposal.
+type Item struct {
+	Kind   string `json:"kind"`
+	Dir    string `json:"dir"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Path   string `json:"path"`
+	// Date is the review date of the record, or the date a stale proposal
+	// moved to its status.
+	Date string `json:"date"`
+	// Days is the number of days from today to the date, negative if the
+	// date has passed.
+	Days int `json:"days"`
+}
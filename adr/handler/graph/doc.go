@@ -0,0 +1,3 @@
+// Package graph provides handler functionality for the graph command, which
+// exports the links between decision records.
+package graph
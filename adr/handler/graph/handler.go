@@ -0,0 +1,298 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrUnknownFormat is returned when the requested output format is not
+// supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// statusColors holds the fill colour of the nodes of each known status.
+var statusColors = map[string]string{
+	"draft":      "#e0e0e0",
+	"proposed":   "#fff3b0",
+	"accepted":   "#b7e4c7",
+	"rejected":   "#f4a4a4",
+	"deprecated": "#ffd6a5",
+	"superseded": "#cdd5e0",
+}
+
+const defaultColor = "#ffffff"
+
+var unsafeID = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Handler describes a type that is used to handle the graph command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func nodeID(rec adr.Record) string {
+	return fmt.Sprintf("%s:%s", rec.Dir, rec.ID)
+}
+
+// normalize turns a link into the edge it represents. The inverse link
+// types are flipped, so a link written into both records is a single edge.
+func normalize(from, to string, linkType string) edge {
+	switch linkType {
+	case adr.LinkSupersededBy:
+		return edge{From: to, To: from, Type: strings.ToLower(adr.LinkSupersedes)}
+	case adr.LinkAmendedBy:
+		return edge{From: to, To: from, Type: strings.ToLower(adr.LinkAmends)}
+	case adr.LinkRelatesTo:
+		// Relates to has no direction, so the pair is ordered to dedupe it.
+		if to < from {
+			from, to = to, from
+		}
+	}
+
+	return edge{From: from, To: to, Type: strings.ToLower(linkType)}
+}
+
+// build produces the graph of the given records. Links to records outside
+// of the given set are left out.
+func build(records []adr.Record) graph {
+	byPath := map[string]adr.Record{}
+	for _, rec := range records {
+		byPath[rec.Path] = rec
+	}
+
+	g := graph{Nodes: []node{}, Edges: []edge{}}
+	seen := map[edge]bool{}
+
+	for _, rec := range records {
+		g.Nodes = append(g.Nodes, node{
+			ID:     nodeID(rec),
+			Title:  rec.Title,
+			Status: adr.BaseStatus(rec.Status),
+			Dir:    rec.Dir,
+			Path:   rec.Path,
+		})
+
+		for _, l := range rec.Links {
+			target, ok := byPath[l.Resolve(rec.Path)]
+			if !ok {
+				continue
+			}
+
+			e := normalize(nodeID(rec), nodeID(target), l.Type)
+			if !seen[e] {
+				seen[e] = true
+				g.Edges = append(g.Edges, e)
+			}
+		}
+	}
+
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+
+		if a.From != b.From {
+			return a.From < b.From
+		}
+
+		if a.To != b.To {
+			return a.To < b.To
+		}
+
+		return a.Type < b.Type
+	})
+
+	return g
+}
+
+func color(status string) string {
+	if c, ok := statusColors[strings.ToLower(status)]; ok {
+		return c
+	}
+
+	return defaultColor
+}
+
+func quote(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// groups returns the names of the dirs of the nodes in the order they are
+// first seen, along with the nodes of each dir.
+func groups(nodes []node) ([]string, map[string][]node) {
+	var names []string
+
+	byDir := map[string][]node{}
+
+	for _, n := range nodes {
+		if _, ok := byDir[n.Dir]; !ok {
+			names = append(names, n.Dir)
+		}
+
+		byDir[n.Dir] = append(byDir[n.Dir], n)
+	}
+
+	return names, byDir
+}
+
+// writeDOT writes the graph in the Graphviz DOT language. Each adr dir is a
+// cluster, and nodes are filled with the colour of their status.
+func writeDOT(out io.Writer, g graph) {
+	fmt.Fprintln(out, "digraph decisions {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, `  node [shape=box, style="rounded,filled"];`)
+
+	names, byDir := groups(g.Nodes)
+
+	for i, name := range names {
+		fmt.Fprintf(out, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(out, "    label=\"%s\";\n", quote(name))
+
+		for _, n := range byDir[name] {
+			fmt.Fprintf(out, "    \"%s\" [label=\"%s. %s\\n%s\", fillcolor=\"%s\"];\n",
+				quote(n.ID), quote(strings.TrimPrefix(n.ID, n.Dir+":")), quote(n.Title), quote(n.Status), color(n.Status),
+			)
+		}
+
+		fmt.Fprintln(out, "  }")
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(out, "  \"%s\" -> \"%s\" [label=\"%s\"];\n", quote(e.From), quote(e.To), e.Type)
+	}
+
+	fmt.Fprintln(out, "}")
+}
+
+func mermaidID(id string) string {
+	return unsafeID.ReplaceAllString(id, "_")
+}
+
+func mermaidText(value string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(value)
+}
+
+// writeMermaid writes the graph as a Mermaid flowchart. Each adr dir is a
+// subgraph, and nodes are styled with a class named after their status.
+func writeMermaid(out io.Writer, g graph) {
+	fmt.Fprintln(out, "flowchart LR")
+
+	names, byDir := groups(g.Nodes)
+	classes := map[string][]string{}
+
+	var statuses []string
+
+	for _, name := range names {
+		fmt.Fprintf(out, "  subgraph %s[\"%s\"]\n", mermaidID("dir_"+name), mermaidText(name))
+
+		for _, n := range byDir[name] {
+			fmt.Fprintf(out, "    %s[\"%s. %s\"]\n",
+				mermaidID(n.ID), mermaidText(strings.TrimPrefix(n.ID, n.Dir+":")), mermaidText(n.Title),
+			)
+
+			class := mermaidID(strings.ToLower(n.Status))
+			if class == "" {
+				continue
+			}
+
+			if _, ok := classes[class]; !ok {
+				statuses = append(statuses, class)
+			}
+
+			classes[class] = append(classes[class], mermaidID(n.ID))
+		}
+
+		fmt.Fprintln(out, "  end")
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(out, "  %s -->|%s| %s\n", mermaidID(e.From), e.Type, mermaidID(e.To))
+	}
+
+	for _, class := range statuses {
+		fmt.Fprintf(out, "  classDef %s fill:%s\n", class, color(class))
+		fmt.Fprintf(out, "  class %s %s\n", strings.Join(classes[class], ","), class)
+	}
+}
+
+func write(out io.Writer, format string, g graph) error {
+	switch format {
+	case FormatDOT:
+		writeDOT(out, g)
+	case FormatMermaid:
+		writeMermaid(out, g)
+	case FormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(g)
+	}
+
+	return nil
+}
+
+// Handle is the main Handler function. This function writes the graph of
+// the links between the records of the selected adr dirs.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatDOT, FormatMermaid, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	var records []adr.Record
+
+	for _, dir := range dirs {
+		recs, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		records = append(records, recs...)
+	}
+
+	if err = write(out, opts.Format, build(records)); err != nil {
+		return fmt.Errorf("write graph: %w", err)
+	}
+
+	return nil
+}
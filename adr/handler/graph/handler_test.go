@@ -0,0 +1,263 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/graph"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Record decisions",
+			Status: "Accepted",
+			Links: []adr.Link{
+				{Type: adr.LinkRelatesTo, Title: "0001. Add mTLS", Target: "../../security/0001-add-mtls.md"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0001-record-decisions.md",
+		},
+		{
+			ID:     "0002",
+			Title:  `Use "PostgreSQL"`,
+			Status: "Superseded by [0001. Add mTLS](../../security/0001-add-mtls.md)",
+			Links: []adr.Link{
+				{Type: adr.LinkSupersededBy, Title: "0001. Add mTLS", Target: "../../security/0001-add-mtls.md"},
+				{Type: adr.LinkAmends, Title: "0009. Missing", Target: "0009-missing.md"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0002-use-postgresql.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Add mTLS",
+			Status: "Proposed",
+			Links: []adr.Link{
+				{Type: adr.LinkSupersedes, Title: "0002. Use PostgreSQL", Target: "../docs/adr/0002-use-postgresql.md"},
+				{Type: adr.LinkRelatesTo, Title: "0001. Record decisions", Target: "../docs/adr/0001-record-decisions.md"},
+			},
+			Dir:  "security",
+			Path: "security/0001-add-mtls.md",
+		},
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) graph.StateManager
+		store        func(ctrl *gomock.Controller) graph.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	allDirsState := func(ctrl *gomock.Controller) graph.StateManager {
+		s := graph.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	allDirsStore := func(ctrl *gomock.Controller) graph.RecordStore {
+		s := graph.NewmockRecordStore(ctrl)
+		s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+		s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+		return s
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input graph.Options
+		wants want
+	}{
+		{
+			name: "dot",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: graph.Options{Format: graph.FormatDOT},
+			wants: want{
+				output: `digraph decisions {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled"];
+  subgraph cluster_0 {
+    label="platform";
+    "platform:0001" [label="0001. Record decisions\nAccepted", fillcolor="#b7e4c7"];
+    "platform:0002" [label="0002. Use \"PostgreSQL\"\nSuperseded", fillcolor="#cdd5e0"];
+  }
+  subgraph cluster_1 {
+    label="security";
+    "security:0001" [label="0001. Add mTLS\nProposed", fillcolor="#fff3b0"];
+  }
+  "platform:0001" -> "security:0001" [label="relates to"];
+  "security:0001" -> "platform:0002" [label="supersedes"];
+}
+`,
+			},
+		},
+		{
+			name: "mermaid",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: graph.Options{Format: graph.FormatMermaid},
+			wants: want{
+				output: `flowchart LR
+  subgraph dir_platform["platform"]
+    platform_0001["0001. Record decisions"]
+    platform_0002["0002. Use #quot;PostgreSQL#quot;"]
+  end
+  subgraph dir_security["security"]
+    security_0001["0001. Add mTLS"]
+  end
+  platform_0001 -->|relates to| security_0001
+  security_0001 -->|supersedes| platform_0002
+  classDef accepted fill:#b7e4c7
+  class platform_0001 accepted
+  classDef superseded fill:#cdd5e0
+  class platform_0002 superseded
+  classDef proposed fill:#fff3b0
+  class security_0001 proposed
+`,
+			},
+		},
+		{
+			name: "json of a single dir leaves out links to other dirs",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) graph.StateManager {
+					s := graph.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) graph.RecordStore {
+					s := graph.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					return s
+				},
+			},
+			input: graph.Options{Dir: "platform", Format: graph.FormatJSON},
+			wants: want{
+				output: `{
+  "nodes": [
+    {
+      "id": "platform:0001",
+      "title": "Record decisions",
+      "status": "Accepted",
+      "dir": "platform",
+      "path": "docs/adr/0001-record-decisions.md"
+    },
+    {
+      "id": "platform:0002",
+      "title": "Use \"PostgreSQL\"",
+      "status": "Superseded",
+      "dir": "platform",
+      "path": "docs/adr/0002-use-postgresql.md"
+    }
+  ],
+  "edges": []
+}
+`,
+			},
+		},
+		{
+			name: "unknown format",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) graph.StateManager {
+					return graph.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) graph.RecordStore {
+					return graph.NewmockRecordStore(ctrl)
+				},
+			},
+			input: graph.Options{Format: "svg"},
+			wants: want{
+				err: graph.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) graph.StateManager {
+					s := graph.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) graph.RecordStore {
+					return graph.NewmockRecordStore(ctrl)
+				},
+			},
+			input: graph.Options{Dir: "nope", Format: graph.FormatDOT},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "failing to list records",
+			setup: setup{
+				stateManager: allDirsState,
+				store: func(ctrl *gomock.Controller) graph.RecordStore {
+					s := graph.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(nil, os.ErrPermission)
+					return s
+				},
+			},
+			input: graph.Options{Format: graph.FormatDOT},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := graph.New(
+				graph.WithStateManager(tt.setup.stateManager(ctrl)),
+				graph.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wants.output, out.String())
+			}
+		})
+	}
+}
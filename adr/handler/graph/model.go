@@ -0,0 +1,40 @@
+package graph
+
+// The output formats supported by the graph command.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Options represents the input of the graph command.
+type Options struct {
+	// Dir limits the graph to the records of a single adr directory, by
+	// name or path.
+	Dir    string
+	Format string
+}
+
+// node represents a record within the graph.
+type node struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Dir    string `json:"dir"`
+	Path   string `json:"path"`
+}
+
+// edge represents a link from one record to another. Links that are written
+// into both records, such as supersedes and superseded by, produce a single
+// edge from the newer record to the older one.
+type edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// graph represents the nodes and edges that are written out.
+type graph struct {
+	Nodes []node `json:"nodes"`
+	Edges []edge `json:"edges"`
+}
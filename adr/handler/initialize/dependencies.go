@@ -1,10 +1,12 @@
-//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=initialize -mock_names FileSystem=mockFileSystem,StateManager=mockStateManager,Survey=mockSurvey
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=initialize -mock_names FileSystem=mockFileSystem,RecordStore=mockRecordStore,Renderer=mockRenderer,StateManager=mockStateManager,Survey=mockSurvey
 
 package initialize
 
 import (
 	survey "github.com/AlecAivazis/survey/v2"
 
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
 	"github.com/docula-io/docula/state"
 )
 
@@ -23,7 +25,23 @@ type FileSystem interface {
 	Mkdir(name string) error
 }
 
+// RecordStore represents a type that is able to read and write the records
+// that already exist within a directory, including those it cannot parse.
+type RecordStore interface {
+	Scan(stateDir string, dir adr.Directory) ([]adr.Record, []store.InvalidRecord, error)
+	Read(path string) ([]byte, error)
+	Apply(changes ...store.Change) error
+}
+
+// Renderer represents a type that is able to read the source of a template,
+// so that custom templates can be checked before they are saved.
+type Renderer interface {
+	Source(stateDir string, name string) ([]byte, error)
+}
+
 // Survey represents a type that is able to get various inputs from stdin.
+// The defaults are offered as the answers, and the number of existing
+// records decides whether to ask about normalizing them.
 type Survey interface {
-	Ask(opts ...survey.AskOpt) (Configuration, error)
+	Ask(defaults Configuration, existing int, opts ...survey.AskOpt) (Configuration, error)
 }
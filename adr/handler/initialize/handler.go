@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/adr/template"
 	"github.com/docula-io/docula/state"
 )
 
@@ -16,6 +19,8 @@ var ErrAlreadyIntialized = errors.New("adr dir already initialized")
 type Handler struct {
 	stateManager StateManager
 	fs           FileSystem
+	store        RecordStore
+	renderer     Renderer
 	survey       Survey
 }
 
@@ -27,6 +32,8 @@ func New(opts ...Option) *Handler {
 	h := &Handler{
 		stateManager: state.NewManager(),
 		fs:           &defaultFileSystem{},
+		store:        store.New(),
+		renderer:     template.New(),
 		survey:       &defaultSurvey{},
 	}
 
@@ -42,10 +49,27 @@ func New(opts ...Option) *Handler {
 type Configuration struct {
 	Name      string `survey:"name"`
 	IndexType string `survey:"index"`
+	Template  string `survey:"template"`
+	Normalize bool   `survey:"normalize"`
 }
 
-func (h *Handler) runSurvey() (Configuration, error) {
-	answers, err := h.survey.Ask()
+// existing represents the records that were already in the directory before
+// it was initialized, and the settings that were inferred from them.
+type existing struct {
+	records  []adr.Record
+	invalid  []store.InvalidRecord
+	data     map[string][]byte
+	index    string
+	template string
+}
+
+func (h *Handler) runSurvey(found existing) (Configuration, error) {
+	defaults := Configuration{
+		IndexType: found.index,
+		Template:  found.template,
+	}
+
+	answers, err := h.survey.Ask(defaults, len(found.records))
 	if err != nil {
 		return Configuration{}, err
 	}
@@ -53,6 +77,131 @@ func (h *Handler) runSurvey() (Configuration, error) {
 	return answers, nil
 }
 
+// scan reads the records that already exist within the directory, and
+// infers the index type from their names and the template from the layout
+// most of them share.
+func (h *Handler) scan(stateDir string, path string) (existing, error) {
+	found := existing{data: map[string][]byte{}}
+
+	records, invalid, err := h.store.Scan(stateDir, adr.Directory{Path: path})
+	if errors.Is(err, os.ErrNotExist) {
+		return found, nil
+	}
+
+	if err != nil {
+		return found, fmt.Errorf("list existing records: %w", err)
+	}
+
+	ids := make([]string, 0, len(records))
+	votes := map[string]int{}
+
+	for _, rec := range records {
+		data, err := h.store.Read(stateDir + rec.Path)
+		if err != nil {
+			return found, err
+		}
+
+		ids = append(ids, rec.ID)
+		found.data[rec.Path] = data
+
+		if name := template.Infer(data); name != "" {
+			votes[name]++
+		}
+	}
+
+	found.records = records
+	found.invalid = invalid
+	found.index = adr.InferIndex(ids)
+
+	for _, name := range template.Names() {
+		if votes[name] > votes[found.template] {
+			found.template = name
+		}
+	}
+
+	return found, nil
+}
+
+// report writes the records found within the directory, noting those that
+// cannot be parsed and would be left out of every command.
+func report(out io.Writer, path string, found existing) {
+	if len(found.records) == 0 && len(found.invalid) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "found %s in %s\n", adr.Plural(len(found.records), "record"), path)
+	fmt.Fprintf(out, "  index type: %s\n", found.index)
+
+	if found.template != "" {
+		fmt.Fprintf(out, "  template: %s\n", found.template)
+	} else {
+		fmt.Fprintln(out, "  template: not recognised")
+	}
+
+	for _, rec := range found.records {
+		switch {
+		case rec.Title == "":
+			fmt.Fprintf(out, "  %s: no title\n", rec.Path)
+		case rec.Status == "":
+			fmt.Fprintf(out, "  %s: no status\n", rec.Path)
+		}
+	}
+
+	for _, invalid := range found.invalid {
+		fmt.Fprintf(out, "  %s: cannot be parsed: %s\n", invalid.Path, invalid.Err)
+	}
+}
+
+// normalize converts the existing records into the layout docula writes.
+// Records without a status are left as they are.
+func (h *Handler) normalize(stateDir string, found existing) (int, error) {
+	var changes []store.Change
+
+	for _, rec := range found.records {
+		data := found.data[rec.Path]
+
+		updated, err := adr.Normalize(data, rec)
+		if errors.Is(err, adr.ErrNoStatus) {
+			continue
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("normalize %s: %w", rec.Path, err)
+		}
+
+		if string(updated) != string(data) {
+			changes = append(changes, store.Change{Path: stateDir + rec.Path, Data: updated})
+		}
+	}
+
+	if err := h.store.Apply(changes...); err != nil {
+		return 0, fmt.Errorf("write records: %w", err)
+	}
+
+	return len(changes), nil
+}
+
+// templatePath returns the path of a custom template relative to the state
+// dir, checking that the template can be read from within the project. The
+// path is given relative to the working dir. Built-in templates are returned
+// as they are.
+func (h *Handler) templatePath(stateDir string, name string) (string, error) {
+	if name == "" || template.IsBuiltin(name) {
+		return name, nil
+	}
+
+	path, err := h.stateManager.NormalizePath(name)
+	if err != nil {
+		return "", fmt.Errorf("normalize path: %w", err)
+	}
+
+	if _, err = h.renderer.Source(stateDir, path); err != nil {
+		return "", fmt.Errorf("custom template: %w", err)
+	}
+
+	return path, nil
+}
+
 func (h *Handler) checkExistingADRs(s state.State, dir adr.Directory) error {
 	// Check path is not already an ADR dir
 	for _, adrDir := range s.ADR.Directories {
@@ -64,16 +213,11 @@ func (h *Handler) checkExistingADRs(s state.State, dir adr.Directory) error {
 	return nil
 }
 
-func (h *Handler) createDir(path string) error {
-	stateDir, err := h.stateManager.StateDir()
-	if err != nil {
-		return fmt.Errorf("obtain state path: %w", err)
-	}
-
+func (h *Handler) createDir(stateDir string, path string) error {
 	absPath := fmt.Sprintf("%s%s", stateDir, path)
 
 	// Create path if not exists
-	if err = h.fs.Mkdir(absPath); err != nil && !errors.Is(err, os.ErrExist) {
+	if err := h.fs.Mkdir(absPath); err != nil && !errors.Is(err, os.ErrExist) {
 		return fmt.Errorf("create adr dir: %w", err)
 	}
 
@@ -81,8 +225,9 @@ func (h *Handler) createDir(path string) error {
 }
 
 // Handle is the main Handler function. This function is used to initialize
-// a new directory as an adr dir.
-func (h *Handler) Handle(ctx context.Context, path string) error {
+// a new directory as an adr dir. Records that already exist within the
+// directory are reported, and are used to suggest its configuration.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, path string) error {
 	path, err := h.stateManager.NormalizePath(path)
 	if err != nil {
 		return fmt.Errorf("normalize path: %w", err)
@@ -94,25 +239,51 @@ func (h *Handler) Handle(ctx context.Context, path string) error {
 		return fmt.Errorf("loading state: %w", err)
 	}
 
-	config, err := h.runSurvey()
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	found, err := h.scan(stateDir, path)
+	if err != nil {
+		return err
+	}
+
+	report(out, path, found)
+
+	config, err := h.runSurvey(found)
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 
-	if err = h.createDir(path); err != nil {
+	if config.Template, err = h.templatePath(stateDir, config.Template); err != nil {
+		return err
+	}
+
+	if err = h.createDir(stateDir, path); err != nil {
 		return err
 	}
 
 	dir := adr.Directory{
-		Path:  path,
-		Name:  config.Name,
-		Index: config.IndexType,
+		Path:     path,
+		Name:     config.Name,
+		Index:    config.IndexType,
+		Template: config.Template,
 	}
 
 	if err = h.checkExistingADRs(s, dir); err != nil {
 		return err
 	}
 
+	if config.Normalize {
+		count, err := h.normalize(stateDir, found)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "normalized %s\n", adr.Plural(count, "record"))
+	}
+
 	// Update the ADR part
 	s.ADR.Directories = append(s.ADR.Directories, dir)
 
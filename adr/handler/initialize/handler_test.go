@@ -1,8 +1,10 @@
 package initialize_test
 
 import (
+	"bytes"
 	"context"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,18 +12,29 @@ import (
 
 	"github.com/docula-io/docula/adr"
 	"github.com/docula-io/docula/adr/handler/initialize"
+	adrstore "github.com/docula-io/docula/adr/store"
 	"github.com/docula-io/docula/state"
 )
 
 var defaultConfig = initialize.Configuration{
 	Name:      "bar",
 	IndexType: "timestamp",
+	Template:  "madr",
+}
+
+// noRecords is the store of a dir that does not exist yet.
+func noRecords(ctrl *gomock.Controller) initialize.RecordStore {
+	s := initialize.NewmockRecordStore(ctrl)
+	s.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(nil, nil, os.ErrNotExist).AnyTimes()
+	return s
 }
 
 func TestHandler(t *testing.T) {
 	type setup struct {
 		stateManager func(ctrl *gomock.Controller) initialize.StateManager
 		fs           func(ctrl *gomock.Controller) initialize.FileSystem
+		store        func(ctrl *gomock.Controller) initialize.RecordStore
+		renderer     func(ctrl *gomock.Controller) initialize.Renderer
 		survey       func(ctrl *gomock.Controller) initialize.Survey
 	}
 
@@ -43,9 +56,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "foo/bar",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "foo/bar",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -60,7 +74,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -98,9 +112,10 @@ func TestHandler(t *testing.T) {
 									Index: "sequential",
 								},
 								{
-									Path:  "foo/bar",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "foo/bar",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -115,7 +130,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -134,9 +149,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "baz/foo",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "baz/foo",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -151,7 +167,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -170,9 +186,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "baz/foo",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "baz/foo",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -187,7 +204,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -205,9 +222,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "foo/bar",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "foo/bar",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -222,13 +240,93 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
 			input: "foo/bar",
 			wants: initialize.ErrAlreadyIntialized,
 		},
+		{
+			name: "with a custom template",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) initialize.StateManager {
+					s := initialize.NewmockStateManager(ctrl)
+					s.EXPECT().NormalizePath("foo/bar").Return("foo/bar", nil)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					s.EXPECT().NormalizePath("../templates/decision.md").Return("templates/decision.md", nil)
+					s.EXPECT().Save(state.State{
+						ADR: adr.State{
+							Directories: []adr.Directory{
+								{
+									Path:     "foo/bar",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "templates/decision.md",
+								},
+							},
+						},
+					}).Return(nil)
+
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) initialize.FileSystem {
+					fs := initialize.NewmockFileSystem(ctrl)
+					fs.EXPECT().Mkdir("/foo/bar").Return(nil)
+					return fs
+				},
+				renderer: func(ctrl *gomock.Controller) initialize.Renderer {
+					r := initialize.NewmockRenderer(ctrl)
+					r.EXPECT().Source("/", "templates/decision.md").Return([]byte("# {{ .Title }}\n"), nil)
+					return r
+				},
+				survey: func(ctrl *gomock.Controller) initialize.Survey {
+					s := initialize.NewmockSurvey(ctrl)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(initialize.Configuration{
+						Name:      "bar",
+						IndexType: "timestamp",
+						Template:  "../templates/decision.md",
+					}, nil)
+					return s
+				},
+			},
+			input: "foo/bar",
+			wants: nil,
+		},
+		{
+			name: "with a missing custom template",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) initialize.StateManager {
+					s := initialize.NewmockStateManager(ctrl)
+					s.EXPECT().NormalizePath("foo/bar").Return("foo/bar", nil)
+					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					s.EXPECT().NormalizePath("templates/missing.md").Return("templates/missing.md", nil)
+
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) initialize.FileSystem {
+					return initialize.NewmockFileSystem(ctrl)
+				},
+				renderer: func(ctrl *gomock.Controller) initialize.Renderer {
+					r := initialize.NewmockRenderer(ctrl)
+					r.EXPECT().Source("/", "templates/missing.md").Return(nil, os.ErrNotExist)
+					return r
+				},
+				survey: func(ctrl *gomock.Controller) initialize.Survey {
+					s := initialize.NewmockSurvey(ctrl)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(initialize.Configuration{
+						Name:      "bar",
+						IndexType: "timestamp",
+						Template:  "templates/missing.md",
+					}, nil)
+					return s
+				},
+			},
+			input: "foo/bar",
+			wants: os.ErrNotExist,
+		},
 		{
 			name: "failing to normalize path",
 			setup: setup{
@@ -254,6 +352,7 @@ func TestHandler(t *testing.T) {
 					s := initialize.NewmockStateManager(ctrl)
 					s.EXPECT().NormalizePath("hello/world").Return("foo", nil)
 					s.EXPECT().Load().Return(state.State{}, nil)
+					s.EXPECT().StateDir().Return("/home/user/", nil)
 					return s
 				},
 				fs: func(ctrl *gomock.Controller) initialize.FileSystem {
@@ -262,7 +361,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(
 						initialize.Configuration{}, os.ErrDeadlineExceeded,
 					)
 					return s
@@ -308,9 +407,7 @@ func TestHandler(t *testing.T) {
 					return fs
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
-					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
-					return s
+					return initialize.NewmockSurvey(ctrl)
 				},
 			},
 			input: "hello/world",
@@ -333,7 +430,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -352,9 +449,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "foo",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "foo",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -369,7 +467,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -387,9 +485,10 @@ func TestHandler(t *testing.T) {
 						ADR: adr.State{
 							Directories: []adr.Directory{
 								{
-									Path:  "foo",
-									Name:  "bar",
-									Index: "timestamp",
+									Path:     "foo",
+									Name:     "bar",
+									Index:    "timestamp",
+									Template: "madr",
 								},
 							},
 						},
@@ -404,7 +503,7 @@ func TestHandler(t *testing.T) {
 				},
 				survey: func(ctrl *gomock.Controller) initialize.Survey {
 					s := initialize.NewmockSurvey(ctrl)
-					s.EXPECT().Ask().Return(defaultConfig, nil)
+					s.EXPECT().Ask(initialize.Configuration{}, 0).Return(defaultConfig, nil)
 					return s
 				},
 			},
@@ -424,13 +523,25 @@ func TestHandler(t *testing.T) {
 			fs := tt.setup.fs(ctrl)
 			survey := tt.setup.survey(ctrl)
 
+			store := noRecords
+			if tt.setup.store != nil {
+				store = tt.setup.store
+			}
+
+			renderer := initialize.Renderer(initialize.NewmockRenderer(ctrl))
+			if tt.setup.renderer != nil {
+				renderer = tt.setup.renderer(ctrl)
+			}
+
 			h := initialize.New(
 				initialize.WithFileSystem(fs),
 				initialize.WithStateManager(sm),
+				initialize.WithRecordStore(store(ctrl)),
+				initialize.WithRenderer(renderer),
 				initialize.WithSurvey(survey),
 			)
 
-			err := h.Handle(context.Background(), tt.input)
+			err := h.Handle(context.Background(), &bytes.Buffer{}, tt.input)
 
 			if tt.wants != nil {
 				assert.ErrorIs(t, err, tt.wants)
@@ -440,3 +551,102 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerExistingRecords(t *testing.T) {
+	first := "# 1. Use PostgreSQL\n\n* Status: accepted\n* Deciders: Jane\n* Date: 2021-04-12\n\n" +
+		"## Context and Problem Statement\n\n## Decision Outcome\n"
+	second := "# Use Redis\n\n* Deciders: John\n\n## Context and Problem Statement\n\n## Decision Outcome\n"
+
+	records := make([]adr.Record, 0, 2)
+
+	for name, data := range map[string]string{"0001-use-postgresql.md": first, "0002-use-redis.md": second} {
+		rec, err := adr.ParseRecord(name, []byte(data))
+		assert.NoError(t, err)
+
+		rec.Path = "docs/decisions/" + name
+		records = append(records, rec)
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+
+	invalid := []adrstore.InvalidRecord{
+		{Path: "docs/decisions/0003-use-nats.md", Err: adr.ErrInvalidFrontmatter},
+	}
+
+	testCases := []struct {
+		name      string
+		normalize bool
+		wants     string
+	}{
+		{
+			name:      "normalizing the records",
+			normalize: true,
+			wants: "found 2 records in docs/decisions\n" +
+				"  index type: sequential\n" +
+				"  template: madr\n" +
+				"  docs/decisions/0002-use-redis.md: no status\n" +
+				"  docs/decisions/0003-use-nats.md: cannot be parsed: invalid frontmatter\n" +
+				"normalized 1 record\n",
+		},
+		{
+			name: "leaving the records",
+			wants: "found 2 records in docs/decisions\n" +
+				"  index type: sequential\n" +
+				"  template: madr\n" +
+				"  docs/decisions/0002-use-redis.md: no status\n" +
+				"  docs/decisions/0003-use-nats.md: cannot be parsed: invalid frontmatter\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := adr.Directory{Path: "docs/decisions", Name: "legacy", Index: "sequential", Template: "madr"}
+
+			sm := initialize.NewmockStateManager(ctrl)
+			sm.EXPECT().NormalizePath("docs/decisions").Return("docs/decisions", nil)
+			sm.EXPECT().Load().Return(state.State{}, nil)
+			sm.EXPECT().StateDir().Return("/", nil)
+			sm.EXPECT().Save(state.State{ADR: adr.State{Directories: []adr.Directory{dir}}}).Return(nil)
+
+			fs := initialize.NewmockFileSystem(ctrl)
+			fs.EXPECT().Mkdir("/docs/decisions").Return(os.ErrExist)
+
+			store := initialize.NewmockRecordStore(ctrl)
+			store.EXPECT().Scan("/", adr.Directory{Path: "docs/decisions"}).Return(records, invalid, nil)
+			store.EXPECT().Read("/docs/decisions/0001-use-postgresql.md").Return([]byte(first), nil)
+			store.EXPECT().Read("/docs/decisions/0002-use-redis.md").Return([]byte(second), nil)
+
+			if tt.normalize {
+				store.EXPECT().Apply(adrstore.Change{
+					Path: "/docs/decisions/0001-use-postgresql.md",
+					Data: []byte("# Use PostgreSQL\n\n* Status: accepted\n* Deciders: Jane\n* Date: 2021-04-12\n\n" +
+						"<!-- docula:status-history -->\n- 2021-04-12: accepted\n<!-- /docula:status-history -->\n\n" +
+						"## Context and Problem Statement\n\n## Decision Outcome\n"),
+				}).Return(nil)
+			}
+
+			survey := initialize.NewmockSurvey(ctrl)
+			survey.EXPECT().Ask(initialize.Configuration{IndexType: "sequential", Template: "madr"}, 2).Return(
+				initialize.Configuration{Name: "legacy", IndexType: "sequential", Template: "madr", Normalize: tt.normalize}, nil,
+			)
+
+			h := initialize.New(
+				initialize.WithFileSystem(fs),
+				initialize.WithStateManager(sm),
+				initialize.WithRecordStore(store),
+				initialize.WithSurvey(survey),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, "docs/decisions")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, out.String())
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	reflect "reflect"
 
 	v2 "github.com/AlecAivazis/survey/v2"
+	adr "github.com/docula-io/docula/adr"
+	store "github.com/docula-io/docula/adr/store"
 	state "github.com/docula-io/docula/state"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -131,6 +133,116 @@ func (mr *mockFileSystemMockRecorder) Mkdir(name interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mkdir", reflect.TypeOf((*mockFileSystem)(nil).Mkdir), name)
 }
 
+// mockRecordStore is a mock of RecordStore interface.
+type mockRecordStore struct {
+	ctrl     *gomock.Controller
+	recorder *mockRecordStoreMockRecorder
+}
+
+// mockRecordStoreMockRecorder is the mock recorder for mockRecordStore.
+type mockRecordStoreMockRecorder struct {
+	mock *mockRecordStore
+}
+
+// NewmockRecordStore creates a new mock instance.
+func NewmockRecordStore(ctrl *gomock.Controller) *mockRecordStore {
+	mock := &mockRecordStore{ctrl: ctrl}
+	mock.recorder = &mockRecordStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *mockRecordStore) EXPECT() *mockRecordStoreMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *mockRecordStore) Apply(changes ...store.Change) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range changes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Apply", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *mockRecordStoreMockRecorder) Apply(changes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*mockRecordStore)(nil).Apply), changes...)
+}
+
+// Read mocks base method.
+func (m *mockRecordStore) Read(path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *mockRecordStoreMockRecorder) Read(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*mockRecordStore)(nil).Read), path)
+}
+
+// Scan mocks base method.
+func (m *mockRecordStore) Scan(stateDir string, dir adr.Directory) ([]adr.Record, []store.InvalidRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", stateDir, dir)
+	ret0, _ := ret[0].([]adr.Record)
+	ret1, _ := ret[1].([]store.InvalidRecord)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *mockRecordStoreMockRecorder) Scan(stateDir, dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*mockRecordStore)(nil).Scan), stateDir, dir)
+}
+
+// mockRenderer is a mock of Renderer interface.
+type mockRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *mockRendererMockRecorder
+}
+
+// mockRendererMockRecorder is the mock recorder for mockRenderer.
+type mockRendererMockRecorder struct {
+	mock *mockRenderer
+}
+
+// NewmockRenderer creates a new mock instance.
+func NewmockRenderer(ctrl *gomock.Controller) *mockRenderer {
+	mock := &mockRenderer{ctrl: ctrl}
+	mock.recorder = &mockRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *mockRenderer) EXPECT() *mockRendererMockRecorder {
+	return m.recorder
+}
+
+// Source mocks base method.
+func (m *mockRenderer) Source(stateDir, name string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Source", stateDir, name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Source indicates an expected call of Source.
+func (mr *mockRendererMockRecorder) Source(stateDir, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Source", reflect.TypeOf((*mockRenderer)(nil).Source), stateDir, name)
+}
+
 // mockSurvey is a mock of Survey interface.
 type mockSurvey struct {
 	ctrl     *gomock.Controller
@@ -155,9 +267,9 @@ func (m *mockSurvey) EXPECT() *mockSurveyMockRecorder {
 }
 
 // Ask mocks base method.
-func (m *mockSurvey) Ask(opts ...v2.AskOpt) (Configuration, error) {
+func (m *mockSurvey) Ask(defaults Configuration, existing int, opts ...v2.AskOpt) (Configuration, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
+	varargs := []interface{}{defaults, existing}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
@@ -168,7 +280,8 @@ func (m *mockSurvey) Ask(opts ...v2.AskOpt) (Configuration, error) {
 }
 
 // Ask indicates an expected call of Ask.
-func (mr *mockSurveyMockRecorder) Ask(opts ...interface{}) *gomock.Call {
+func (mr *mockSurveyMockRecorder) Ask(defaults, existing interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ask", reflect.TypeOf((*mockSurvey)(nil).Ask), opts...)
+	varargs := append([]interface{}{defaults, existing}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ask", reflect.TypeOf((*mockSurvey)(nil).Ask), varargs...)
 }
@@ -24,3 +24,17 @@ func WithSurvey(survey Survey) Option {
 		h.survey = survey
 	}
 }
+
+// WithRecordStore is used to override the internal RecordStore of the handler.
+func WithRecordStore(store RecordStore) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
+
+// WithRenderer is used to override the internal Renderer of the handler.
+func WithRenderer(renderer Renderer) Option {
+	return func(h *Handler) {
+		h.renderer = renderer
+	}
+}
@@ -4,33 +4,108 @@ import (
 	"fmt"
 
 	survey "github.com/AlecAivazis/survey/v2"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/template"
 )
 
+// customTemplate is the choice of a template that asks for the path of a
+// custom template.
+const customTemplate = "custom path"
+
 type defaultSurvey struct{}
 
-var questions = []*survey.Question{
-	{
-		Name:      "name",
-		Prompt:    &survey.Input{Message: "What should we name this dir?"},
-		Validate:  survey.Required,
-		Transform: survey.ToLower,
-	},
-	{
-		Name: "index",
-		Prompt: &survey.Select{
-			Message: "Choose an index type",
-			Options: []string{"timestamp", "sequential"},
-			Default: "timestamp",
+func questions(defaults Configuration) []*survey.Question {
+	index := defaults.IndexType
+	if index == "" {
+		index = adr.IndexTimestamp
+	}
+
+	tmpl := defaults.Template
+
+	switch {
+	case tmpl == "":
+		tmpl = template.Default
+	case !template.IsBuiltin(tmpl):
+		tmpl = customTemplate
+	}
+
+	return []*survey.Question{
+		{
+			Name:      "name",
+			Prompt:    &survey.Input{Message: "What should we name this dir?", Default: defaults.Name},
+			Validate:  survey.Required,
+			Transform: survey.ToLower,
+		},
+		{
+			Name: "index",
+			Prompt: &survey.Select{
+				Message: "Choose an index type",
+				Options: adr.IndexTypes(),
+				Default: index,
+			},
+		},
+		{
+			Name: "template",
+			Prompt: &survey.Select{
+				Message: "Choose a record template",
+				Options: append(template.Names(), customTemplate),
+				Default: tmpl,
+			},
+		},
+	}
+}
+
+// templateQuestions ask for the path of a custom template, relative to the
+// working dir.
+func templateQuestions(defaults Configuration) []*survey.Question {
+	path := defaults.Template
+	if template.IsBuiltin(path) {
+		path = ""
+	}
+
+	return []*survey.Question{
+		{
+			Name:     "template",
+			Prompt:   &survey.Input{Message: "What is the path of the custom template?", Default: path},
+			Validate: survey.Required,
+		},
+	}
+}
+
+func normalizeQuestions(existing int) []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "normalize",
+			Prompt: &survey.Confirm{
+				Message: fmt.Sprintf("Normalize the metadata of the %d existing records?", existing),
+				Default: true,
+			},
 		},
-	},
+	}
 }
 
-func (s *defaultSurvey) Ask(opts ...survey.AskOpt) (Configuration, error) {
+// Ask asks for the configuration of the dir. The path of a custom template
+// is only asked for when it is chosen, and the existing records are only
+// offered for normalizing when there are any.
+func (s *defaultSurvey) Ask(defaults Configuration, existing int, opts ...survey.AskOpt) (Configuration, error) {
 	var answers Configuration
 
-	if err := survey.Ask(questions, &answers, opts...); err != nil {
+	if err := survey.Ask(questions(defaults), &answers, opts...); err != nil {
 		return answers, fmt.Errorf("asking survey: %w", err)
 	}
 
+	if answers.Template == customTemplate {
+		if err := survey.Ask(templateQuestions(defaults), &answers, opts...); err != nil {
+			return answers, fmt.Errorf("asking survey: %w", err)
+		}
+	}
+
+	if existing > 0 {
+		if err := survey.Ask(normalizeQuestions(existing), &answers, opts...); err != nil {
+			return answers, fmt.Errorf("asking survey: %w", err)
+		}
+	}
+
 	return answers, nil
 }
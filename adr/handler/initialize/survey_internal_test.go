@@ -14,13 +14,18 @@ import (
 const (
 	nameLine      = "What should we name this dir?"
 	indexTypeLine = "Choose an index type"
+	templateLine  = "Choose a record template"
+	customLine    = "What is the path of the custom template?"
+	normalizeLine = "Normalize the metadata of the 3 existing records?"
 )
 
 func TestDefaultSurveyAsk(t *testing.T) {
 	testCases := []struct {
-		name  string
-		input func(t *testing.T, c *expect.Console)
-		wants Configuration
+		name     string
+		defaults Configuration
+		existing int
+		input    func(t *testing.T, c *expect.Console)
+		wants    Configuration
 	}{
 		{
 			name: "default index option",
@@ -37,11 +42,18 @@ func TestDefaultSurveyAsk(t *testing.T) {
 
 				assert.NoError(t, err)
 
+				c.ExpectString(templateLine)
+
+				_, err = c.SendLine("")
+
+				assert.NoError(t, err)
+
 				c.ExpectEOF()
 			},
 			wants: Configuration{
 				Name:      "foobar",
 				IndexType: "timestamp",
+				Template:  "nygard",
 			},
 		},
 		{
@@ -60,11 +72,93 @@ func TestDefaultSurveyAsk(t *testing.T) {
 				_, err = c.SendLine("")
 				assert.NoError(t, err)
 
+				c.ExpectString(templateLine)
+
+				_, err = c.Send(string(terminal.KeyArrowDown))
+				assert.NoError(t, err)
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
 				c.ExpectEOF()
 			},
 			wants: Configuration{
 				Name:      "barfoo",
 				IndexType: "sequential",
+				Template:  "madr",
+			},
+		},
+		{
+			name: "custom template",
+			input: func(t *testing.T, c *expect.Console) {
+				c.ExpectString(nameLine)
+
+				_, err := c.SendLine("custom")
+				assert.NoError(t, err)
+
+				c.ExpectString(indexTypeLine)
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
+				c.ExpectString(templateLine)
+
+				for i := 0; i < 4; i++ {
+					_, err = c.Send(string(terminal.KeyArrowDown))
+					assert.NoError(t, err)
+				}
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
+				c.ExpectString(customLine)
+
+				_, err = c.SendLine("templates/decision.md")
+				assert.NoError(t, err)
+
+				c.ExpectEOF()
+			},
+			wants: Configuration{
+				Name:      "custom",
+				IndexType: "timestamp",
+				Template:  "templates/decision.md",
+			},
+		},
+		{
+			name: "defaults inferred from existing records",
+			defaults: Configuration{
+				IndexType: "sequential",
+				Template:  "madr",
+			},
+			existing: 3,
+			input: func(t *testing.T, c *expect.Console) {
+				c.ExpectString(nameLine)
+
+				_, err := c.SendLine("legacy")
+				assert.NoError(t, err)
+
+				c.ExpectString(indexTypeLine)
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
+				c.ExpectString(templateLine)
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
+				c.ExpectString(normalizeLine)
+
+				_, err = c.SendLine("")
+				assert.NoError(t, err)
+
+				c.ExpectEOF()
+			},
+			wants: Configuration{
+				Name:      "legacy",
+				IndexType: "sequential",
+				Template:  "madr",
+				Normalize: true,
 			},
 		},
 	}
@@ -93,7 +187,7 @@ func TestDefaultSurveyAsk(t *testing.T) {
 
 			s := defaultSurvey{}
 
-			res, err := s.Ask(survey.WithStdio(stdio.In, stdio.Out, stdio.Err))
+			res, err := s.Ask(tt.defaults, tt.existing, survey.WithStdio(stdio.In, stdio.Out, stdio.Err))
 			assert.NoError(t, err)
 
 			assert.Equal(t, tt.wants, res)
@@ -0,0 +1,2 @@
+// Package list provides handler functionality for the list command.
+package list
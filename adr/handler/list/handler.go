@@ -0,0 +1,260 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/query"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrUnknownFormat is returned when the requested output format is not
+	// supported.
+	ErrUnknownFormat = errors.New("unknown output format")
+
+	// ErrUnknownSort is returned when the requested sort field is not
+	// supported.
+	ErrUnknownSort = errors.New("unknown sort field")
+)
+
+// Handler describes a type that is used to handle the list command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func validate(opts Options) error {
+	switch opts.Format {
+	case FormatTable, FormatJSON, FormatYAML, FormatIDs:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	switch opts.Sort {
+	case SortID, SortTitle, SortStatus, SortDate:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSort, opts.Sort)
+	}
+
+	return nil
+}
+
+// matches reports whether the record passes each of the filters. A record
+// passes the status and tag filters if it matches any of the given values.
+func matches(rec adr.Record, opts Options, q *query.Query) bool {
+	if q != nil && !q.Match(rec) {
+		return false
+	}
+
+	if len(opts.Statuses) > 0 && !matchesAny(opts.Statuses, rec.HasStatus) {
+		return false
+	}
+
+	if len(opts.Tags) > 0 && !matchesAny(opts.Tags, rec.HasTag) {
+		return false
+	}
+
+	if !opts.Since.IsZero() && (rec.Date.IsZero() || rec.Date.Before(opts.Since)) {
+		return false
+	}
+
+	if !opts.Until.IsZero() && (rec.Date.IsZero() || rec.Date.After(opts.Until)) {
+		return false
+	}
+
+	return true
+}
+
+func matchesAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func less(field string, a, b adr.Record) bool {
+	var cmp int
+
+	switch field {
+	case SortTitle:
+		cmp = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case SortStatus:
+		cmp = strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+	case SortDate:
+		switch {
+		case a.Date.Before(b.Date):
+			cmp = -1
+		case a.Date.After(b.Date):
+			cmp = 1
+		}
+	}
+
+	if cmp == 0 {
+		cmp = strings.Compare(a.Dir, b.Dir)
+	}
+
+	if cmp == 0 {
+		cmp = adr.CompareIDs(a.ID, b.ID)
+	}
+
+	return cmp < 0
+}
+
+func sortRecords(records []adr.Record, field string, reverse bool) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if reverse {
+			return less(field, records[j], records[i])
+		}
+
+		return less(field, records[i], records[j])
+	})
+}
+
+func toEntries(records []adr.Record) []entry {
+	entries := make([]entry, 0, len(records))
+
+	for _, rec := range records {
+		e := entry{
+			ID:     rec.ID,
+			Title:  rec.Title,
+			Status: rec.Status,
+			Tags:   rec.Tags,
+			Dir:    rec.Dir,
+			Path:   rec.Path,
+		}
+
+		if !rec.Date.IsZero() {
+			e.Date = rec.Date.Format(adr.DateFormat)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+func write(out io.Writer, format string, entries []entry) error {
+	switch format {
+	case FormatIDs:
+		for _, e := range entries {
+			fmt.Fprintf(out, "%s:%s\n", e.Dir, e.ID)
+		}
+
+		return nil
+	case FormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(entries)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(out)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(entries); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tDATE\tDIR")
+
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.Title, e.Status, e.Date, e.Dir)
+	}
+
+	return w.Flush()
+}
+
+// Handle is the main Handler function. This function is used to print the
+// records of the adr dirs that match the given options.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	if err := validate(opts); err != nil {
+		return err
+	}
+
+	var q *query.Query
+
+	if opts.Query != "" {
+		parsed, err := query.Parse(opts.Query, h.now())
+		if err != nil {
+			return err
+		}
+
+		q = &parsed
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	var records []adr.Record
+
+	for _, dir := range dirs {
+		recs, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range recs {
+			if matches(rec, opts, q) {
+				records = append(records, rec)
+			}
+		}
+	}
+
+	sortRecords(records, opts.Sort, opts.Reverse)
+
+	if err = write(out, opts.Format, toEntries(records)); err != nil {
+		return fmt.Errorf("write records: %w", err)
+	}
+
+	return nil
+}
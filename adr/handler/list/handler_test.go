@@ -0,0 +1,344 @@
+package list_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/list"
+	"github.com/docula-io/docula/adr/query"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Record decisions",
+			Status: "Accepted",
+			Date:   date(2022, 1, 10),
+			Dir:    "platform",
+			Path:   "docs/adr/0001-record-decisions.md",
+		},
+		{
+			ID:     "0002",
+			Title:  "Use PostgreSQL",
+			Status: "Proposed",
+			Date:   date(2022, 3, 2),
+			Tags:   []string{"db", "storage"},
+			Dir:    "platform",
+			Path:   "docs/adr/0002-use-postgresql.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Add mTLS",
+			Status: "Proposed",
+			Date:   date(2022, 2, 20),
+			Tags:   []string{"network"},
+			Dir:    "security",
+			Path:   "security/0001-add-mtls.md",
+		},
+	}
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func defaultOpts(modify func(o *list.Options)) list.Options {
+	opts := list.Options{
+		Sort:   list.SortID,
+		Format: list.FormatTable,
+	}
+
+	if modify != nil {
+		modify(&opts)
+	}
+
+	return opts
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) list.StateManager
+		store        func(ctrl *gomock.Controller) list.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	allDirsState := func(ctrl *gomock.Controller) list.StateManager {
+		s := list.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	allDirsStore := func(ctrl *gomock.Controller) list.RecordStore {
+		s := list.NewmockRecordStore(ctrl)
+		s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+		s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+		return s
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input list.Options
+		wants want
+	}{
+		{
+			name: "table of every dir",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: defaultOpts(nil),
+			wants: want{
+				output: "ID    TITLE             STATUS    DATE        DIR\n" +
+					"0001  Record decisions  Accepted  2022-01-10  platform\n" +
+					"0002  Use PostgreSQL    Proposed  2022-03-02  platform\n" +
+					"0001  Add mTLS          Proposed  2022-02-20  security\n",
+			},
+		},
+		{
+			name: "filtered by status and sorted by date",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Statuses = []string{"proposed"}
+				o.Sort = list.SortDate
+				o.Reverse = true
+			}),
+			wants: want{
+				output: "ID    TITLE           STATUS    DATE        DIR\n" +
+					"0002  Use PostgreSQL  Proposed  2022-03-02  platform\n" +
+					"0001  Add mTLS        Proposed  2022-02-20  security\n",
+			},
+		},
+		{
+			name: "filtered by tag and date range as json",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Tags = []string{"db", "network"}
+				o.Since = date(2022, 2, 1)
+				o.Until = date(2022, 2, 28)
+				o.Format = list.FormatJSON
+			}),
+			wants: want{
+				output: `[
+  {
+    "id": "0001",
+    "title": "Add mTLS",
+    "status": "Proposed",
+    "date": "2022-02-20",
+    "tags": [
+      "network"
+    ],
+    "dir": "security",
+    "path": "security/0001-add-mtls.md"
+  }
+]
+`,
+			},
+		},
+		{
+			name: "single dir by path as yaml",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					s := list.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("./docs/adr").Return("docs/adr", nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					s := list.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords[:1], nil)
+					return s
+				},
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Dir = "./docs/adr"
+				o.Format = list.FormatYAML
+			}),
+			wants: want{
+				output: `- id: "0001"
+  title: Record decisions
+  status: Accepted
+  date: "2022-01-10"
+  dir: platform
+  path: docs/adr/0001-record-decisions.md
+`,
+			},
+		},
+		{
+			name: "selected by query as ids",
+			setup: setup{
+				stateManager: allDirsState,
+				store:        allDirsStore,
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Query = "status = proposed and (tag = db or date < today - 20y)"
+				o.Format = list.FormatIDs
+			}),
+			wants: want{
+				output: "platform:0002\n",
+			},
+		},
+		{
+			name: "invalid query",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					return list.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					return list.NewmockRecordStore(ctrl)
+				},
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Query = "status ="
+			}),
+			wants: want{
+				err: query.ErrSyntax,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					s := list.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					return list.NewmockRecordStore(ctrl)
+				},
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Dir = "nope"
+			}),
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "unknown format",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					return list.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					return list.NewmockRecordStore(ctrl)
+				},
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Format = "xml"
+			}),
+			wants: want{
+				err: list.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown sort",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					return list.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					return list.NewmockRecordStore(ctrl)
+				},
+			},
+			input: defaultOpts(func(o *list.Options) {
+				o.Sort = "size"
+			}),
+			wants: want{
+				err: list.ErrUnknownSort,
+			},
+		},
+		{
+			name: "failing to load the state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) list.StateManager {
+					s := list.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					return list.NewmockRecordStore(ctrl)
+				},
+			},
+			input: defaultOpts(nil),
+			wants: want{
+				err: state.ErrNotFound,
+			},
+		},
+		{
+			name: "failing to list records",
+			setup: setup{
+				stateManager: allDirsState,
+				store: func(ctrl *gomock.Controller) list.RecordStore {
+					s := list.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(nil, os.ErrPermission)
+					return s
+				},
+			},
+			input: defaultOpts(nil),
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := list.New(
+				list.WithStateManager(tt.setup.stateManager(ctrl)),
+				list.WithRecordStore(tt.setup.store(ctrl)),
+				list.WithClock(func() time.Time { return date(2022, 6, 1) }),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wants.output, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package list
+
+import "time"
+
+// The output formats supported by the list command. The ids format prints
+// the qualified identifier of each record on its own line, so that the
+// records can be piped into other commands.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatIDs   = "ids"
+)
+
+// The fields the records can be sorted by.
+const (
+	SortID     = "id"
+	SortTitle  = "title"
+	SortStatus = "status"
+	SortDate   = "date"
+)
+
+// Options represents the filters and presentation of the records listed by
+// the handler. Zero values disable the related filter. The query is an
+// expression of the query language, which is applied on top of the other
+// filters.
+type Options struct {
+	Dir      string
+	Statuses []string
+	Tags     []string
+	Since    time.Time
+	Until    time.Time
+	Query    string
+	Sort     string
+	Reverse  bool
+	Format   string
+}
+
+// entry represents a record as it is presented in the output.
+type entry struct {
+	ID     string   `json:"id" yaml:"id"`
+	Title  string   `json:"title" yaml:"title"`
+	Status string   `json:"status" yaml:"status"`
+	Date   string   `json:"date,omitempty" yaml:"date,omitempty"`
+	Tags   []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Dir    string   `json:"dir" yaml:"dir"`
+	Path   string   `json:"path" yaml:"path"`
+}
@@ -0,0 +1,3 @@
+// Package pending provides handler functionality for the pending command,
+// which lists the proposals that are waiting on sign-offs.
+package pending
@@ -0,0 +1,177 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrUnknownFormat is returned when the requested output format is not
+// supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Handler describes a type that is used to handle the pending command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func newEntry(dir adr.Directory, rec adr.Record) entry {
+	tally := dir.Tally(rec)
+
+	e := entry{
+		ID:         rec.ID,
+		Title:      rec.Title,
+		Status:     rec.Status,
+		Approvals:  len(tally.Approvers),
+		Required:   tally.Required,
+		Waiting:    tally.Waiting,
+		Objections: []objection{},
+		Ready:      tally.Met,
+		Dir:        rec.Dir,
+		Path:       rec.Path,
+	}
+
+	if e.Waiting == nil {
+		e.Waiting = []string{}
+	}
+
+	for _, o := range tally.Objections {
+		e.Objections = append(e.Objections, objection{
+			By:   o.By,
+			Date: o.Date.Format(adr.DateFormat),
+			Note: o.Note,
+		})
+	}
+
+	return e
+}
+
+// Handle is the main Handler function. This function lists the records that
+// are neither accepted nor in a terminal status of their workflow, along
+// with their approvals, the deciders they are waiting on and their open
+// objections.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatTable, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	entries := []entry{}
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		workflow := dir.StatusWorkflow()
+
+		for _, rec := range records {
+			if rec.HasStatus(adr.StatusAccepted) || workflow.IsTerminal(rec.Status) {
+				continue
+			}
+
+			entries = append(entries, newEntry(dir, rec))
+		}
+	}
+
+	if opts.Format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "no proposals are pending")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tAPPROVALS\tWAITING ON\tOBJECTIONS\tDIR")
+
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.ID, e.Title, e.Status, approvals(e), orNone(e.Waiting), orNone(objectors(e)), e.Dir)
+	}
+
+	return w.Flush()
+}
+
+// approvals describes the approvals of the entry, against the quorum if the
+// directory has one.
+func approvals(e entry) string {
+	switch {
+	case e.Required == 0:
+		return fmt.Sprint(e.Approvals)
+	case e.Ready:
+		return fmt.Sprintf("%d/%d ready", e.Approvals, e.Required)
+	default:
+		return fmt.Sprintf("%d/%d", e.Approvals, e.Required)
+	}
+}
+
+// objectors returns the names of the people with open objections, without
+// their emails.
+func objectors(e entry) []string {
+	var res []string
+
+	for _, o := range e.Objections {
+		name, _, _ := strings.Cut(o.By, " <")
+		res = append(res, name)
+	}
+
+	return res
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, ", ")
+}
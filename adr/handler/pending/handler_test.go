@@ -0,0 +1,257 @@
+package pending_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/pending"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Quorum: &adr.Quorum{Approvals: 2}}
+	securityDir = adr.Directory{Path: "security", Name: "security"}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Use PostgreSQL",
+			Status:   "Accepted",
+			Deciders: []string{"@jane", "@john"},
+			Dir:      "platform",
+			Path:     "docs/adr/0001-use-postgresql.md",
+		},
+		{
+			ID:       "0002",
+			Title:    "Use Kafka",
+			Status:   "Proposed",
+			Deciders: []string{"@jane", "@john"},
+			SignOffs: []adr.SignOff{
+				{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Verdict: adr.VerdictApproved, By: "Jane Doe <jane@example.com>"},
+				{Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Verdict: adr.VerdictObjected, By: "John <john@example.com>", Note: "too costly"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0002-use-kafka.md",
+		},
+		{
+			ID:       "0003",
+			Title:    "Use NATS",
+			Status:   "Proposed",
+			Deciders: []string{"@jane", "@john"},
+			SignOffs: []adr.SignOff{
+				{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Verdict: adr.VerdictApproved, By: "Jane Doe <jane@example.com>"},
+				{Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Verdict: adr.VerdictApproved, By: "John <john@example.com>"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0003-use-nats.md",
+		},
+		{
+			ID:     "0004",
+			Title:  "Use MongoDB",
+			Status: "Rejected",
+			Dir:    "platform",
+			Path:   "docs/adr/0004-use-mongodb.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Rotate keys",
+			Status:   "Draft",
+			Deciders: []string{"@mallory"},
+			Dir:      "security",
+			Path:     "security/0001-rotate-keys.md",
+		},
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) pending.StateManager
+		store        func(ctrl *gomock.Controller) pending.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) pending.StateManager {
+		sm := pending.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) pending.RecordStore {
+		rs := pending.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	noState := func(ctrl *gomock.Controller) pending.StateManager {
+		return pending.NewmockStateManager(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  pending.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			opts: pending.Options{Format: pending.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "ID    TITLE        STATUS    APPROVALS  WAITING ON  OBJECTIONS  DIR\n" +
+					"0002  Use Kafka    Proposed  1/2        @john       John        platform\n" +
+					"0003  Use NATS     Proposed  2/2 ready  -           -           platform\n" +
+					"0001  Rotate keys  Draft     0          @mallory    -           security\n",
+			},
+		},
+		{
+			name: "single dir as json",
+			opts: pending.Options{Dir: "platform", Format: pending.FormatJSON},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) pending.RecordStore {
+					rs := pending.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords[:2], nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: `[
+  {
+    "id": "0002",
+    "title": "Use Kafka",
+    "status": "Proposed",
+    "approvals": 1,
+    "required": 2,
+    "waiting": [
+      "@john"
+    ],
+    "objections": [
+      {
+        "by": "John <john@example.com>",
+        "date": "2025-01-03",
+        "note": "too costly"
+      }
+    ],
+    "ready": false,
+    "dir": "platform",
+    "path": "docs/adr/0002-use-kafka.md"
+  }
+]
+`,
+			},
+		},
+		{
+			name: "nothing pending",
+			opts: pending.Options{Dir: "platform", Format: pending.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) pending.RecordStore {
+					rs := pending.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords[:1], nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: "no proposals are pending\n",
+			},
+		},
+		{
+			name: "unknown format",
+			opts: pending.Options{Format: "csv"},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: pending.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: pending.Options{Dir: "nope", Format: pending.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) pending.StateManager {
+					sm := pending.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			opts: pending.Options{Format: pending.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) pending.StateManager {
+					sm := pending.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := pending.New(
+				pending.WithStateManager(tt.setup.stateManager(ctrl)),
+				pending.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
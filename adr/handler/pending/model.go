@@ -0,0 +1,43 @@
+package pending
+
+// The output formats of the pending command.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Options represents the input of the pending command.
+type Options struct {
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Format is the output format, either table or json.
+	Format string
+}
+
+// entry represents a proposal and the sign-offs it is waiting on.
+type entry struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	// Approvals is the number of approvals that count towards the quorum.
+	Approvals int `json:"approvals"`
+	// Required is the number of approvals the quorum needs, zero if the
+	// directory has no quorum.
+	Required int `json:"required"`
+	// Waiting holds the deciders that have not approved.
+	Waiting    []string    `json:"waiting"`
+	Objections []objection `json:"objections"`
+	// Ready reports whether the quorum is met, so the record can be
+	// accepted.
+	Ready bool   `json:"ready"`
+	Dir   string `json:"dir"`
+	Path  string `json:"path"`
+}
+
+// objection represents an open objection to a proposal.
+type objection struct {
+	By   string `json:"by"`
+	Date string `json:"date"`
+	Note string `json:"note,omitempty"`
+}
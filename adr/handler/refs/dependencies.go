@@ -0,0 +1,34 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=refs -mock_names FileSystem=mockFileSystem,RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package refs
+
+import (
+	"os"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to list and read the records of
+// an adr directory, and to write a set of changes to them atomically.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+	Apply(changes ...store.Change) error
+}
+
+// FileSystem provides an interface that can interact with the file system.
+// This interface is primarily used for testing. All of these methods are
+// found in the `os` package.
+type FileSystem interface {
+	ReadDir(name string) ([]os.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+}
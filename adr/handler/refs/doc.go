@@ -0,0 +1,5 @@
+// Package refs provides handler functionality for the adr refs command,
+// which scans the source code of the project for citations of decision
+// records, such as "// see ADR-0007", and reports the code locations that
+// cite each record.
+package refs
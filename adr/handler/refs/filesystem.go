@@ -0,0 +1,13 @@
+package refs
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
@@ -0,0 +1,387 @@
+package refs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrUnknownFormat is returned when the requested output format is not
+// supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// citation matches a citation of a record within code. Only the upper case
+// form is matched, as lower case forms are common in paths and identifiers.
+var citation = regexp.MustCompile(`\bADR-(\d+)\b`)
+
+// Handler describes a type that is used to handle the refs command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// scanner walks the project for citations.
+type scanner struct {
+	stateDir string
+	ignore   ignore
+	// skip holds the paths of the adr dirs, whose records cite each other
+	// rather than being code.
+	skip      map[string]bool
+	citations []Citation
+}
+
+func (h *Handler) loadIgnore(stateDir string) (ignore, error) {
+	data, err := h.fs.ReadFile(stateDir + IgnoreFile)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("read %s: %w", IgnoreFile, err)
+	}
+
+	return parseIgnore(data), nil
+}
+
+// scan collects the citations of the files within the dir and its sub dirs.
+// Hidden files, vendored code, ignored paths, binary files and large files
+// are skipped.
+func (h *Handler) scan(sc *scanner, rel string) error {
+	entries, err := h.fs.ReadDir(sc.stateDir + rel)
+	if err != nil {
+		return fmt.Errorf("read dir %s: %w", rel, err)
+	}
+
+	for _, entry := range entries {
+		filePath := path.Join(rel, entry.Name())
+
+		if strings.HasPrefix(entry.Name(), ".") || sc.ignore.matches(filePath, entry.IsDir()) {
+			continue
+		}
+
+		if entry.IsDir() {
+			if adr.SkipDir(entry.Name()) || sc.skip[filePath] {
+				continue
+			}
+
+			if err = h.scan(sc, filePath); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", filePath, err)
+		}
+
+		if info.Size() > maxFileSize {
+			continue
+		}
+
+		data, err := h.fs.ReadFile(sc.stateDir + filePath)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filePath, err)
+		}
+
+		if bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+
+		for i, line := range strings.Split(string(data), "\n") {
+			for _, m := range citation.FindAllString(line, -1) {
+				sc.citations = append(sc.citations, Citation{Path: filePath, Line: i + 1, Text: m})
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve builds the reverse index of the records of the selected dirs, in
+// the order the records are given, and warns about citations of stale or
+// missing records. Citations match records of every dir, so only citations
+// that match no record at all are reported as missing.
+func resolve(citations []Citation, all []adr.Record, selected map[string]bool) Report {
+	report := Report{Records: []Entry{}, Warnings: []Warning{}}
+
+	entries := map[string]int{}
+
+	for _, c := range citations {
+		number := strings.TrimPrefix(c.Text, "ADR-")
+
+		var found bool
+
+		for _, rec := range all {
+			if !adr.SameID(rec.ID, number) {
+				continue
+			}
+
+			found = true
+
+			if !selected[rec.Dir] {
+				continue
+			}
+
+			i, ok := entries[rec.Path]
+			if !ok {
+				i = len(report.Records)
+				entries[rec.Path] = i
+
+				report.Records = append(report.Records, Entry{
+					Dir:    rec.Dir,
+					ID:     rec.ID,
+					Title:  rec.Title,
+					Status: adr.BaseStatus(rec.Status),
+					Path:   rec.Path,
+				})
+			}
+
+			report.Records[i].Citations = append(report.Records[i].Citations, c)
+
+			for _, status := range []string{adr.StatusSuperseded, adr.StatusRejected} {
+				if rec.HasStatus(status) {
+					report.Warnings = append(report.Warnings, Warning{
+						Path: c.Path,
+						Line: c.Line,
+						Message: fmt.Sprintf("%s cites %s:%s %s, which is %s",
+							c.Text, rec.Dir, rec.ID, rec.Title, strings.ToLower(status)),
+					})
+				}
+			}
+		}
+
+		if !found {
+			report.Warnings = append(report.Warnings, Warning{
+				Path:    c.Path,
+				Line:    c.Line,
+				Message: fmt.Sprintf("%s does not match any record", c.Text),
+			})
+		}
+	}
+
+	order := map[string]int{}
+	for i, rec := range all {
+		order[rec.Path] = i
+	}
+
+	sort.SliceStable(report.Records, func(i, j int) bool {
+		return order[report.Records[i].Path] < order[report.Records[j].Path]
+	})
+
+	return report
+}
+
+// section renders the section listing the citations of a record. The links
+// are relative to the record.
+func section(rec adr.Record, citations []Citation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n## %s\n\n", refsStart, SectionTitle)
+
+	for _, c := range citations {
+		link := adr.LinkTo("", rec, adr.Record{Path: c.Path})
+		fmt.Fprintf(&b, "- [%s:%d](%s#L%d)\n", c.Path, c.Line, link.Target, c.Line)
+	}
+
+	b.WriteString(refsEnd)
+
+	return b.String()
+}
+
+// write updates the section of every record of the selected dirs. It
+// reports the paths of the records that were changed.
+func (h *Handler) write(stateDir string, records []adr.Record, report Report) ([]string, error) {
+	cited := map[string][]Citation{}
+	for _, e := range report.Records {
+		cited[e.Path] = e.Citations
+	}
+
+	var (
+		changes []store.Change
+		paths   []string
+	)
+
+	for _, rec := range records {
+		data, err := h.store.Read(stateDir + rec.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		var region string
+		if citations := cited[rec.Path]; len(citations) > 0 {
+			region = section(rec, citations)
+		}
+
+		updated, err := adr.ReplaceRegion(data, refsStart, refsEnd, region)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rec.Path, err)
+		}
+
+		if bytes.Equal(updated, data) {
+			continue
+		}
+
+		changes = append(changes, store.Change{Path: stateDir + rec.Path, Data: updated})
+		paths = append(paths, rec.Path)
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	if err := h.store.Apply(changes...); err != nil {
+		return nil, fmt.Errorf("write records: %w", err)
+	}
+
+	return paths, nil
+}
+
+func render(out io.Writer, format string, report Report) error {
+	if format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	if len(report.Records) == 0 {
+		fmt.Fprintln(out, "no records are cited from code")
+	}
+
+	for _, e := range report.Records {
+		fmt.Fprintf(out, "%s:%s  %s (%s)\n", e.Dir, e.ID, e.Title, e.Status)
+
+		for _, c := range e.Citations {
+			fmt.Fprintf(out, "    %s:%d\n", c.Path, c.Line)
+		}
+	}
+
+	for _, w := range report.Warnings {
+		fmt.Fprintf(out, "warning: %s:%d: %s\n", w.Path, w.Line, w.Message)
+	}
+
+	return nil
+}
+
+// Handle is the main Handler function. This function scans the project for
+// citations of records, and prints the code locations citing each record of
+// the selected adr dirs, along with warnings for citations of superseded,
+// rejected or missing records.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	sc := &scanner{stateDir: stateDir, skip: map[string]bool{}}
+
+	if sc.ignore, err = h.loadIgnore(stateDir); err != nil {
+		return err
+	}
+
+	selected := map[string]bool{}
+	for _, dir := range dirs {
+		selected[dir.Name] = true
+	}
+
+	var all, records []adr.Record
+
+	for _, dir := range s.ADR.Directories {
+		sc.skip[dir.Path] = true
+
+		recs, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		all = append(all, recs...)
+
+		if selected[dir.Name] {
+			records = append(records, recs...)
+		}
+	}
+
+	if err = h.scan(sc, ""); err != nil {
+		return err
+	}
+
+	sort.SliceStable(sc.citations, func(i, j int) bool {
+		if sc.citations[i].Path != sc.citations[j].Path {
+			return sc.citations[i].Path < sc.citations[j].Path
+		}
+
+		return sc.citations[i].Line < sc.citations[j].Line
+	})
+
+	report := resolve(sc.citations, all, selected)
+
+	if err = render(out, opts.Format, report); err != nil {
+		return err
+	}
+
+	if !opts.Write {
+		return nil
+	}
+
+	paths, err := h.write(stateDir, records, report)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatText {
+		for _, p := range paths {
+			fmt.Fprintf(out, "%s: updated\n", p)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,436 @@
+package refs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/refs"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+type fileInfo struct {
+	size int64
+}
+
+func (f fileInfo) Name() string       { return "" }
+func (f fileInfo) Size() int64        { return f.size }
+func (f fileInfo) Mode() fs.FileMode  { return 0 }
+func (f fileInfo) ModTime() time.Time { return time.Time{} }
+func (f fileInfo) IsDir() bool        { return false }
+func (f fileInfo) Sys() interface{}   { return nil }
+
+type dirEntry struct {
+	name string
+	dir  bool
+	size int64
+}
+
+func (d dirEntry) Name() string               { return d.name }
+func (d dirEntry) IsDir() bool                { return d.dir }
+func (d dirEntry) Type() fs.FileMode          { return 0 }
+func (d dirEntry) Info() (fs.FileInfo, error) { return fileInfo{size: d.size}, nil }
+
+var (
+	errDiskFull = errors.New("disk full")
+
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{ID: "0001", Title: "Use Kafka", Status: "Accepted", Dir: "platform", Path: "docs/adr/0001-use-kafka.md"},
+		{
+			ID:     "0002",
+			Title:  "Use MySQL",
+			Status: "Superseded by [0001. Use Kafka](0001-use-kafka.md)",
+			Dir:    "platform",
+			Path:   "docs/adr/0002-use-mysql.md",
+		},
+		{ID: "0003", Title: "Pool connections", Status: "Rejected", Dir: "platform", Path: "docs/adr/0003-pool-connections.md"},
+	}
+
+	securityRecords = []adr.Record{
+		{ID: "0001", Title: "Add mTLS", Status: "Accepted", Dir: "security", Path: "security/0001-add-mtls.md"},
+	}
+
+	// project holds the files of the project, relative to the state dir.
+	project = map[string]string{
+		".gitignore":                 "# build output\nbuild/\n*.gen.go\n!keep.gen.go\n",
+		".git/HEAD":                  "ADR-0001",
+		"main.go":                    "package main\n\n// See ADR-0001 and ADR-0002.\nfunc main() {}\n",
+		"internal/db/db.go":          "package db\n\n// Pooling follows ADR-0003.\n// Sharding follows ADR-0042.\n",
+		"internal/db/db.gen.go":      "// ADR-0001\n",
+		"internal/db/keep.gen.go":    "// ADR-0001\n",
+		"build/out.go":               "// ADR-0001\n",
+		"vendor/lib/lib.go":          "// ADR-0001\n",
+		"bin/tool":                   "\x00ADR-0001",
+		"docs/adr/0001-use-kafka.md": "# Use Kafka\n\nSupersedes ADR-0002.\n",
+		"security/0001-add-mtls.md":  "# Add mTLS\n\n## Status\n\nAccepted\n",
+	}
+
+	mtlsSection = "# Add mTLS\n\n## Status\n\nAccepted\n\n" +
+		"<!-- docula:refs -->\n" +
+		"## Referenced from code\n" +
+		"\n" +
+		"- [internal/db/keep.gen.go:1](../internal/db/keep.gen.go#L1)\n" +
+		"- [main.go:3](../main.go#L3)\n" +
+		"<!-- /docula:refs -->\n"
+
+	indexOutput = "platform:0001  Use Kafka (Accepted)\n" +
+		"    internal/db/keep.gen.go:1\n" +
+		"    main.go:3\n" +
+		"platform:0002  Use MySQL (Superseded)\n" +
+		"    main.go:3\n" +
+		"platform:0003  Pool connections (Rejected)\n" +
+		"    internal/db/db.go:3\n" +
+		"security:0001  Add mTLS (Accepted)\n" +
+		"    internal/db/keep.gen.go:1\n" +
+		"    main.go:3\n" +
+		"warning: internal/db/db.go:3: ADR-0003 cites platform:0003 Pool connections, which is rejected\n" +
+		"warning: internal/db/db.go:4: ADR-0042 does not match any record\n" +
+		"warning: main.go:3: ADR-0002 cites platform:0002 Use MySQL, which is superseded\n"
+)
+
+// filesOf produces a file system holding the given files.
+func filesOf(files map[string]string) func(ctrl *gomock.Controller) refs.FileSystem {
+	return func(ctrl *gomock.Controller) refs.FileSystem {
+		fs := refs.NewmockFileSystem(ctrl)
+
+		fs.EXPECT().ReadDir(gomock.Any()).DoAndReturn(func(name string) ([]os.DirEntry, error) {
+			dir := strings.TrimPrefix(name, "/")
+			if dir != "" {
+				dir += "/"
+			}
+
+			seen := map[string]bool{}
+
+			var entries []os.DirEntry
+
+			for filePath, data := range files {
+				if !strings.HasPrefix(filePath, dir) {
+					continue
+				}
+
+				first, _, nested := strings.Cut(strings.TrimPrefix(filePath, dir), "/")
+
+				if !seen[first] {
+					seen[first] = true
+					entries = append(entries, dirEntry{name: first, dir: nested, size: int64(len(data))})
+				}
+			}
+
+			sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+			return entries, nil
+		}).AnyTimes()
+
+		fs.EXPECT().ReadFile(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+			if data, ok := files[strings.TrimPrefix(name, "/")]; ok {
+				return []byte(data), nil
+			}
+
+			return nil, os.ErrNotExist
+		}).AnyTimes()
+
+		return fs
+	}
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) refs.StateManager
+		store        func(ctrl *gomock.Controller) refs.RecordStore
+		fs           func(ctrl *gomock.Controller) refs.FileSystem
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) refs.StateManager {
+		sm := refs.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	testCases := []struct {
+		name  string
+		opts  refs.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			opts: refs.Options{Format: refs.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+
+					return rs
+				},
+				fs: filesOf(project),
+			},
+			wants: want{
+				output: indexOutput,
+			},
+		},
+		{
+			name: "json",
+			opts: refs.Options{Format: refs.FormatJSON, Dir: "security"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+
+					return rs
+				},
+				fs: filesOf(map[string]string{"main.go": "// ADR-1, ADR-7\n"}),
+			},
+			wants: want{
+				output: `{
+  "records": [
+    {
+      "dir": "security",
+      "id": "0001",
+      "title": "Add mTLS",
+      "status": "Accepted",
+      "path": "security/0001-add-mtls.md",
+      "citations": [
+        {
+          "path": "main.go",
+          "line": 1,
+          "text": "ADR-1"
+        }
+      ]
+    }
+  ],
+  "warnings": [
+    {
+      "path": "main.go",
+      "line": 1,
+      "message": "ADR-7 does not match any record"
+    }
+  ]
+}
+`,
+			},
+		},
+		{
+			name: "write section",
+			opts: refs.Options{Format: refs.FormatText, Dir: "security", Write: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					rs.EXPECT().Read("/security/0001-add-mtls.md").Return([]byte(project["security/0001-add-mtls.md"]), nil)
+					rs.EXPECT().Apply(store.Change{Path: "/security/0001-add-mtls.md", Data: []byte(mtlsSection)})
+
+					return rs
+				},
+				fs: filesOf(project),
+			},
+			wants: want{
+				output: "security:0001  Add mTLS (Accepted)\n" +
+					"    internal/db/keep.gen.go:1\n" +
+					"    main.go:3\n" +
+					"warning: internal/db/db.go:4: ADR-0042 does not match any record\n" +
+					"security/0001-add-mtls.md: updated\n",
+			},
+		},
+		{
+			name: "section is up to date",
+			opts: refs.Options{Format: refs.FormatText, Dir: "security", Write: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					rs.EXPECT().Read("/security/0001-add-mtls.md").Return([]byte(mtlsSection), nil)
+
+					return rs
+				},
+				fs: filesOf(project),
+			},
+			wants: want{
+				output: "security:0001  Add mTLS (Accepted)\n" +
+					"    internal/db/keep.gen.go:1\n" +
+					"    main.go:3\n" +
+					"warning: internal/db/db.go:4: ADR-0042 does not match any record\n",
+			},
+		},
+		{
+			name: "remove stale section",
+			opts: refs.Options{Format: refs.FormatText, Dir: "security", Write: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					rs.EXPECT().Read("/security/0001-add-mtls.md").Return([]byte(mtlsSection), nil)
+					rs.EXPECT().Apply(store.Change{
+						Path: "/security/0001-add-mtls.md",
+						Data: []byte(project["security/0001-add-mtls.md"]),
+					})
+
+					return rs
+				},
+				fs: filesOf(map[string]string{"main.go": "package main\n"}),
+			},
+			wants: want{
+				output: "no records are cited from code\n" +
+					"security/0001-add-mtls.md: updated\n",
+			},
+		},
+		{
+			name: "unbalanced markers",
+			opts: refs.Options{Format: refs.FormatText, Dir: "security", Write: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					rs.EXPECT().Read("/security/0001-add-mtls.md").Return([]byte("# Add mTLS\n\n<!-- docula:refs -->\n"), nil)
+
+					return rs
+				},
+				fs: filesOf(map[string]string{"main.go": "package main\n"}),
+			},
+			wants: want{
+				err:    adr.ErrUnbalancedMarkers,
+				output: "no records are cited from code\n",
+			},
+		},
+		{
+			name: "fail to write",
+			opts: refs.Options{Format: refs.FormatText, Dir: "security", Write: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					rs.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					rs.EXPECT().Read("/security/0001-add-mtls.md").Return([]byte(project["security/0001-add-mtls.md"]), nil)
+					rs.EXPECT().Apply(gomock.Any()).Return(errDiskFull)
+
+					return rs
+				},
+				fs: filesOf(map[string]string{"main.go": "// ADR-0001\n"}),
+			},
+			wants: want{
+				err: errDiskFull,
+				output: "security:0001  Add mTLS (Accepted)\n" +
+					"    main.go:1\n",
+			},
+		},
+		{
+			name: "unknown format",
+			opts: refs.Options{Format: "xml"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) refs.StateManager {
+					return refs.NewmockStateManager(ctrl)
+				},
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					return refs.NewmockRecordStore(ctrl)
+				},
+				fs: filesOf(nil),
+			},
+			wants: want{
+				err: refs.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: refs.Options{Format: refs.FormatText, Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) refs.StateManager {
+					sm := refs.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					return refs.NewmockRecordStore(ctrl)
+				},
+				fs: filesOf(nil),
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to list records",
+			opts: refs.Options{Format: refs.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) refs.RecordStore {
+					rs := refs.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(nil, os.ErrPermission)
+
+					return rs
+				},
+				fs: filesOf(nil),
+			},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := refs.New(
+				refs.WithStateManager(tt.setup.stateManager(ctrl)),
+				refs.WithRecordStore(tt.setup.store(ctrl)),
+				refs.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package refs
+
+import (
+	"path"
+	"strings"
+)
+
+// pattern represents a single line of an ignore file.
+type pattern struct {
+	glob    string
+	negate  bool
+	dirOnly bool
+	// anchored patterns match against the whole path, rather than any of
+	// its elements.
+	anchored bool
+}
+
+// ignore holds the patterns of an ignore file, which follow the syntax of
+// a .gitignore file, apart from "**" only being supported as a leading
+// "**/".
+type ignore []pattern
+
+func parseIgnore(data []byte) ignore {
+	var patterns ignore
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p pattern
+
+		if strings.HasPrefix(line, "!") {
+			p.negate, line = true, line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly, line = true, strings.TrimRight(line, "/")
+		}
+
+		line = strings.TrimPrefix(line, "**/")
+
+		p.anchored = strings.Contains(line, "/")
+		p.glob = strings.TrimPrefix(line, "/")
+
+		patterns = append(patterns, p)
+	}
+
+	return patterns
+}
+
+// matches reports whether the path, relative to the state dir, is ignored.
+// The last pattern that matches decides.
+func (i ignore) matches(filePath string, dir bool) bool {
+	var ignored bool
+
+	for _, p := range i {
+		if p.dirOnly && !dir {
+			continue
+		}
+
+		name := path.Base(filePath)
+		if p.anchored {
+			name = filePath
+		}
+
+		if ok, _ := path.Match(p.glob, name); ok {
+			ignored = !p.negate
+		}
+	}
+
+	return ignored
+}
@@ -0,0 +1,67 @@
+package refs
+
+// The output formats of the refs command.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// SectionTitle is the heading of the section that lists the code citing a
+// record, when it is written into the record.
+const SectionTitle = "Referenced from code"
+
+// The markers that surround the generated section.
+const (
+	refsStart = "<!-- docula:refs -->"
+	refsEnd   = "<!-- /docula:refs -->"
+)
+
+// IgnoreFile is the file, relative to the state dir, whose patterns exclude
+// paths from the scan.
+const IgnoreFile = ".gitignore"
+
+// maxFileSize is the size above which files are not scanned.
+const maxFileSize = 1 << 20
+
+// Options represents the input of the refs command.
+type Options struct {
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Format is the output format, either text or json.
+	Format string
+	// Write adds a section listing the citing code to each cited record,
+	// and removes it from records that are no longer cited.
+	Write bool
+}
+
+// Citation represents a code location that cites a record.
+type Citation struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	// Text is the citation as it is written, such as ADR-0007.
+	Text string `json:"text"`
+}
+
+// Entry represents a cited record within the reverse index.
+type Entry struct {
+	Dir       string     `json:"dir"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	Path      string     `json:"path"`
+	Citations []Citation `json:"citations"`
+}
+
+// Warning represents a citation of a record that is stale or missing.
+type Warning struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Report represents the output of the refs command.
+type Report struct {
+	Records  []Entry   `json:"records"`
+	Warnings []Warning `json:"warnings"`
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=score -mock_names RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package score
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to find, read and write the
+// records of the adr directories.
+type RecordStore interface {
+	Find(stateDir string, dirs []adr.Directory, id string) (adr.Record, error)
+	Read(path string) ([]byte, error)
+	Write(path string, data []byte) error
+}
@@ -0,0 +1,3 @@
+// Package score provides handler functionality for the score command, which
+// computes the weighted decision matrix of a record.
+package score
@@ -0,0 +1,144 @@
+package score
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrOutdated is returned when checking a matrix table that does not match
+// the weighted totals of its matrix.
+var ErrOutdated = errors.New("matrix table is out of date")
+
+// Handler describes a type that is used to handle the score command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle is the main Handler function. This function validates the decision
+// matrix of the record and ranks its options by their weighted totals. The
+// matrix is then rendered into the record as a normalized table, unless the
+// table is only checked.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	rec, err := h.store.Find(stateDir, dirs, opts.ID)
+	if err != nil {
+		return fmt.Errorf("find record: %w", err)
+	}
+
+	path := stateDir + rec.Path
+
+	data, err := h.store.Read(path)
+	if err != nil {
+		return err
+	}
+
+	m, err := adr.ParseMatrix(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rec.Path, err)
+	}
+
+	if err = m.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", rec.Path, err)
+	}
+
+	if err = rank(out, m); err != nil {
+		return err
+	}
+
+	rendered, err := adr.RenderMatrix(data, m)
+	if err != nil {
+		return fmt.Errorf("render matrix of %s: %w", rec.Path, err)
+	}
+
+	switch {
+	case bytes.Equal(rendered, data):
+		fmt.Fprintf(out, "%s: matrix table is up to date\n", rec.Path)
+	case opts.Check:
+		return fmt.Errorf("%w: %s", ErrOutdated, rec.Path)
+	default:
+		if err = h.store.Write(path, rendered); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+
+		fmt.Fprintf(out, "%s: matrix table updated\n", rec.Path)
+	}
+
+	return nil
+}
+
+// rank writes the options of the matrix, highest total first. Options with
+// the same total share their rank.
+func rank(out io.Writer, m adr.Matrix) error {
+	totals := m.Totals()
+
+	order := make([]int, len(totals))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return totals[order[i]] > totals[order[j]] })
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "RANK\tOPTION\tTOTAL\tOF MAX")
+
+	maxTotal := m.MaxTotal()
+	place := 0
+
+	for i, o := range order {
+		if i == 0 || totals[o] != totals[order[i-1]] {
+			place = i + 1
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.0f%%\n", place, m.Options[o].Name, format(totals[o]), totals[o]/maxTotal*100)
+	}
+
+	return w.Flush()
+}
+
+// format formats a total, rounded to two decimals.
+func format(v float64) string {
+	return fmt.Sprint(math.Round(v*100) / 100)
+}
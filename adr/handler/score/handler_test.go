@@ -0,0 +1,281 @@
+package score_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/score"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform"}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir},
+		},
+	}
+
+	proposed = adr.Record{
+		ID:     "0012",
+		Title:  "Use PostgreSQL",
+		Status: "Proposed",
+		Dir:    "platform",
+		Path:   "docs/adr/0012-use-postgresql.md",
+	}
+)
+
+const matrix = "```yaml docula:matrix\n" +
+	"criteria:\n" +
+	"  - {name: Cost, weight: 2}\n" +
+	"  - {name: Operations}\n" +
+	"options:\n" +
+	"  - {name: MySQL, scores: {Cost: 4, Operations: 3}}\n" +
+	"  - {name: PostgreSQL, scores: {Cost: 4, Operations: 5}}\n" +
+	"  - {name: SQLite, scores: {Cost: 5, Operations: 1}}\n" +
+	"```\n"
+
+const table = "<!-- docula:matrix scale=1-5 -->\n" +
+	"| Criterion  | Weight | MySQL | PostgreSQL | SQLite |\n" +
+	"| ---------- | -----: | ----: | ---------: | -----: |\n" +
+	"| Cost       |      2 |     4 |          4 |      5 |\n" +
+	"| Operations |      1 |     3 |          5 |      1 |\n" +
+	"| **Total**  |        |    11 |     **13** |     11 |\n" +
+	"<!-- /docula:matrix -->\n"
+
+const ranking = "RANK  OPTION      TOTAL  OF MAX\n" +
+	"1     PostgreSQL  13     87%\n" +
+	"2     MySQL       11     73%\n" +
+	"2     SQLite      11     73%\n"
+
+const unscoredRecord = "# Use PostgreSQL\n\n## Options\n\n" + matrix
+
+const scoredRecord = unscoredRecord + "\n" + table
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) score.StateManager
+		store        func(ctrl *gomock.Controller) score.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) score.StateManager {
+		sm := score.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	testCases := []struct {
+		name  string
+		opts  score.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "rendering the table",
+			opts: score.Options{ID: "12"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(unscoredRecord), nil)
+					rs.EXPECT().Write("/docs/adr/0012-use-postgresql.md", []byte(scoredRecord)).Return(nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: ranking + "docs/adr/0012-use-postgresql.md: matrix table updated\n",
+			},
+		},
+		{
+			name: "table up to date",
+			opts: score.Options{ID: "12", Dir: "platform", Check: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(scoredRecord), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: ranking + "docs/adr/0012-use-postgresql.md: matrix table is up to date\n",
+			},
+		},
+		{
+			name: "checking an outdated table",
+			opts: score.Options{ID: "12", Check: true},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					outdated := strings.Replace(scoredRecord, "**13**", "**14**", 1)
+
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(outdated), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				err:    score.ErrOutdated,
+				output: ranking,
+			},
+		},
+		{
+			name: "score outside the scale",
+			opts: score.Options{ID: "12"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					invalid := strings.Replace(unscoredRecord, "Operations: 1", "Operations: 0", 1)
+
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(invalid), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: adr.ErrInvalidMatrix,
+			},
+		},
+		{
+			name: "no criterion weighted",
+			opts: score.Options{ID: "12"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					unweighted := strings.NewReplacer(
+						"{name: Cost, weight: 2}", "{name: Cost, weight: 0}",
+						"{name: Operations}", "{name: Operations, weight: 0}",
+					).Replace(unscoredRecord)
+
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(unweighted), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: adr.ErrInvalidMatrix,
+			},
+		},
+		{
+			name: "record without a matrix",
+			opts: score.Options{ID: "12"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					rs.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte("# Use PostgreSQL\n"), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: adr.ErrNoMatrix,
+			},
+		},
+		{
+			name: "unknown record",
+			opts: score.Options{ID: "99"},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					rs := score.NewmockRecordStore(ctrl)
+					rs.EXPECT().Find("/", defaultState.ADR.Directories, "99").Return(adr.Record{}, store.ErrRecordNotFound)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: store.ErrRecordNotFound,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: score.Options{ID: "12", Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) score.StateManager {
+					sm := score.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					return score.NewmockRecordStore(ctrl)
+				},
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			opts: score.Options{ID: "12"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) score.StateManager {
+					sm := score.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: func(ctrl *gomock.Controller) score.RecordStore {
+					return score.NewmockRecordStore(ctrl)
+				},
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := score.New(
+				score.WithStateManager(tt.setup.stateManager(ctrl)),
+				score.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
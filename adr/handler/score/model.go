@@ -0,0 +1,13 @@
+package score
+
+// Options represents the input of the score command.
+type Options struct {
+	// ID is the identifier of the record holding the matrix.
+	ID string
+	// Dir optionally restricts the lookup of the record to a single adr
+	// dir, selected by name or path.
+	Dir string
+	// Check reports a matrix table that is out of date as an error, rather
+	// than rendering it into the record.
+	Check bool
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=similar -mock_names RecordStore=mockRecordStore,Renderer=mockRenderer,StateManager=mockStateManager
+
+package similar
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to find, list and read the
+// records of the adr directories.
+type RecordStore interface {
+	Find(stateDir string, dirs []adr.Directory, id string) (adr.Record, error)
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+}
+
+// Renderer represents a type that is able to render a record from a template.
+type Renderer interface {
+	Render(stateDir string, name string, data template.Data) ([]byte, error)
+}
@@ -0,0 +1,3 @@
+// Package similar provides handler functionality for the similar command,
+// which finds the decision records that resemble a text or another record.
+package similar
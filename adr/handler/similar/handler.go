@@ -0,0 +1,208 @@
+package similar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/similarity"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrUnknownFormat is returned when the requested output format is not
+	// supported.
+	ErrUnknownFormat = errors.New("unknown output format")
+
+	// ErrEmptyQuery is returned when there is no text to compare the records
+	// with.
+	ErrEmptyQuery = errors.New("empty query")
+)
+
+// idPattern matches the queries that may identify a record, such as 12 or
+// security:12.
+var idPattern = regexp.MustCompile(`^([^:\s]+:)?[0-9]+$`)
+
+// Handler describes a type that is used to handle the similar command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	renderer     Renderer
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		renderer:     template.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// boilerplate returns the lines of the templates the records of the adr dirs
+// can have been written from. Templates that cannot be rendered are left
+// out.
+func (h *Handler) boilerplate(stateDir string, dirs []adr.Directory) similarity.Boilerplate {
+	var rendered [][]byte
+
+	for _, name := range template.Used(dirs) {
+		if data, err := h.renderer.Render(stateDir, name, template.Data{}); err == nil {
+			rendered = append(rendered, data)
+		}
+	}
+
+	return similarity.NewBoilerplate(rendered...)
+}
+
+// query returns the title and text to compare the records with. A query
+// that identifies a record of any adr directory is replaced by the title
+// and contents of that record, and any other query is used as a title.
+func (h *Handler) query(stateDir string, s state.State, q string, b similarity.Boilerplate) (string, string, string, error) {
+	if !idPattern.MatchString(q) {
+		return q, "", "", nil
+	}
+
+	rec, err := h.store.Find(stateDir, s.ADR.Directories, q)
+	if errors.Is(err, store.ErrRecordNotFound) || errors.Is(err, adr.ErrDirNotFound) {
+		return q, "", "", nil
+	}
+
+	if err != nil {
+		return "", "", "", fmt.Errorf("find record: %w", err)
+	}
+
+	data, err := h.store.Read(stateDir + rec.Path)
+	if err != nil {
+		return "", "", "", fmt.Errorf("read record: %w", err)
+	}
+
+	return rec.Title, b.Strip(string(data)), rec.Path, nil
+}
+
+// Handle is the main Handler function. This function ranks the records of
+// the adr directories by how similar their titles and contents are to the
+// query, using TF-IDF vectors of the words they hold. The text the records
+// share with their templates is left out of the comparison.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatTable, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	q := strings.TrimSpace(opts.Query)
+	if q == "" {
+		return ErrEmptyQuery
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	b := h.boilerplate(stateDir, s.ADR.Directories)
+
+	title, text, self, err := h.query(stateDir, s, q, b)
+	if err != nil {
+		return err
+	}
+
+	records := map[string]adr.Record{}
+
+	var docs []similarity.Document
+
+	for _, dir := range dirs {
+		list, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range list {
+			data, err := h.store.Read(stateDir + rec.Path)
+			if err != nil {
+				return fmt.Errorf("read record: %w", err)
+			}
+
+			records[rec.Path] = rec
+			docs = append(docs, similarity.Document{ID: rec.Path, Title: rec.Title, Text: b.Strip(string(data))})
+		}
+	}
+
+	matches := []Match{}
+
+	for _, m := range similarity.NewIndex(docs).Search(title, text) {
+		score := int(math.Round(m.Score * 100))
+
+		if m.ID == self || score == 0 || score < opts.Min {
+			continue
+		}
+
+		if opts.Limit > 0 && len(matches) == opts.Limit {
+			break
+		}
+
+		rec := records[m.ID]
+		matches = append(matches, Match{
+			Dir:    rec.Dir,
+			ID:     rec.ID,
+			Title:  rec.Title,
+			Status: rec.Status,
+			Path:   rec.Path,
+			Score:  score,
+		})
+	}
+
+	if opts.Format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(matches)
+	}
+
+	return render(out, matches)
+}
+
+func render(out io.Writer, matches []Match) error {
+	if len(matches) == 0 {
+		_, err := fmt.Fprintln(out, "no similar decisions")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SCORE\tID\tTITLE\tSTATUS\tDIR")
+
+	for _, m := range matches {
+		fmt.Fprintf(w, "%d%%\t%s\t%s\t%s\t%s\n", m.Score, m.ID, m.Title, m.Status, m.Dir)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,351 @@
+package similar_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/similar"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform"}
+	securityDir = adr.Directory{Path: "security", Name: "security"}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	postgres = adr.Record{
+		ID:     "0001",
+		Title:  "Use PostgreSQL",
+		Status: "Accepted",
+		Dir:    "platform",
+		Path:   "docs/adr/0001-use-postgresql.md",
+	}
+
+	platformRecords = []adr.Record{
+		postgres,
+		{
+			ID:     "0002",
+			Title:  "Cache sessions in Redis",
+			Status: "Accepted",
+			Dir:    "platform",
+			Path:   "docs/adr/0002-cache-sessions-in-redis.md",
+		},
+		{
+			ID:     "0003",
+			Title:  "Publish events with Kafka",
+			Status: "Proposed",
+			Dir:    "platform",
+			Path:   "docs/adr/0003-publish-events-with-kafka.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Encrypt the database at rest",
+			Status: "Accepted",
+			Dir:    "security",
+			Path:   "security/0001-encrypt-the-database-at-rest.md",
+		},
+	}
+
+	contents = map[string]string{
+		"/docs/adr/0001-use-postgresql.md": "# Use PostgreSQL\n\n## Context\n\n" +
+			"Billing needs a relational database with transactions. Sessions are kept in Redis.\n",
+		"/docs/adr/0002-cache-sessions-in-redis.md": "# Cache sessions in Redis\n\n## Context\n\n" +
+			"Sessions are read on every request, which puts load on the database.\n",
+		"/docs/adr/0003-publish-events-with-kafka.md": "# Publish events with Kafka\n\n## Context\n\n" +
+			"Services publish domain events to topics.\n",
+		"/security/0001-encrypt-the-database-at-rest.md": "# Encrypt the database at rest\n\n## Context\n\n" +
+			"Backups of the database hold personal data.\n",
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) similar.StateManager
+		store        func(ctrl *gomock.Controller) similar.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) similar.StateManager {
+		sm := similar.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	type found struct {
+		id  string
+		rec adr.Record
+		err error
+	}
+
+	recordsAnd := func(f *found) func(ctrl *gomock.Controller) similar.RecordStore {
+		return func(ctrl *gomock.Controller) similar.RecordStore {
+			rs := similar.NewmockRecordStore(ctrl)
+			rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+			rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+			rs.EXPECT().Read(gomock.Any()).DoAndReturn(func(path string) ([]byte, error) {
+				return []byte(contents[path]), nil
+			}).AnyTimes()
+
+			if f != nil {
+				rs.EXPECT().Find("/", defaultState.ADR.Directories, f.id).Return(f.rec, f.err)
+			}
+
+			return rs
+		}
+	}
+
+	records := recordsAnd(nil)
+
+	noState := func(ctrl *gomock.Controller) similar.StateManager {
+		return similar.NewmockStateManager(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  similar.Options
+		setup setup
+		wants want
+	}{
+		{
+			name:  "text",
+			opts:  similar.Options{Query: "Cache user sessions", Format: similar.FormatTable},
+			setup: setup{stateManager: loaded, store: records},
+			wants: want{
+				output: "SCORE  ID    TITLE                    STATUS    DIR\n" +
+					"51%    0002  Cache sessions in Redis  Accepted  platform\n" +
+					"5%     0001  Use PostgreSQL           Accepted  platform\n",
+			},
+		},
+		{
+			name:  "text shared with several records",
+			opts:  similar.Options{Query: "Encrypt sessions", Format: similar.FormatTable},
+			setup: setup{stateManager: loaded, store: records},
+			wants: want{
+				output: "SCORE  ID    TITLE                         STATUS    DIR\n" +
+					"54%    0001  Encrypt the database at rest  Accepted  security\n" +
+					"16%    0002  Cache sessions in Redis       Accepted  platform\n" +
+					"7%     0001  Use PostgreSQL                Accepted  platform\n",
+			},
+		},
+		{
+			name:  "limited to the best matches above the minimum",
+			opts:  similar.Options{Query: "Encrypt sessions", Limit: 2, Min: 10, Format: similar.FormatTable},
+			setup: setup{stateManager: loaded, store: records},
+			wants: want{
+				output: "SCORE  ID    TITLE                         STATUS    DIR\n" +
+					"54%    0001  Encrypt the database at rest  Accepted  security\n" +
+					"16%    0002  Cache sessions in Redis       Accepted  platform\n",
+			},
+		},
+		{
+			name: "record",
+			opts: similar.Options{Query: "platform:1", Format: similar.FormatJSON},
+			setup: setup{
+				stateManager: loaded,
+				store:        recordsAnd(&found{id: "platform:1", rec: postgres}),
+			},
+			wants: want{
+				output: `[
+  {
+    "dir": "platform",
+    "id": "0002",
+    "title": "Cache sessions in Redis",
+    "status": "Accepted",
+    "path": "docs/adr/0002-cache-sessions-in-redis.md",
+    "score": 10
+  },
+  {
+    "dir": "security",
+    "id": "0001",
+    "title": "Encrypt the database at rest",
+    "status": "Accepted",
+    "path": "security/0001-encrypt-the-database-at-rest.md",
+    "score": 1
+  }
+]
+`,
+			},
+		},
+		{
+			name: "number that is not a record",
+			opts: similar.Options{Query: "42", Format: similar.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store:        recordsAnd(&found{id: "42", err: store.ErrRecordNotFound}),
+			},
+			wants: want{output: "no similar decisions\n"},
+		},
+		{
+			name: "ambiguous record",
+			opts: similar.Options{Query: "1", Format: similar.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store:        recordsAnd(&found{id: "1", err: store.ErrAmbiguousID}),
+			},
+			wants: want{err: store.ErrAmbiguousID},
+		},
+		{
+			name:  "single dir",
+			opts:  similar.Options{Query: "Encrypt sessions", Dir: "security", Format: similar.FormatTable},
+			setup: setup{stateManager: loaded, store: records},
+			wants: want{
+				output: "SCORE  ID    TITLE                         STATUS    DIR\n" +
+					"36%    0001  Encrypt the database at rest  Accepted  security\n",
+			},
+		},
+		{
+			name:  "nothing in common",
+			opts:  similar.Options{Query: "Rotate keys", Format: similar.FormatJSON},
+			setup: setup{stateManager: loaded, store: records},
+			wants: want{output: "[]\n"},
+		},
+		{
+			name:  "empty query",
+			opts:  similar.Options{Query: " ", Format: similar.FormatTable},
+			setup: setup{stateManager: noState, store: records},
+			wants: want{err: similar.ErrEmptyQuery},
+		},
+		{
+			name:  "unknown format",
+			opts:  similar.Options{Query: "Cache", Format: "csv"},
+			setup: setup{stateManager: noState, store: records},
+			wants: want{err: similar.ErrUnknownFormat},
+		},
+		{
+			name: "unknown dir",
+			opts: similar.Options{Query: "Cache", Dir: "nope", Format: similar.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) similar.StateManager {
+					sm := similar.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{err: adr.ErrDirNotFound},
+		},
+		{
+			name: "fail to load state",
+			opts: similar.Options{Query: "Cache", Format: similar.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) similar.StateManager {
+					sm := similar.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{err: os.ErrNotExist},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := similar.New(
+				similar.WithStateManager(tt.setup.stateManager(ctrl)),
+				similar.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+
+}
+
+func TestHandlerTemplateOnlyRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := adr.Directory{Path: "docs/adr", Name: "platform", Template: template.MADR}
+	s := state.State{ADR: adr.State{Directories: []adr.Directory{dir}}}
+
+	titles := []string{"Use PostgreSQL", "Deploy on Kubernetes", "Build the UI with React", "Log as JSON", "Adopt OpenTelemetry"}
+	contents := map[string][]byte{}
+
+	var records []adr.Record
+
+	for i, title := range titles {
+		name := template.MADR
+		if i == len(titles)-1 {
+			name = template.Nygard
+		}
+
+		rec := adr.Record{ID: fmt.Sprintf("%04d", i+1), Title: title, Status: "Proposed", Dir: "platform"}
+		rec.Path = fmt.Sprintf("docs/adr/%s.md", rec.ID)
+
+		data, err := template.New().Render("/", name, template.Data{
+			Dir:    dir,
+			ID:     rec.ID,
+			Title:  title,
+			Status: rec.Status,
+			Date:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+
+		records = append(records, rec)
+		contents["/"+rec.Path] = data
+	}
+
+	sm := similar.NewmockStateManager(ctrl)
+	sm.EXPECT().Load().Return(s, nil)
+	sm.EXPECT().StateDir().Return("/", nil)
+
+	rs := similar.NewmockRecordStore(ctrl)
+	rs.EXPECT().Find("/", s.ADR.Directories, "0001").Return(records[0], nil)
+	rs.EXPECT().List("/", dir).Return(records, nil)
+	rs.EXPECT().Read(gomock.Any()).DoAndReturn(func(path string) ([]byte, error) {
+		return contents[path], nil
+	}).AnyTimes()
+
+	h := similar.New(
+		similar.WithStateManager(sm),
+		similar.WithRecordStore(rs),
+	)
+
+	out := &bytes.Buffer{}
+
+	err := h.Handle(context.Background(), out, similar.Options{Query: "0001", Min: 10, Format: similar.FormatTable})
+	assert.NoError(t, err)
+	assert.Equal(t, "no similar decisions\n", out.String())
+}
@@ -0,0 +1,35 @@
+package similar
+
+// The output formats of the similar command.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Options represents the input of the similar command.
+type Options struct {
+	// Query is the text to compare the records with, or the identifier of
+	// the record to compare them with, such as 12 or security:12.
+	Query string
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Limit is the maximum number of records listed. All of the similar
+	// records are listed when it is zero.
+	Limit int
+	// Min is the lowest similarity listed, as a percentage.
+	Min int
+	// Format is the output format, either table or json.
+	Format string
+}
+
+// Match represents a record that resembles the query.
+type Match struct {
+	Dir    string `json:"dir"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Path   string `json:"path"`
+	// Score is the similarity of the record and the query, as a percentage.
+	Score int `json:"score"`
+}
@@ -0,0 +1,3 @@
+// Package status provides handler functionality for the commands that change
+// the status of a decision record, such as accept and reject.
+package status
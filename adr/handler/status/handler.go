@@ -0,0 +1,108 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/git"
+	"github.com/docula-io/docula/state"
+)
+
+// Handler describes a type that is used to handle the status commands.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	identity     Identity
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		identity:     git.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle is the main Handler function. This function is used to move a
+// record to a new status, recording the change in the status history of the
+// record. The record is left untouched if the change cannot be made.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	rec, err := h.store.Find(stateDir, dirs, opts.ID)
+	if err != nil {
+		return fmt.Errorf("find record: %w", err)
+	}
+
+	dir, _ := s.ADR.FindDirectory(rec.Dir)
+	workflow := dir.StatusWorkflow()
+
+	to, ok := workflow.Status(opts.Status)
+	if !ok {
+		return fmt.Errorf("%w: %s is not a status of %s", adr.ErrUnknownStatus, opts.Status, rec.Dir)
+	}
+
+	if !workflow.CanTransition(rec.Status, to) {
+		return fmt.Errorf("%w: %s to %s", adr.ErrIllegalTransition, rec.Status, to)
+	}
+
+	if dir.Quorum != nil && strings.EqualFold(to, adr.StatusAccepted) {
+		if tally := dir.Tally(rec); !tally.Met {
+			return fmt.Errorf("%w for %s: %s", adr.ErrQuorumNotMet, rec.Path, tally.Shortfall())
+		}
+	}
+
+	path := stateDir + rec.Path
+
+	data, err := h.store.Read(path)
+	if err != nil {
+		return err
+	}
+
+	data, err = adr.SetStatus(data, rec, adr.StatusChange{
+		Date:   h.now(),
+		Status: to,
+		By:     git.Author(ctx, h.identity, opts.By),
+	})
+	if err != nil {
+		return fmt.Errorf("set status of %s: %w", rec.Path, err)
+	}
+
+	if err = h.store.Write(path, data); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+
+	fmt.Fprintf(out, "%s: %s -> %s\n", rec.Path, rec.Status, to)
+
+	return nil
+}
@@ -0,0 +1,376 @@
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/status"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/git"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform"}
+	securityDir = adr.Directory{
+		Path: "security",
+		Name: "security",
+		Workflow: &adr.Workflow{
+			Statuses: []string{"proposed", "under-review", "accepted", "rejected"},
+			Transitions: map[string][]string{
+				"proposed":     {"under-review", "rejected"},
+				"under-review": {"accepted", "rejected"},
+			},
+			Terminal: []string{"rejected"},
+		},
+	}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	proposed = adr.Record{
+		ID:     "0012",
+		Title:  "Use PostgreSQL",
+		Status: "Proposed",
+		Date:   time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+		Dir:    "platform",
+		Path:   "docs/adr/0012-use-postgresql.md",
+	}
+)
+
+const proposedRecord = `# Use PostgreSQL
+
+Date: 2022-07-01
+
+## Status
+
+Proposed
+
+## Context
+`
+
+const acceptedRecord = `# Use PostgreSQL
+
+Date: 2022-07-01
+
+## Status
+
+Accepted
+
+<!-- docula:status-history -->
+- 2022-07-01: Proposed
+- 2022-07-14: Accepted by Jane Doe
+<!-- /docula:status-history -->
+
+## Context
+`
+
+func clock() time.Time {
+	return time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) status.StateManager
+		store        func(ctrl *gomock.Controller) status.RecordStore
+		identity     func(ctrl *gomock.Controller) status.Identity
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	defaultStateManager := func(ctrl *gomock.Controller) status.StateManager {
+		s := status.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	gitUser := func(ctrl *gomock.Controller) status.Identity {
+		i := status.NewmockIdentity(ctrl)
+		i.EXPECT().UserName(gomock.Any()).Return("Jane Doe", nil)
+		return i
+	}
+
+	noIdentity := func(ctrl *gomock.Controller) status.Identity {
+		return status.NewmockIdentity(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input status.Options
+		wants want
+	}{
+		{
+			name: "accepting a proposed record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", []byte(acceptedRecord)).Return(nil)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: Proposed -> Accepted\n",
+			},
+		},
+		{
+			name: "rejecting with an explicit author",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) status.StateManager {
+					s := status.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", []adr.Directory{platformDir}, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).DoAndReturn(
+						func(path string, data []byte) error {
+							assert.Contains(t, string(data), "- 2022-07-14: Rejected by John Smith\n")
+							return nil
+						},
+					)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Dir: "platform", Status: adr.StatusRejected, By: "John Smith"},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: Proposed -> Rejected\n",
+			},
+		},
+		{
+			name: "unattributed without a git user",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).DoAndReturn(
+						func(path string, data []byte) error {
+							assert.Contains(t, string(data), "- 2022-07-14: Accepted\n")
+							return nil
+						},
+					)
+					return s
+				},
+				identity: func(ctrl *gomock.Controller) status.Identity {
+					i := status.NewmockIdentity(ctrl)
+					i.EXPECT().UserName(gomock.Any()).Return("", git.ErrNoIdentity)
+					return i
+				},
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				output: "docs/adr/0012-use-postgresql.md: Proposed -> Accepted\n",
+			},
+		},
+		{
+			name: "custom workflow step",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					rec := proposed
+					rec.Dir = "security"
+					rec.Path = "security/0012-use-postgresql.md"
+
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					s.EXPECT().Read("/security/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/security/0012-use-postgresql.md", gomock.Any()).DoAndReturn(
+						func(path string, data []byte) error {
+							assert.Contains(t, string(data), "\n\nunder-review\n")
+							return nil
+						},
+					)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: status.Options{ID: "12", Status: "Under-Review"},
+			wants: want{
+				output: "security/0012-use-postgresql.md: Proposed -> under-review\n",
+			},
+		},
+		{
+			name: "custom workflow forbids skipping a step",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					rec := proposed
+					rec.Dir = "security"
+
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(rec, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				err: adr.ErrIllegalTransition,
+			},
+		},
+		{
+			name: "status outside of the workflow",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Status: "under-review"},
+			wants: want{
+				err: adr.ErrUnknownStatus,
+			},
+		},
+		{
+			name: "quorum not met",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) status.StateManager {
+					dir := platformDir
+					dir.Quorum = &adr.Quorum{Approvals: 2}
+
+					s := status.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{ADR: adr.State{Directories: []adr.Directory{dir}}}, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					rec := proposed
+					rec.Deciders = []string{"Jane Doe", "John Smith"}
+					rec.SignOffs = []adr.SignOff{{Date: clock(), Verdict: adr.VerdictApproved, By: "Jane Doe"}}
+
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", gomock.Any(), "12").Return(rec, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				err: adr.ErrQuorumNotMet,
+			},
+		},
+		{
+			name: "illegal transition leaves the record untouched",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusDeprecated},
+			wants: want{
+				err: adr.ErrIllegalTransition,
+			},
+		},
+		{
+			name: "unknown record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "99").Return(adr.Record{}, store.ErrRecordNotFound)
+					return s
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "99", Status: adr.StatusAccepted},
+			wants: want{
+				err: store.ErrRecordNotFound,
+			},
+		},
+		{
+			name: "failing to write the record",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					s := status.NewmockRecordStore(ctrl)
+					s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(proposed, nil)
+					s.EXPECT().Read("/docs/adr/0012-use-postgresql.md").Return([]byte(proposedRecord), nil)
+					s.EXPECT().Write("/docs/adr/0012-use-postgresql.md", gomock.Any()).Return(os.ErrPermission)
+					return s
+				},
+				identity: gitUser,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+		{
+			name: "failing to load the state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) status.StateManager {
+					s := status.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) status.RecordStore {
+					return status.NewmockRecordStore(ctrl)
+				},
+				identity: noIdentity,
+			},
+			input: status.Options{ID: "12", Status: adr.StatusAccepted},
+			wants: want{
+				err: state.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := status.New(
+				status.WithStateManager(tt.setup.stateManager(ctrl)),
+				status.WithRecordStore(tt.setup.store(ctrl)),
+				status.WithIdentity(tt.setup.identity(ctrl)),
+				status.WithClock(clock),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wants.output, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package status
+
+// Options represents the status change that is requested by the user.
+type Options struct {
+	// ID is the identifier of the record to change.
+	ID string
+	// Dir optionally restricts the lookup of the record to a single adr
+	// dir, selected by name or path.
+	Dir string
+	// Status is the status the record should move to.
+	Status string
+	// By is the person making the change. If empty, the git user is used.
+	By string
+}
@@ -0,0 +1,30 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=supersede -mock_names Identity=mockIdentity,RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package supersede
+
+import (
+	"context"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to find, read and write the
+// records of the adr directories.
+type RecordStore interface {
+	Find(stateDir string, dirs []adr.Directory, id string) (adr.Record, error)
+	Read(path string) ([]byte, error)
+	Apply(changes ...store.Change) error
+}
+
+// Identity represents a type that is able to identify the current user.
+type Identity interface {
+	UserName(ctx context.Context) (string, error)
+}
@@ -0,0 +1,2 @@
+// Package supersede provides handler functionality for the supersede command.
+package supersede
@@ -0,0 +1,118 @@
+package supersede
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/git"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrSameRecord is returned when a record is asked to supersede itself.
+var ErrSameRecord = errors.New("a record cannot supersede itself")
+
+// Handler describes a type that is used to handle the supersede command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	identity     Identity
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		identity:     git.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle is the main Handler function. This function marks the old record
+// as superseded by the new record and links the new record back to the old
+// one. Both records are written together, or neither is changed.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	oldRec, err := h.store.Find(stateDir, s.ADR.Directories, opts.Old)
+	if err != nil {
+		return fmt.Errorf("find superseded record: %w", err)
+	}
+
+	newRec, err := h.store.Find(stateDir, s.ADR.Directories, opts.New)
+	if err != nil {
+		return fmt.Errorf("find superseding record: %w", err)
+	}
+
+	if oldRec.Path == newRec.Path {
+		return fmt.Errorf("%w: %s", ErrSameRecord, oldRec.Path)
+	}
+
+	dir, _ := s.ADR.FindDirectory(oldRec.Dir)
+
+	if !dir.StatusWorkflow().CanTransition(oldRec.Status, adr.StatusSuperseded) {
+		return fmt.Errorf("%w: %s to %s", adr.ErrIllegalTransition, oldRec.Status, adr.StatusSuperseded)
+	}
+
+	oldData, err := h.store.Read(stateDir + oldRec.Path)
+	if err != nil {
+		return err
+	}
+
+	newData, err := h.store.Read(stateDir + newRec.Path)
+	if err != nil {
+		return err
+	}
+
+	supersededBy := adr.LinkTo(adr.LinkSupersededBy, oldRec, newRec)
+
+	oldData, err = adr.SetStatus(oldData, oldRec, adr.StatusChange{
+		Date:   h.now(),
+		Status: adr.StatusSuperseded,
+		By:     git.Author(ctx, h.identity, opts.By),
+		Detail: fmt.Sprintf("by [%s](%s)", supersededBy.Title, supersededBy.Target),
+	})
+	if err != nil {
+		return fmt.Errorf("set status of %s: %w", oldRec.Path, err)
+	}
+
+	newData, err = adr.AddLink(newData, adr.LinkTo(adr.LinkSupersedes, newRec, oldRec))
+	if err != nil {
+		return fmt.Errorf("link %s: %w", newRec.Path, err)
+	}
+
+	err = h.store.Apply(
+		store.Change{Path: stateDir + oldRec.Path, Data: oldData},
+		store.Change{Path: stateDir + newRec.Path, Data: newData},
+	)
+	if err != nil {
+		return fmt.Errorf("write records: %w", err)
+	}
+
+	fmt.Fprintf(out, "%s: superseded by %s\n", oldRec.Path, newRec.Path)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package supersede_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/supersede"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{
+				{Path: "docs/adr", Name: "platform"},
+				{Path: "security/decisions", Name: "security"},
+			},
+		},
+	}
+
+	oldRec = adr.Record{
+		ID:     "0004",
+		Title:  "Use MySQL",
+		Status: "Accepted",
+		Dir:    "platform",
+		Path:   "docs/adr/0004-use-mysql.md",
+	}
+
+	newRec = adr.Record{
+		ID:     "0012",
+		Title:  "Use PostgreSQL",
+		Status: "Proposed",
+		Dir:    "security",
+		Path:   "security/decisions/0012-use-postgresql.md",
+	}
+)
+
+const oldData = `# Use MySQL
+
+## Status
+
+Accepted
+
+## Context
+`
+
+const newData = `# Use PostgreSQL
+
+## Status
+
+Proposed
+
+## Context
+`
+
+const oldWants = `# Use MySQL
+
+## Status
+
+Superseded by [0012. Use PostgreSQL](../../security/decisions/0012-use-postgresql.md)
+
+<!-- docula:status-history -->
+- 2022-07-14: Superseded by Jane Doe
+<!-- /docula:status-history -->
+
+## Context
+`
+
+const newWants = `# Use PostgreSQL
+
+## Status
+
+Proposed
+
+Supersedes [0004. Use MySQL](../../docs/adr/0004-use-mysql.md)
+
+## Context
+`
+
+func clock() time.Time {
+	return time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func TestHandler(t *testing.T) {
+	defaultStateManager := func(ctrl *gomock.Controller) supersede.StateManager {
+		s := supersede.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	testCases := []struct {
+		name  string
+		store func(ctrl *gomock.Controller) supersede.RecordStore
+		input supersede.Options
+		wants error
+	}{
+		{
+			name: "happy path",
+			store: func(ctrl *gomock.Controller) supersede.RecordStore {
+				s := supersede.NewmockRecordStore(ctrl)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "4").Return(oldRec, nil)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "security:12").Return(newRec, nil)
+				s.EXPECT().Read("/docs/adr/0004-use-mysql.md").Return([]byte(oldData), nil)
+				s.EXPECT().Read("/security/decisions/0012-use-postgresql.md").Return([]byte(newData), nil)
+				s.EXPECT().Apply(
+					store.Change{Path: "/docs/adr/0004-use-mysql.md", Data: []byte(oldWants)},
+					store.Change{Path: "/security/decisions/0012-use-postgresql.md", Data: []byte(newWants)},
+				).Return(nil)
+				return s
+			},
+			input: supersede.Options{Old: "4", New: "security:12", By: "Jane Doe"},
+		},
+		{
+			name: "superseding itself",
+			store: func(ctrl *gomock.Controller) supersede.RecordStore {
+				s := supersede.NewmockRecordStore(ctrl)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "4").Return(oldRec, nil)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "0004").Return(oldRec, nil)
+				return s
+			},
+			input: supersede.Options{Old: "4", New: "0004"},
+			wants: supersede.ErrSameRecord,
+		},
+		{
+			name: "superseding a proposal",
+			store: func(ctrl *gomock.Controller) supersede.RecordStore {
+				s := supersede.NewmockRecordStore(ctrl)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(newRec, nil)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "4").Return(oldRec, nil)
+				return s
+			},
+			input: supersede.Options{Old: "12", New: "4"},
+			wants: adr.ErrIllegalTransition,
+		},
+		{
+			name: "unknown record",
+			store: func(ctrl *gomock.Controller) supersede.RecordStore {
+				s := supersede.NewmockRecordStore(ctrl)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "4").Return(oldRec, nil)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "99").Return(adr.Record{}, store.ErrRecordNotFound)
+				return s
+			},
+			input: supersede.Options{Old: "4", New: "99"},
+			wants: store.ErrRecordNotFound,
+		},
+		{
+			name: "failing to write the records",
+			store: func(ctrl *gomock.Controller) supersede.RecordStore {
+				s := supersede.NewmockRecordStore(ctrl)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "4").Return(oldRec, nil)
+				s.EXPECT().Find("/", defaultState.ADR.Directories, "12").Return(newRec, nil)
+				s.EXPECT().Read("/docs/adr/0004-use-mysql.md").Return([]byte(oldData), nil)
+				s.EXPECT().Read("/security/decisions/0012-use-postgresql.md").Return([]byte(newData), nil)
+				s.EXPECT().Apply(gomock.Any(), gomock.Any()).Return(os.ErrPermission)
+				return s
+			},
+			input: supersede.Options{Old: "4", New: "12", By: "Jane Doe"},
+			wants: os.ErrPermission,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := supersede.New(
+				supersede.WithStateManager(defaultStateManager(ctrl)),
+				supersede.WithRecordStore(tt.store(ctrl)),
+				supersede.WithIdentity(supersede.NewmockIdentity(ctrl)),
+				supersede.WithClock(clock),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t,
+					"docs/adr/0004-use-mysql.md: superseded by security/decisions/0012-use-postgresql.md\n",
+					out.String(),
+				)
+			}
+		})
+	}
+}
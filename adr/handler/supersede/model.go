@@ -0,0 +1,11 @@
+package supersede
+
+// Options represents the supersession that is requested by the user.
+type Options struct {
+	// Old is the identifier of the record that is being replaced.
+	Old string
+	// New is the identifier of the record that replaces it.
+	New string
+	// By is the person making the change. If empty, the git user is used.
+	By string
+}
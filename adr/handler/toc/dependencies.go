@@ -0,0 +1,23 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=toc -mock_names RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package toc
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to list the records of an adr
+// directory, and read and write the README of the directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+	Write(path string, data []byte) error
+}
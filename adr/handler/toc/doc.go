@@ -0,0 +1,3 @@
+// Package toc provides handler functionality for the index command, which
+// generates a table of contents into the README of each adr directory.
+package toc
@@ -0,0 +1,237 @@
+package toc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrStale is returned by a check when a README does not match the records of
+// its directory.
+var ErrStale = errors.New("index is out of date")
+
+// Handler describes a type that is used to handle the index command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// supersession collects the supersession links of every record, keyed by
+// the path of the record. Links are taken from both ends, so a record that
+// only names its successor in its status still lists it.
+func supersession(records map[string]adr.Record) map[string][]adr.Link {
+	links := map[string][]adr.Link{}
+
+	add := func(from adr.Record, linkType string, to adr.Record) {
+		for _, l := range links[from.Path] {
+			if l.Type == linkType && l.Resolve(from.Path) == to.Path {
+				return
+			}
+		}
+
+		links[from.Path] = append(links[from.Path], adr.LinkTo(linkType, from, to))
+	}
+
+	paths := make([]string, 0, len(records))
+	for p := range records {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		rec := records[p]
+
+		for _, l := range rec.Links {
+			target, ok := records[l.Resolve(rec.Path)]
+			if !ok {
+				continue
+			}
+
+			switch l.Type {
+			case adr.LinkSupersedes:
+				add(rec, adr.LinkSupersedes, target)
+				add(target, adr.LinkSupersededBy, rec)
+			case adr.LinkSupersededBy:
+				add(rec, adr.LinkSupersededBy, target)
+				add(target, adr.LinkSupersedes, rec)
+			}
+		}
+	}
+
+	return links
+}
+
+func escape(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// table renders the table of contents of the directory. Link targets are
+// relative to the README, which lives at the root of the directory.
+func table(dir adr.Directory, records []adr.Record, links map[string][]adr.Link) string {
+	readme := adr.Record{Path: path.Join(dir.Path, ReadmeName)}
+
+	var b strings.Builder
+
+	b.WriteString("| Number | Title | Status | Date | Supersession |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, rec := range records {
+		var date string
+		if !rec.Date.IsZero() {
+			date = rec.Date.Format(adr.DateFormat)
+		}
+
+		var cells []string
+
+		for _, l := range links[rec.Path] {
+			target := adr.LinkTo(l.Type, readme, adr.Record{Path: l.Resolve(rec.Path)})
+			cells = append(cells, fmt.Sprintf("%s [%s](%s)", l.Type, escape(l.Title), target.Target))
+		}
+
+		title := adr.LinkTo("", readme, rec)
+
+		fmt.Fprintf(&b, "| %s | [%s](%s) | %s | %s | %s |\n",
+			rec.ID, escape(rec.Title), title.Target, escape(adr.BaseStatus(rec.Status)), date,
+			strings.Join(cells, ", "),
+		)
+	}
+
+	return b.String()
+}
+
+// update replaces the region between the index markers of the README with
+// the table. A README without markers has the region appended to it, and a
+// missing README is created with a heading.
+func update(readme []byte, dir adr.Directory, toc string) ([]byte, error) {
+	region := fmt.Sprintf("%s\n%s%s", indexStart, toc, indexEnd)
+
+	if readme == nil {
+		return []byte(fmt.Sprintf("# %s decision records\n\n%s\n", dir.Name, region)), nil
+	}
+
+	return adr.ReplaceRegion(readme, indexStart, indexEnd, region)
+}
+
+func (h *Handler) readme(readmePath string) ([]byte, error) {
+	data, err := h.store.Read(readmePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Handle is the main Handler function. This function regenerates the table
+// of contents within the README of each selected adr dir, or reports the
+// READMEs that are stale when checking.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	byDir := map[string][]adr.Record{}
+	byPath := map[string]adr.Record{}
+
+	// Records are read from every dir, so that supersession across dirs
+	// resolves even when a single dir is selected.
+	for _, dir := range s.ADR.Directories {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		sort.SliceStable(records, func(i, j int) bool {
+			return adr.CompareIDs(records[i].ID, records[j].ID) < 0
+		})
+
+		byDir[dir.Name] = records
+
+		for _, rec := range records {
+			byPath[rec.Path] = rec
+		}
+	}
+
+	links := supersession(byPath)
+
+	var stale []string
+
+	for _, dir := range dirs {
+		readmePath := path.Join(dir.Path, ReadmeName)
+
+		current, err := h.readme(stateDir + readmePath)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", readmePath, err)
+		}
+
+		updated, err := update(current, dir, table(dir, byDir[dir.Name], links))
+		if err != nil {
+			return fmt.Errorf("update %s: %w", readmePath, err)
+		}
+
+		if current != nil && bytes.Equal(current, updated) {
+			continue
+		}
+
+		if opts.Check {
+			stale = append(stale, readmePath)
+			fmt.Fprintf(out, "%s: out of date\n", readmePath)
+
+			continue
+		}
+
+		if err = h.store.Write(stateDir+readmePath, updated); err != nil {
+			return fmt.Errorf("write %s: %w", readmePath, err)
+		}
+
+		fmt.Fprintf(out, "%s: updated\n", readmePath)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("%w: %s", ErrStale, strings.Join(stale, ", "))
+	}
+
+	return nil
+}
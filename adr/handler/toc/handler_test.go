@@ -0,0 +1,274 @@
+package toc_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/toc"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0002",
+			Title:  "Use PostgreSQL | MySQL",
+			Status: "Superseded by [0001. Add mTLS](../../security/0001-add-mtls.md)",
+			Date:   time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC),
+			Links: []adr.Link{
+				{Type: adr.LinkSupersededBy, Title: "0001. Add mTLS", Target: "../../security/0001-add-mtls.md"},
+			},
+			Dir:  "platform",
+			Path: "docs/adr/0002-use-postgresql.md",
+		},
+		{
+			ID:     "0001",
+			Title:  "Record decisions",
+			Status: "Accepted",
+			Date:   time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0001-record-decisions.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Add mTLS",
+			Status: "Proposed",
+			Links: []adr.Link{
+				{Type: adr.LinkSupersedes, Title: "0002. Use PostgreSQL", Target: "../docs/adr/0002-use-postgresql.md"},
+			},
+			Dir:  "security",
+			Path: "security/0001-add-mtls.md",
+		},
+	}
+
+	platformTable = "<!-- docula:index -->\n" +
+		"| Number | Title | Status | Date | Supersession |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| 0001 | [Record decisions](0001-record-decisions.md) | Accepted | 2022-01-10 |  |\n" +
+		"| 0002 | [Use PostgreSQL \\| MySQL](0002-use-postgresql.md) | Superseded | 2022-03-02 | " +
+		"Superseded by [0001. Add mTLS](../../security/0001-add-mtls.md) |\n" +
+		"<!-- /docula:index -->"
+
+	securityTable = "<!-- docula:index -->\n" +
+		"| Number | Title | Status | Date | Supersession |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| 0001 | [Add mTLS](0001-add-mtls.md) | Proposed |  | " +
+		"Supersedes [0002. Use PostgreSQL \\| MySQL](../docs/adr/0002-use-postgresql.md) |\n" +
+		"<!-- /docula:index -->"
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) toc.StateManager
+		store        func(ctrl *gomock.Controller) toc.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	defaultStateManager := func(ctrl *gomock.Controller) toc.StateManager {
+		s := toc.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input toc.Options
+		wants want
+	}{
+		{
+			name: "creates missing readmes",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/docs/adr/README.md").Return(nil, fmt.Errorf("read record: %w", os.ErrNotExist))
+					s.EXPECT().Write("/docs/adr/README.md", []byte("# platform decision records\n\n"+platformTable+"\n")).Return(nil)
+					s.EXPECT().Read("/security/README.md").Return(nil, fmt.Errorf("read record: %w", os.ErrNotExist))
+					s.EXPECT().Write("/security/README.md", []byte("# security decision records\n\n"+securityTable+"\n")).Return(nil)
+					return s
+				},
+			},
+			wants: want{
+				output: "docs/adr/README.md: updated\nsecurity/README.md: updated\n",
+			},
+		},
+		{
+			name: "replaces only the marked region",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/security/README.md").Return(
+						[]byte("# Security\n\nIntro.\n\n<!-- docula:index -->\nold\n<!-- /docula:index -->\n\nFooter.\n"), nil,
+					)
+					s.EXPECT().Write("/security/README.md",
+						[]byte("# Security\n\nIntro.\n\n"+securityTable+"\n\nFooter.\n"),
+					).Return(nil)
+					return s
+				},
+			},
+			input: toc.Options{Dir: "security"},
+			wants: want{
+				output: "security/README.md: updated\n",
+			},
+		},
+		{
+			name: "appends the region to a readme without markers",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/docs/adr/README.md").Return([]byte("# Decisions\n"), nil)
+					s.EXPECT().Write("/docs/adr/README.md", []byte("# Decisions\n\n"+platformTable+"\n")).Return(nil)
+					s.EXPECT().Read("/security/README.md").Return([]byte(securityTable+"\n"), nil)
+					return s
+				},
+			},
+			wants: want{
+				output: "docs/adr/README.md: updated\n",
+			},
+		},
+		{
+			name: "check passes when up to date",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/docs/adr/README.md").Return([]byte("# Decisions\n\n"+platformTable+"\n"), nil)
+					s.EXPECT().Read("/security/README.md").Return([]byte(securityTable+"\n"), nil)
+					return s
+				},
+			},
+			input: toc.Options{Check: true},
+		},
+		{
+			name: "check reports stale readmes",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/docs/adr/README.md").Return(nil, fmt.Errorf("read record: %w", os.ErrNotExist))
+					s.EXPECT().Read("/security/README.md").Return([]byte(securityTable+"\n"), nil)
+					return s
+				},
+			},
+			input: toc.Options{Check: true},
+			wants: want{
+				err:    toc.ErrStale,
+				output: "docs/adr/README.md: out of date\n",
+			},
+		},
+		{
+			name: "unbalanced markers",
+			setup: setup{
+				stateManager: defaultStateManager,
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					s := toc.NewmockRecordStore(ctrl)
+					s.EXPECT().List("/", platformDir).Return(platformRecords, nil)
+					s.EXPECT().List("/", securityDir).Return(securityRecords, nil)
+					s.EXPECT().Read("/docs/adr/README.md").Return([]byte("<!-- /docula:index -->\n"), nil)
+					return s
+				},
+			},
+			wants: want{
+				err: adr.ErrUnbalancedMarkers,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) toc.StateManager {
+					s := toc.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					return toc.NewmockRecordStore(ctrl)
+				},
+			},
+			input: toc.Options{Dir: "nope"},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "failing to load the state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) toc.StateManager {
+					s := toc.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					return s
+				},
+				store: func(ctrl *gomock.Controller) toc.RecordStore {
+					return toc.NewmockRecordStore(ctrl)
+				},
+			},
+			wants: want{
+				err: state.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := toc.New(
+				toc.WithStateManager(tt.setup.stateManager(ctrl)),
+				toc.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package toc
+
+// ReadmeName is the name of the file the table of contents is written into.
+const ReadmeName = "README.md"
+
+// The markers that surround the generated table of contents.
+const (
+	indexStart = "<!-- docula:index -->"
+	indexEnd   = "<!-- /docula:index -->"
+)
+
+// Options represents the input of the index command.
+type Options struct {
+	// Dir limits the command to a single adr directory, by name or path.
+	Dir string
+	// Check reports stale READMEs instead of writing them.
+	Check bool
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=todo -mock_names RecordStore=mockRecordStore,StateManager=mockStateManager
+
+package todo
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to list and read the records
+// of an adr directory.
+type RecordStore interface {
+	List(stateDir string, dir adr.Directory) ([]adr.Record, error)
+	Read(path string) ([]byte, error)
+}
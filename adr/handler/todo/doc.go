@@ -0,0 +1,3 @@
+// Package todo provides handler functionality for the todo command, which
+// collects the open follow-ups of the accepted decision records.
+package todo
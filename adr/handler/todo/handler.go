@@ -0,0 +1,291 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrUnknownFormat is returned when the requested output format is not
+	// supported.
+	ErrUnknownFormat = errors.New("unknown output format")
+
+	// ErrUnknownGroup is returned when the requested grouping is not
+	// supported.
+	ErrUnknownGroup = errors.New("unknown grouping")
+)
+
+// day is the length of a day, used to count the days since a date.
+const day = 24 * time.Hour
+
+// Handler describes a type that is used to handle the todo command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	now          func() time.Time
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// decisions returns the accepted records of the dirs that have open
+// follow-ups. The follow-ups of a record are overdue if it was accepted
+// before the cutoff.
+func (h *Handler) decisions(stateDir string, dirs []adr.Directory, today time.Time, cutoff time.Time) ([]Decision, error) {
+	res := []Decision{}
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return nil, fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range records {
+			if !rec.HasStatus(adr.StatusAccepted) {
+				continue
+			}
+
+			data, err := h.store.Read(stateDir + rec.Path)
+			if err != nil {
+				return nil, fmt.Errorf("read record: %w", err)
+			}
+
+			tasks := adr.OpenTasks(data)
+			if len(tasks) == 0 {
+				continue
+			}
+
+			d := Decision{Dir: rec.Dir, ID: rec.ID, Title: rec.Title, Path: rec.Path}
+
+			if accepted := rec.StatusDate(); !accepted.IsZero() {
+				d.Accepted = accepted.Format(adr.DateFormat)
+				d.Days = int(today.Sub(accepted) / day)
+				d.Overdue = !cutoff.IsZero() && accepted.Before(cutoff)
+			}
+
+			for _, task := range tasks {
+				owners := task.Owners
+				if owners == nil {
+					owners = []string{}
+				}
+
+				d.Items = append(d.Items, Item{Line: task.Line, Text: task.Text, Owners: owners})
+			}
+
+			res = append(res, d)
+		}
+	}
+
+	return res, nil
+}
+
+// owners regroups the follow-ups of the decisions by the people and teams
+// they mention, sorted by name. Follow-ups that mention nobody are
+// unassigned, which comes last.
+func owners(decisions []Decision) []Owner {
+	byOwner := map[string]*Owner{}
+
+	var names []string
+
+	for _, d := range decisions {
+		for _, item := range d.Items {
+			mentioned := item.Owners
+			if len(mentioned) == 0 {
+				mentioned = []string{Unassigned}
+			}
+
+			for _, name := range mentioned {
+				key := strings.ToLower(name)
+
+				o, ok := byOwner[key]
+				if !ok {
+					o = &Owner{Owner: name}
+					byOwner[key] = o
+					names = append(names, key)
+				}
+
+				o.Items = append(o.Items, OwnerItem{
+					Dir:     d.Dir,
+					ID:      d.ID,
+					Title:   d.Title,
+					Path:    d.Path,
+					Line:    item.Line,
+					Text:    item.Text,
+					Overdue: d.Overdue,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		if names[i] == Unassigned || names[j] == Unassigned {
+			return names[j] == Unassigned && names[i] != Unassigned
+		}
+
+		return names[i] < names[j]
+	})
+
+	res := []Owner{}
+	for _, name := range names {
+		res = append(res, *byOwner[name])
+	}
+
+	return res
+}
+
+// Handle is the main Handler function. This function collects the unchecked
+// task list items of the accepted records, grouped by decision and by the
+// owners they mention, and flags the decisions that were accepted longer
+// ago than the interval of the options.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	switch opts.Group {
+	case GroupDecision, GroupOwner:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownGroup, opts.Group)
+	}
+
+	now := h.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var cutoff time.Time
+
+	if opts.Older != "" {
+		older, err := adr.ParseInterval(opts.Older)
+		if err != nil {
+			return fmt.Errorf("older: %w", err)
+		}
+
+		cutoff = older.Before(today)
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	decisions, err := h.decisions(stateDir, dirs, today, cutoff)
+	if err != nil {
+		return err
+	}
+
+	report := Report{Decisions: decisions, Owners: owners(decisions)}
+
+	if opts.Format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	return render(out, report, opts)
+}
+
+func render(out io.Writer, report Report, opts Options) error {
+	if len(report.Decisions) == 0 {
+		_, err := fmt.Fprintln(out, "no open follow-ups")
+		return err
+	}
+
+	var items, overdue int
+
+	for _, d := range report.Decisions {
+		items += len(d.Items)
+
+		if d.Overdue {
+			overdue++
+		}
+	}
+
+	if opts.Group == GroupOwner {
+		for i, o := range report.Owners {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+
+			fmt.Fprintf(out, "%s:\n", o.Owner)
+
+			for _, item := range o.Items {
+				fmt.Fprintf(out, "  - %s:%s %s (%s:%d%s)\n",
+					item.Dir, item.ID, item.Text, item.Path, item.Line, flag(item.Overdue, opts))
+			}
+		}
+	} else {
+		for i, d := range report.Decisions {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+
+			fmt.Fprintf(out, "%s:%s %s (%s%s):\n", d.Dir, d.ID, d.Title, accepted(d), flag(d.Overdue, opts))
+
+			for _, item := range d.Items {
+				fmt.Fprintf(out, "  - %s (%s:%d)\n", item.Text, d.Path, item.Line)
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("%s in %s", adr.Plural(items, "open follow-up"), adr.Plural(len(report.Decisions), "decision"))
+	if overdue > 0 {
+		summary = fmt.Sprintf("%s, %d older than %s", summary, overdue, opts.Older)
+	}
+
+	_, err := fmt.Fprintf(out, "\n%s\n", summary)
+
+	return err
+}
+
+func accepted(d Decision) string {
+	if d.Accepted == "" {
+		return "accepted"
+	}
+
+	return fmt.Sprintf("accepted %s, %s ago", d.Accepted, adr.Plural(d.Days, "day"))
+}
+
+func flag(overdue bool, opts Options) string {
+	if !overdue {
+		return ""
+	}
+
+	return fmt.Sprintf(", older than %s", opts.Older)
+}
@@ -0,0 +1,328 @@
+package todo_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/todo"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform"}
+	securityDir = adr.Directory{Path: "security", Name: "security"}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	now = time.Date(2024, 6, 15, 13, 30, 0, 0, time.UTC)
+
+	platformRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Use PostgreSQL",
+			Status: "Accepted",
+			Date:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0001-use-postgresql.md",
+		},
+		{
+			ID:     "0002",
+			Title:  "Use gRPC",
+			Status: "Accepted",
+			Date:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			Dir:    "platform",
+			Path:   "docs/adr/0002-use-grpc.md",
+		},
+		{
+			ID:     "0003",
+			Title:  "Use Kafka",
+			Status: "Proposed",
+			Dir:    "platform",
+			Path:   "docs/adr/0003-use-kafka.md",
+		},
+		{
+			ID:     "0004",
+			Title:  "Use NATS",
+			Status: "Accepted",
+			Dir:    "platform",
+			Path:   "docs/adr/0004-use-nats.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:     "0001",
+			Title:  "Rotate keys",
+			Status: "Accepted",
+			Dir:    "security",
+			Path:   "security/0001-rotate-keys.md",
+		},
+	}
+
+	contents = map[string]string{
+		"/docs/adr/0001-use-postgresql.md": "# Use PostgreSQL\n\n## Consequences\n\n" +
+			"- [ ] Add replica alerts @sre\n- [ ] Write a runbook\n- [x] Provision the cluster @sre\n",
+		"/docs/adr/0002-use-grpc.md":    "# Use gRPC\n\n## Consequences\n\n- [ ] Publish the protos @Jane @sre\n",
+		"/docs/adr/0004-use-nats.md":    "# Use NATS\n",
+		"/security/0001-rotate-keys.md": "# Rotate keys\n\n## Consequences\n\n- [ ] Rotate the CA @jane\n",
+	}
+)
+
+func clock() time.Time {
+	return now
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) todo.StateManager
+		store        func(ctrl *gomock.Controller) todo.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) todo.StateManager {
+		sm := todo.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) todo.RecordStore {
+		rs := todo.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+		rs.EXPECT().Read(gomock.Any()).DoAndReturn(func(path string) ([]byte, error) {
+			return []byte(contents[path]), nil
+		}).AnyTimes()
+
+		return rs
+	}
+
+	noState := func(ctrl *gomock.Controller) todo.StateManager {
+		return todo.NewmockStateManager(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  todo.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "grouped by decision",
+			opts: todo.Options{Older: "90d", Group: todo.GroupDecision, Format: todo.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "platform:0001 Use PostgreSQL (accepted 2024-01-01, 166 days ago, older than 90d):\n" +
+					"  - Add replica alerts @sre (docs/adr/0001-use-postgresql.md:5)\n" +
+					"  - Write a runbook (docs/adr/0001-use-postgresql.md:6)\n" +
+					"\n" +
+					"platform:0002 Use gRPC (accepted 2024-06-01, 14 days ago):\n" +
+					"  - Publish the protos @Jane @sre (docs/adr/0002-use-grpc.md:5)\n" +
+					"\n" +
+					"security:0001 Rotate keys (accepted):\n" +
+					"  - Rotate the CA @jane (security/0001-rotate-keys.md:5)\n" +
+					"\n" +
+					"4 open follow-ups in 3 decisions, 1 older than 90d\n",
+			},
+		},
+		{
+			name: "grouped by owner",
+			opts: todo.Options{Older: "90d", Group: todo.GroupOwner, Format: todo.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "@Jane:\n" +
+					"  - platform:0002 Publish the protos @Jane @sre (docs/adr/0002-use-grpc.md:5)\n" +
+					"  - security:0001 Rotate the CA @jane (security/0001-rotate-keys.md:5)\n" +
+					"\n" +
+					"@sre:\n" +
+					"  - platform:0001 Add replica alerts @sre (docs/adr/0001-use-postgresql.md:5, older than 90d)\n" +
+					"  - platform:0002 Publish the protos @Jane @sre (docs/adr/0002-use-grpc.md:5)\n" +
+					"\n" +
+					"unassigned:\n" +
+					"  - platform:0001 Write a runbook (docs/adr/0001-use-postgresql.md:6, older than 90d)\n" +
+					"\n" +
+					"4 open follow-ups in 3 decisions, 1 older than 90d\n",
+			},
+		},
+		{
+			name: "single dir as json",
+			opts: todo.Options{Dir: "security", Group: todo.GroupDecision, Format: todo.FormatJSON},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: `{
+  "decisions": [
+    {
+      "dir": "security",
+      "id": "0001",
+      "title": "Rotate keys",
+      "path": "security/0001-rotate-keys.md",
+      "days": 0,
+      "overdue": false,
+      "items": [
+        {
+          "line": 5,
+          "text": "Rotate the CA @jane",
+          "owners": [
+            "@jane"
+          ]
+        }
+      ]
+    }
+  ],
+  "owners": [
+    {
+      "owner": "@jane",
+      "items": [
+        {
+          "dir": "security",
+          "id": "0001",
+          "title": "Rotate keys",
+          "path": "security/0001-rotate-keys.md",
+          "line": 5,
+          "text": "Rotate the CA @jane",
+          "overdue": false
+        }
+      ]
+    }
+  ]
+}
+`,
+			},
+		},
+		{
+			name: "nothing to do",
+			opts: todo.Options{Group: todo.GroupDecision, Format: todo.FormatText},
+			setup: setup{
+				stateManager: loaded,
+				store: func(ctrl *gomock.Controller) todo.RecordStore {
+					rs := todo.NewmockRecordStore(ctrl)
+					rs.EXPECT().List("/", platformDir).Return(platformRecords[2:], nil)
+					rs.EXPECT().List("/", securityDir).Return(nil, nil)
+					rs.EXPECT().Read("/docs/adr/0004-use-nats.md").Return([]byte(contents["/docs/adr/0004-use-nats.md"]), nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: "no open follow-ups\n",
+			},
+		},
+		{
+			name: "invalid interval",
+			opts: todo.Options{Older: "soon", Group: todo.GroupDecision, Format: todo.FormatText},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: adr.ErrInvalidInterval,
+			},
+		},
+		{
+			name: "unknown grouping",
+			opts: todo.Options{Group: "team", Format: todo.FormatText},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: todo.ErrUnknownGroup,
+			},
+		},
+		{
+			name: "unknown format",
+			opts: todo.Options{Group: todo.GroupDecision, Format: "csv"},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: todo.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: todo.Options{Dir: "nope", Group: todo.GroupDecision, Format: todo.FormatText},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) todo.StateManager {
+					sm := todo.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			opts: todo.Options{Group: todo.GroupDecision, Format: todo.FormatText},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) todo.StateManager {
+					sm := todo.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := todo.New(
+				todo.WithStateManager(tt.setup.stateManager(ctrl)),
+				todo.WithRecordStore(tt.setup.store(ctrl)),
+				todo.WithClock(clock),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
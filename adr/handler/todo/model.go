@@ -0,0 +1,79 @@
+package todo
+
+// The output formats of the todo command.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// The groupings of the text output.
+const (
+	GroupDecision = "decision"
+	GroupOwner    = "owner"
+)
+
+// Unassigned is the owner of the follow-ups that mention nobody.
+const Unassigned = "unassigned"
+
+// Options represents the input of the todo command.
+type Options struct {
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Older is the interval after which the follow-ups of a decision are
+	// flagged, counted from the day it was accepted, such as 90d. Nothing is
+	// flagged when it is empty.
+	Older string
+	// Group is the grouping of the text output, either decision or owner.
+	// The json output holds both.
+	Group string
+	// Format is the output format, either text or json.
+	Format string
+}
+
+// Report holds the open follow-ups, grouped by decision and by owner.
+type Report struct {
+	Decisions []Decision `json:"decisions"`
+	Owners    []Owner    `json:"owners"`
+}
+
+// Decision represents an accepted record with open follow-ups.
+type Decision struct {
+	Dir   string `json:"dir"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	// Accepted is the day the decision was accepted, if it is known.
+	Accepted string `json:"accepted,omitempty"`
+	// Days is the number of days since the decision was accepted.
+	Days int `json:"days"`
+	// Overdue reports whether the follow-ups are older than the interval
+	// of the options.
+	Overdue bool   `json:"overdue"`
+	Items   []Item `json:"items"`
+}
+
+// Item represents an open follow-up of a decision.
+type Item struct {
+	Line   int      `json:"line"`
+	Text   string   `json:"text"`
+	Owners []string `json:"owners"`
+}
+
+// Owner represents the open follow-ups that mention a person or team.
+type Owner struct {
+	Owner string      `json:"owner"`
+	Items []OwnerItem `json:"items"`
+}
+
+// OwnerItem represents an open follow-up of an owner, along with the
+// decision it belongs to.
+type OwnerItem struct {
+	Dir     string `json:"dir"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Overdue bool   `json:"overdue"`
+}
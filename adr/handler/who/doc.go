@@ -0,0 +1,4 @@
+// Package who provides handler functionality for the adr who command, which
+// lists the decisions a person or team is involved in as a decider, or as
+// someone who is consulted or informed.
+package who
@@ -0,0 +1,156 @@
+package who
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	// ErrUnknownFormat is returned when the requested output format is not
+	// supported.
+	ErrUnknownFormat = errors.New("unknown output format")
+
+	// ErrNoPerson is returned when no person is given to look up.
+	ErrNoPerson = errors.New("no person given")
+)
+
+// Handler describes a type that is used to handle the who command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// normalize reduces a person to the form they are compared in, so that
+// @Jane and jane are the same person.
+func normalize(person string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(person), "@"))
+}
+
+func involves(people []string, person string) bool {
+	for _, p := range people {
+		if normalize(p) == person {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roles returns the roles the person has in the decision of the record.
+func roles(rec adr.Record, person string) []string {
+	var res []string
+
+	if involves(rec.Deciders, person) {
+		res = append(res, RoleDecider)
+	}
+
+	if involves(rec.Consulted, person) {
+		res = append(res, RoleConsulted)
+	}
+
+	if involves(rec.Informed, person) {
+		res = append(res, RoleInformed)
+	}
+
+	return res
+}
+
+// Handle is the main Handler function. This function lists the records of
+// the decisions the person is involved in, along with their roles.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	switch opts.Format {
+	case FormatTable, FormatJSON:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	person := normalize(opts.Person)
+	if person == "" {
+		return ErrNoPerson
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	dirs, err := s.ADR.SelectDirectories(opts.Dir, h.stateManager.NormalizePath)
+	if err != nil {
+		return err
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	entries := []entry{}
+
+	for _, dir := range dirs {
+		records, err := h.store.List(stateDir, dir)
+		if err != nil {
+			return fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		for _, rec := range records {
+			if r := roles(rec, person); len(r) > 0 {
+				entries = append(entries, entry{
+					ID:     rec.ID,
+					Title:  rec.Title,
+					Status: rec.Status,
+					Roles:  r,
+					Dir:    rec.Dir,
+					Path:   rec.Path,
+				})
+			}
+		}
+	}
+
+	if opts.Format == FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "no decisions involve %s\n", opts.Person)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tROLE\tDIR")
+
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.Title, e.Status, strings.Join(e.Roles, ", "), e.Dir)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,226 @@
+package who_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/handler/who"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	platformRecords = []adr.Record{
+		{
+			ID:        "0001",
+			Title:     "Use PostgreSQL",
+			Status:    "Accepted",
+			Deciders:  []string{"@jane", "@john"},
+			Consulted: []string{"@dba"},
+			Dir:       "platform",
+			Path:      "docs/adr/0001-use-postgresql.md",
+		},
+		{
+			ID:       "0002",
+			Title:    "Use Kafka",
+			Status:   "Proposed",
+			Deciders: []string{"@john"},
+			Dir:      "platform",
+			Path:     "docs/adr/0002-use-kafka.md",
+		},
+	}
+
+	securityRecords = []adr.Record{
+		{
+			ID:        "0001",
+			Title:     "Rotate keys",
+			Status:    "Accepted",
+			Deciders:  []string{"@mallory"},
+			Consulted: []string{"Jane"},
+			Informed:  []string{"@JANE", "@org/sre"},
+			Dir:       "security",
+			Path:      "security/0001-rotate-keys.md",
+		},
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) who.StateManager
+		store        func(ctrl *gomock.Controller) who.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) who.StateManager {
+		sm := who.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	records := func(ctrl *gomock.Controller) who.RecordStore {
+		rs := who.NewmockRecordStore(ctrl)
+		rs.EXPECT().List("/", platformDir).Return(platformRecords, nil).AnyTimes()
+		rs.EXPECT().List("/", securityDir).Return(securityRecords, nil).AnyTimes()
+
+		return rs
+	}
+
+	noState := func(ctrl *gomock.Controller) who.StateManager {
+		return who.NewmockStateManager(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  who.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			opts: who.Options{Person: "jane", Format: who.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "ID    TITLE           STATUS    ROLE                 DIR\n" +
+					"0001  Use PostgreSQL  Accepted  decider              platform\n" +
+					"0001  Rotate keys     Accepted  consulted, informed  security\n",
+			},
+		},
+		{
+			name: "team in a single dir as json",
+			opts: who.Options{Person: "@org/sre", Dir: "security", Format: who.FormatJSON},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: `[
+  {
+    "id": "0001",
+    "title": "Rotate keys",
+    "status": "Accepted",
+    "roles": [
+      "informed"
+    ],
+    "dir": "security",
+    "path": "security/0001-rotate-keys.md"
+  }
+]
+`,
+			},
+		},
+		{
+			name: "not involved",
+			opts: who.Options{Person: "@bob", Format: who.FormatTable},
+			setup: setup{
+				stateManager: loaded,
+				store:        records,
+			},
+			wants: want{
+				output: "no decisions involve @bob\n",
+			},
+		},
+		{
+			name: "no person",
+			opts: who.Options{Person: " @ ", Format: who.FormatTable},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: who.ErrNoPerson,
+			},
+		},
+		{
+			name: "unknown format",
+			opts: who.Options{Person: "jane", Format: "csv"},
+			setup: setup{
+				stateManager: noState,
+				store:        records,
+			},
+			wants: want{
+				err: who.ErrUnknownFormat,
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: who.Options{Person: "jane", Dir: "nope", Format: who.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) who.StateManager {
+					sm := who.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			opts: who.Options{Person: "jane", Format: who.FormatTable},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) who.StateManager {
+					sm := who.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: records,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := who.New(
+				who.WithStateManager(tt.setup.stateManager(ctrl)),
+				who.WithRecordStore(tt.setup.store(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
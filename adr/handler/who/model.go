@@ -0,0 +1,36 @@
+package who
+
+// The output formats of the who command.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// The roles a person or team can have in a decision.
+const (
+	RoleDecider   = "decider"
+	RoleConsulted = "consulted"
+	RoleInformed  = "informed"
+)
+
+// Options represents the input of the who command.
+type Options struct {
+	// Person is the person or team to look up. A leading @ and case are
+	// ignored.
+	Person string
+	// Dir limits the command to the records of a single adr directory, by
+	// name or path.
+	Dir string
+	// Format is the output format, either table or json.
+	Format string
+}
+
+// entry represents a decision the person is involved in.
+type entry struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Status string   `json:"status"`
+	Roles  []string `json:"roles"`
+	Dir    string   `json:"dir"`
+	Path   string   `json:"path"`
+}
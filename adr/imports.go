@@ -0,0 +1,65 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidImportRule is returned when the import rules of a record cannot
+// be read, or a rule lacks its packages.
+var ErrInvalidImportRule = errors.New("invalid import rule")
+
+// ImportRule restricts the imports of a set of Go packages. Packages are
+// given as import paths, or as paths relative to the root of their module,
+// and may use the "..." wildcard of the go command.
+type ImportRule struct {
+	// From selects the packages the rule applies to, such as
+	// internal/billing/...
+	From string `yaml:"from"`
+	// Deny lists the packages that the selected packages must not import.
+	Deny []string `yaml:"deny"`
+}
+
+// Validate checks that the rule selects packages and denies imports.
+func (r ImportRule) Validate() error {
+	if strings.TrimSpace(r.From) == "" {
+		return fmt.Errorf("%w: missing from", ErrInvalidImportRule)
+	}
+
+	if len(r.Deny) == 0 {
+		return fmt.Errorf("%w: %s denies no imports", ErrInvalidImportRule, r.From)
+	}
+
+	return nil
+}
+
+// MatchPackage reports whether the package pattern matches the import path.
+// Patterns are matched against the import path, and against the path
+// relative to the module if the package belongs to the given module.
+func MatchPackage(pattern string, importPath string, module string) bool {
+	re := packagePattern(pattern)
+
+	if re.MatchString(importPath) {
+		return true
+	}
+
+	rel := strings.TrimPrefix(importPath, module+"/")
+
+	return module != "" && rel != importPath && re.MatchString(rel)
+}
+
+// packagePattern converts a package pattern into a regular expression, in
+// the way of the go command, where "..." matches any string and a trailing
+// "/..." also matches the parent.
+func packagePattern(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(strings.Trim(pattern, "/"))
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+
+	if strings.HasSuffix(expr, `/.*`) {
+		expr = strings.TrimSuffix(expr, `/.*`) + `(/.*)?`
+	}
+
+	return regexp.MustCompile(`^` + expr + `$`)
+}
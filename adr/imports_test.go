@@ -0,0 +1,75 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestMatchPackage(t *testing.T) {
+	const module = "example.com/shop"
+
+	testCases := []struct {
+		pattern    string
+		importPath string
+		wants      bool
+	}{
+		{pattern: "internal/auth", importPath: "example.com/shop/internal/auth", wants: true},
+		{pattern: "internal/auth", importPath: "example.com/shop/internal/auth/token", wants: false},
+		{pattern: "internal/auth/...", importPath: "example.com/shop/internal/auth", wants: true},
+		{pattern: "internal/auth/...", importPath: "example.com/shop/internal/auth/token", wants: true},
+		{pattern: "internal/auth/...", importPath: "example.com/shop/internal/authz", wants: false},
+		{pattern: "internal/.../db", importPath: "example.com/shop/internal/billing/db", wants: true},
+		{pattern: "example.com/shop/internal/...", importPath: "example.com/shop/internal/auth", wants: true},
+		{pattern: "github.com/lib/pq", importPath: "github.com/lib/pq", wants: true},
+		{pattern: "internal/auth", importPath: "other.com/internal/auth", wants: false},
+		{pattern: "net/...", importPath: "net/http", wants: true},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.pattern+" "+tt.importPath, func(t *testing.T) {
+			assert.Equal(t, tt.wants, adr.MatchPackage(tt.pattern, tt.importPath, module))
+		})
+	}
+}
+
+func TestImportRuleValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rule  adr.ImportRule
+		wants error
+	}{
+		{
+			name: "valid",
+			rule: adr.ImportRule{From: "internal/billing/...", Deny: []string{"internal/auth/..."}},
+		},
+		{
+			name:  "missing from",
+			rule:  adr.ImportRule{Deny: []string{"internal/auth/..."}},
+			wants: adr.ErrInvalidImportRule,
+		},
+		{
+			name:  "nothing denied",
+			rule:  adr.ImportRule{From: "internal/billing/..."},
+			wants: adr.ErrInvalidImportRule,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
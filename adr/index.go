@@ -0,0 +1,75 @@
+package adr
+
+import (
+	"errors"
+	"time"
+)
+
+// The index types that are supported by an adr directory. The index type
+// decides how the identifier of a new record is produced.
+const (
+	IndexTimestamp  = "timestamp"
+	IndexSequential = "sequential"
+)
+
+const (
+	// TimestampIndexFormat is the layout of the ids written by the timestamp
+	// index.
+	TimestampIndexFormat = "20060102150405"
+
+	// DateIndexFormat is the layout of the date ids written by log4brains.
+	DateIndexFormat = "20060102"
+)
+
+// ErrUnknownIndex is returned when a directory is configured with an index
+// type that docula does not support.
+var ErrUnknownIndex = errors.New("unknown index type")
+
+// IndexTypes returns all of the supported index types.
+func IndexTypes() []string {
+	return []string{IndexTimestamp, IndexSequential}
+}
+
+// IndexType returns the index type of the directory. Directories without an
+// index type are treated as sequential.
+func (d Directory) IndexType() string {
+	if d.Index == "" {
+		return IndexSequential
+	}
+
+	return d.Index
+}
+
+// InferIndex returns the index type that produced the given record ids.
+// Timestamps and dates, such as those written by the timestamp index or
+// log4brains, are timestamps, and any others are sequential. An empty
+// string is returned when there are no ids.
+func InferIndex(ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+
+	for _, id := range ids {
+		if !IsTimestampID(id) {
+			return IndexSequential
+		}
+	}
+
+	return IndexTimestamp
+}
+
+// IsTimestampID reports whether the record id is a timestamp of the form
+// 20060102150405, or a date of the form 20060102.
+func IsTimestampID(id string) bool {
+	for _, layout := range []string{TimestampIndexFormat, DateIndexFormat} {
+		if len(id) != len(layout) {
+			continue
+		}
+
+		if _, err := time.Parse(layout, id); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
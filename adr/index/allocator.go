@@ -0,0 +1,215 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// ErrLocked is returned when the lock file could not be obtained before the
+// lock timeout elapsed.
+var ErrLocked = errors.New("index is locked by another process")
+
+const (
+	// LockFile is the name of the lock file, relative to the state dir.
+	LockFile = ".docula.lock"
+
+	// TimestampFormat is the layout of timestamp identifiers.
+	TimestampFormat = adr.TimestampIndexFormat
+
+	// staleLock is the age after which a lock file is assumed to have been
+	// left behind by a process that has died.
+	staleLock = 30 * time.Second
+
+	// breakSuffix is appended to the lock file to name the lock that is held
+	// while a stale lock file is removed.
+	breakSuffix = ".break"
+
+	retryInterval = 50 * time.Millisecond
+)
+
+var indexedFile = regexp.MustCompile(`^(\d+)-`)
+
+// Allocator produces the next identifier of an adr directory.
+type Allocator struct {
+	fs          FileSystem
+	now         func() time.Time
+	lockTimeout time.Duration
+}
+
+// New acts as the default constructor for the Allocator type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Allocator {
+	a := &Allocator{
+		fs:          &defaultFileSystem{},
+		now:         time.Now,
+		lockTimeout: 10 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Allocate obtains the next identifier for the given directory and passes it
+// to write. The lock is held until write returns, so write should create the
+// record file for the identifier before returning.
+func (a *Allocator) Allocate(
+	ctx context.Context, stateDir string, dir adr.Directory, write func(id string) error,
+) error {
+	if err := dir.Validate(); err != nil {
+		return err
+	}
+
+	lockPath := fmt.Sprintf("%s%s", stateDir, LockFile)
+
+	if err := a.lock(ctx, lockPath); err != nil {
+		return fmt.Errorf("obtain lock: %w", err)
+	}
+
+	defer a.fs.Remove(lockPath)
+
+	a.removeStaleBreak(lockPath)
+
+	ids, err := a.existingIDs(fmt.Sprintf("%s%s", stateDir, dir.Path))
+	if err != nil {
+		return err
+	}
+
+	var id string
+
+	switch dir.IndexType() {
+	case adr.IndexTimestamp:
+		id = nextTimestamp(ids, a.now())
+	default:
+		id = nextSequential(ids)
+	}
+
+	return write(id)
+}
+
+func (a *Allocator) lock(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.lockTimeout)
+	defer cancel()
+
+	for {
+		err := a.fs.CreateExclusive(path)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+
+		if a.removeStale(path) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrLocked, path)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// removeStale removes the lock file if it is older than the stale lock age.
+// It reports whether the lock file was removed. The lock file is checked
+// again and removed only while holding the break lock, so that of the
+// processes that find the same stale lock, only one removes it, and the
+// others find the lock it went on to create rather than removing it too.
+func (a *Allocator) removeStale(path string) bool {
+	if !a.isStale(path) {
+		return false
+	}
+
+	breakPath := path + breakSuffix
+
+	if err := a.fs.CreateExclusive(breakPath); err != nil {
+		return false
+	}
+
+	defer a.fs.Remove(breakPath)
+
+	if !a.isStale(path) {
+		return false
+	}
+
+	return a.fs.Remove(path) == nil
+}
+
+// removeStaleBreak removes the break lock if it is older than the stale lock
+// age, which is only the case if a process died while holding it. It must
+// only be called while holding the lock, as the break lock is only ever
+// taken while the lock file is stale.
+func (a *Allocator) removeStaleBreak(path string) {
+	if breakPath := path + breakSuffix; a.isStale(breakPath) {
+		a.fs.Remove(breakPath)
+	}
+}
+
+// isStale reports whether the file exists and is older than the stale lock
+// age.
+func (a *Allocator) isStale(path string) bool {
+	info, err := a.fs.Stat(path)
+
+	return err == nil && info != nil && a.stale(info)
+}
+
+func (a *Allocator) stale(info os.FileInfo) bool {
+	return a.now().Sub(info.ModTime()) >= staleLock
+}
+
+func (a *Allocator) existingIDs(path string) (map[string]bool, error) {
+	entries, err := a.fs.ReadDir(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read adr dir: %w", err)
+	}
+
+	ids := map[string]bool{}
+
+	for _, entry := range entries {
+		if match := indexedFile.FindStringSubmatch(entry.Name()); match != nil {
+			ids[match[1]] = true
+		}
+	}
+
+	return ids, nil
+}
+
+func nextSequential(ids map[string]bool) string {
+	highest := 0
+
+	for id := range ids {
+		n, err := strconv.Atoi(id)
+		if err == nil && n > highest {
+			highest = n
+		}
+	}
+
+	return fmt.Sprintf("%04d", highest+1)
+}
+
+// nextTimestamp formats the current time as an identifier. If a record was
+// already created within the same second, the identifier is moved forward
+// until it is unique.
+func nextTimestamp(ids map[string]bool, now time.Time) string {
+	now = now.UTC().Truncate(time.Second)
+
+	for ids[now.Format(TimestampFormat)] {
+		now = now.Add(time.Second)
+	}
+
+	return now.Format(TimestampFormat)
+}
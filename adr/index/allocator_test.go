@@ -0,0 +1,367 @@
+package index_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/index"
+)
+
+func clock() time.Time {
+	return time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+}
+
+func setupDir(t *testing.T, files ...string) string {
+	t.Helper()
+
+	tmp, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		os.RemoveAll(tmp)
+	})
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmp, "docs"), 0755))
+
+	for _, f := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(tmp, "docs", f), nil, 0644))
+	}
+
+	return tmp + "/"
+}
+
+func TestAllocatorAllocate(t *testing.T) {
+	type want struct {
+		id  string
+		err error
+	}
+
+	testCases := []struct {
+		name  string
+		files []string
+		index string
+		wants want
+	}{
+		{
+			name:  "empty sequential dir",
+			index: adr.IndexSequential,
+			wants: want{
+				id: "0001",
+			},
+		},
+		{
+			name:  "default index type",
+			files: []string{"0001-foo.md"},
+			wants: want{
+				id: "0002",
+			},
+		},
+		{
+			name:  "sequential dir with gaps",
+			files: []string{"0001-foo.md", "0009-bar.md", "README.md", "notes-0100.md"},
+			index: adr.IndexSequential,
+			wants: want{
+				id: "0010",
+			},
+		},
+		{
+			name:  "sequential dir beyond padding",
+			files: []string{"9999-foo.md"},
+			index: adr.IndexSequential,
+			wants: want{
+				id: "10000",
+			},
+		},
+		{
+			name:  "empty timestamp dir",
+			index: adr.IndexTimestamp,
+			wants: want{
+				id: "20220714093000",
+			},
+		},
+		{
+			name:  "timestamp collision",
+			files: []string{"20220714093000-foo.md", "20220714093001-bar.md"},
+			index: adr.IndexTimestamp,
+			wants: want{
+				id: "20220714093002",
+			},
+		},
+		{
+			name:  "unknown index type",
+			index: "random",
+			wants: want{
+				err: adr.ErrUnknownIndex,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			stateDir := setupDir(t, tt.files...)
+
+			a := index.New(index.WithClock(clock))
+
+			var id string
+
+			err := a.Allocate(
+				context.Background(),
+				stateDir,
+				adr.Directory{Path: "docs", Index: tt.index},
+				func(allocated string) error {
+					id = allocated
+					return nil
+				},
+			)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.id, id)
+
+			_, err = os.Stat(stateDir + index.LockFile)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestAllocatorConcurrentAllocations(t *testing.T) {
+	const workers = 10
+
+	stateDir := setupDir(t)
+	dir := adr.Directory{Path: "docs", Index: adr.IndexSequential}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := index.New().Allocate(context.Background(), stateDir, dir, func(id string) error {
+				name := fmt.Sprintf("%sdocs/%s-record.md", stateDir, id)
+				return os.WriteFile(name, nil, 0644)
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	entries, err := os.ReadDir(stateDir + "docs")
+	assert.NoError(t, err)
+	assert.Len(t, entries, workers)
+	assert.Equal(t, "0010-record.md", entries[workers-1].Name())
+}
+
+func TestAllocatorConcurrentStaleLock(t *testing.T) {
+	const workers = 10
+
+	stateDir := setupDir(t)
+	dir := adr.Directory{Path: "docs", Index: adr.IndexSequential}
+
+	lock := stateDir + index.LockFile
+	assert.NoError(t, os.WriteFile(lock, nil, 0644))
+	assert.NoError(t, os.Chtimes(lock, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := index.New().Allocate(context.Background(), stateDir, dir, func(id string) error {
+				name := fmt.Sprintf("%sdocs/%s-record.md", stateDir, id)
+				return os.WriteFile(name, nil, 0644)
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	entries, err := os.ReadDir(stateDir + "docs")
+	assert.NoError(t, err)
+	assert.Len(t, entries, workers)
+
+	leftovers, err := filepath.Glob(lock + "*")
+	assert.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+type fileInfo struct {
+	os.FileInfo
+	modTime time.Time
+}
+
+func (f fileInfo) ModTime() time.Time {
+	return f.modTime
+}
+
+func TestAllocatorLock(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(ctrl *gomock.Controller) index.FileSystem
+		wants error
+	}{
+		{
+			name: "stale lock is removed",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrExist),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock().Add(-time.Hour)}, nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock.break").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock().Add(-time.Hour)}, nil),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+					fs.EXPECT().Remove("/.docula.lock.break").Return(nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock.break").Return(nil, os.ErrNotExist),
+					fs.EXPECT().ReadDir("/docs").Return(nil, nil),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "stale lock being removed by another process",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrExist),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock().Add(-time.Hour)}, nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock.break").Return(os.ErrExist),
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock.break").Return(fileInfo{modTime: clock()}, nil),
+					fs.EXPECT().ReadDir("/docs").Return(nil, nil),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "stale lock replaced by another process",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrExist),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock().Add(-time.Hour)}, nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock.break").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock()}, nil),
+					fs.EXPECT().Remove("/.docula.lock.break").Return(nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrExist),
+					fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock()}, nil),
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock.break").Return(nil, os.ErrNotExist),
+					fs.EXPECT().ReadDir("/docs").Return(nil, nil),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "break lock left behind by a process that died",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock.break").Return(fileInfo{modTime: clock().Add(-time.Hour)}, nil),
+					fs.EXPECT().Remove("/.docula.lock.break").Return(nil),
+					fs.EXPECT().ReadDir("/docs").Return(nil, nil),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "lock held by another process",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrExist).MinTimes(1)
+				fs.EXPECT().Stat("/.docula.lock").Return(fileInfo{modTime: clock()}, nil).MinTimes(1)
+
+				return fs
+			},
+			wants: index.ErrLocked,
+		},
+		{
+			name: "failing to create the lock",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+				fs.EXPECT().CreateExclusive("/.docula.lock").Return(os.ErrPermission)
+				return fs
+			},
+			wants: os.ErrPermission,
+		},
+		{
+			name: "failing to read the dir",
+			setup: func(ctrl *gomock.Controller) index.FileSystem {
+				fs := index.NewmockFileSystem(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().CreateExclusive("/.docula.lock").Return(nil),
+					fs.EXPECT().Stat("/.docula.lock.break").Return(nil, os.ErrNotExist),
+					fs.EXPECT().ReadDir("/docs").Return(nil, os.ErrPermission),
+					fs.EXPECT().Remove("/.docula.lock").Return(nil),
+				)
+
+				return fs
+			},
+			wants: os.ErrPermission,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := index.New(
+				index.WithFileSystem(tt.setup(ctrl)),
+				index.WithClock(clock),
+				index.WithLockTimeout(500*time.Millisecond),
+			)
+
+			err := a.Allocate(
+				context.Background(),
+				"/",
+				adr.Directory{Path: "docs"},
+				func(string) error { return nil },
+			)
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
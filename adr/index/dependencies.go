@@ -0,0 +1,17 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=index -mock_names FileSystem=mockFileSystem
+
+package index
+
+import "os"
+
+// FileSystem represents a type that is able to manipulate the filesystem.
+// This interface is typically a wrapper around the os package methods and
+// is used to allow for improved testing.
+type FileSystem interface {
+	// CreateExclusive creates the named file, failing with os.ErrExist if
+	// the file already exists.
+	CreateExclusive(name string) error
+	ReadDir(name string) ([]os.DirEntry, error)
+	Remove(name string) error
+	Stat(name string) (os.FileInfo, error)
+}
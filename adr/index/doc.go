@@ -0,0 +1,5 @@
+// Package index provides allocation of record identifiers for adr
+// directories. Allocation is guarded by a lock file that lives next to the
+// docula state file, which prevents concurrent docula processes from handing
+// out the same identifier.
+package index
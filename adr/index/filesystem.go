@@ -0,0 +1,28 @@
+package index
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) CreateExclusive(name string) error {
+	const filePerms = os.FileMode(0644)
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerms)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+func (f *defaultFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (f *defaultFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (f *defaultFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
@@ -0,0 +1,30 @@
+package index
+
+import "time"
+
+// Option represents a type that is able to override the default resources of
+// the allocator. These options are mainly used in a testing capacity.
+type Option func(a *Allocator)
+
+// WithFileSystem is used to override the internal FileSystem of the allocator.
+func WithFileSystem(fs FileSystem) Option {
+	return func(a *Allocator) {
+		a.fs = fs
+	}
+}
+
+// WithClock is used to override the function the allocator uses to obtain
+// the current time.
+func WithClock(now func() time.Time) Option {
+	return func(a *Allocator) {
+		a.now = now
+	}
+}
+
+// WithLockTimeout is used to override how long the allocator waits for
+// another process to release the lock.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(a *Allocator) {
+		a.lockTimeout = timeout
+	}
+}
@@ -0,0 +1,135 @@
+package adr
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The types of link that can be made between records.
+const (
+	LinkSupersedes   = "Supersedes"
+	LinkSupersededBy = "Superseded by"
+	LinkAmends       = "Amends"
+	LinkAmendedBy    = "Amended by"
+	LinkRelatesTo    = "Relates to"
+)
+
+var (
+	linkLine = regexp.MustCompile(
+		`^(?i)(?:[*-]\s+)?(super[sc]edes|super[sc]eded by|amends|amended by|relates to)\s+\[([^\]]*)\]\(([^)\s]+)\)`,
+	)
+
+	// adrToolsSpelling matches the spelling of supersede that adr-tools
+	// writes at the start of its links and statuses, as in "Supercedes" and
+	// "Superceded by".
+	adrToolsSpelling = regexp.MustCompile(`^(?i)((?:[*-]\s+)?super)c(ed)`)
+)
+
+// Link represents a markdown link from one record to another.
+type Link struct {
+	Type  string
+	Title string
+	// Target is the location of the linked record, relative to the record
+	// containing the link.
+	Target string
+}
+
+func (l Link) String() string {
+	return fmt.Sprintf("%s [%s](%s)", l.Type, l.Title, l.Target)
+}
+
+// Resolve returns the location of the linked record relative to the state
+// dir, given the location of the record containing the link.
+func (l Link) Resolve(from string) string {
+	return path.Join(path.Dir(from), l.Target)
+}
+
+func parseLink(line string) (Link, bool) {
+	match := linkLine.FindStringSubmatch(line)
+	if match == nil {
+		return Link{}, false
+	}
+
+	linkType := respell(match[1])
+
+	for _, t := range []string{LinkSupersedes, LinkSupersededBy, LinkAmends, LinkAmendedBy, LinkRelatesTo} {
+		if strings.EqualFold(t, linkType) {
+			linkType = t
+		}
+	}
+
+	return Link{Type: linkType, Title: match[2], Target: match[3]}, true
+}
+
+// respell replaces the adr-tools spelling of supersede at the start of the
+// line, so that "Superceded by" reads "Superseded by".
+func respell(line string) string {
+	return adrToolsSpelling.ReplaceAllString(line, "${1}s${2}")
+}
+
+// LinkTo produces a link of the given type from one record to another. The
+// target of the link is relative to the record it is written into, so it
+// resolves across adr directories.
+func LinkTo(linkType string, from Record, to Record) Link {
+	target, err := filepath.Rel(filepath.Dir(filepath.FromSlash(from.Path)), filepath.FromSlash(to.Path))
+	if err != nil {
+		target = to.Path
+	}
+
+	return Link{
+		Type:   linkType,
+		Title:  to.Label(),
+		Target: filepath.ToSlash(target),
+	}
+}
+
+// Label returns a short human readable description of the record.
+func (r Record) Label() string {
+	return fmt.Sprintf("%s. %s", r.ID, r.Title)
+}
+
+// AddLink writes the link into the status section of the record markdown,
+// below the status and any existing links. Records with an inline status
+// field have the link written at the end of their preamble instead, and
+// records with a frontmatter have it added to the links of the frontmatter.
+func AddLink(data []byte, link Link) ([]byte, error) {
+	if _, _, ok := splitFrontmatter(data); ok {
+		return editFrontmatter(data, func(mapping *yaml.Node) {
+			links := sequence(mapping, "links")
+			links.Content = append(links.Content, linkEntry(link))
+		})
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	statusLine := findStatusLine(lines)
+	if statusLine < 0 {
+		return nil, ErrNoStatus
+	}
+
+	if inlineStatus.MatchString(strings.TrimSpace(lines[statusLine])) {
+		return join(insert(lines, endOfPreamble(lines, statusLine), link.String(), "")), nil
+	}
+
+	at := statusLine + 1
+
+	for i := at; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if _, ok := parseLink(trimmed); ok {
+			at = i + 1
+			continue
+		}
+
+		if trimmed != "" {
+			break
+		}
+	}
+
+	return join(insert(lines, at, "", link.String())), nil
+}
@@ -0,0 +1,91 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestLinkTo(t *testing.T) {
+	testCases := []struct {
+		name  string
+		from  adr.Record
+		to    adr.Record
+		wants string
+	}{
+		{
+			name:  "same dir",
+			from:  adr.Record{Path: "docs/adr/0012-b.md"},
+			to:    adr.Record{ID: "0004", Title: "A", Path: "docs/adr/0004-a.md"},
+			wants: "Supersedes [0004. A](0004-a.md)",
+		},
+		{
+			name:  "sibling dir",
+			from:  adr.Record{Path: "docs/adr/0012-b.md"},
+			to:    adr.Record{ID: "0004", Title: "A", Path: "docs/security/0004-a.md"},
+			wants: "Supersedes [0004. A](../security/0004-a.md)",
+		},
+		{
+			name:  "nested dir",
+			from:  adr.Record{Path: "adr/0012-b.md"},
+			to:    adr.Record{ID: "0004", Title: "A", Path: "services/api/adr/0004-a.md"},
+			wants: "Supersedes [0004. A](../services/api/adr/0004-a.md)",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			link := adr.LinkTo(adr.LinkSupersedes, tt.from, tt.to)
+
+			assert.Equal(t, tt.wants, link.String())
+			assert.Equal(t, tt.to.Path, link.Resolve(tt.from.Path))
+		})
+	}
+}
+
+func TestAddLink(t *testing.T) {
+	link := adr.Link{Type: adr.LinkRelatesTo, Title: "0007. C", Target: "0007-c.md"}
+
+	input := `# B
+
+## Status
+
+Accepted
+
+Supersedes [0004. A](0004-a.md)
+
+## Context
+`
+
+	wants := `# B
+
+## Status
+
+Accepted
+
+Supersedes [0004. A](0004-a.md)
+
+Relates to [0007. C](0007-c.md)
+
+## Context
+`
+
+	res, err := adr.AddLink([]byte(input), link)
+	assert.NoError(t, err)
+	assert.Equal(t, wants, string(res))
+
+	rec, err := adr.ParseRecord("0012-b.md", res)
+	assert.NoError(t, err)
+	assert.Equal(t, "Accepted", rec.Status)
+	assert.Equal(t, []adr.Link{
+		{Type: adr.LinkSupersedes, Title: "0004. A", Target: "0004-a.md"},
+		link,
+	}, rec.Links)
+
+	_, err = adr.AddLink([]byte("# B\n"), link)
+	assert.ErrorIs(t, err, adr.ErrNoStatus)
+}
@@ -0,0 +1,588 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The markers of a decision matrix. The info string of a fenced YAML code
+// block marks the block as the source of the matrix, while the comments
+// surround the rendered table. A marked table without a YAML block is the
+// source of the matrix itself.
+const (
+	matrixFence = "docula:matrix"
+	matrixEnd   = "<!-- /docula:matrix -->"
+)
+
+var (
+	// ErrNoMatrix is returned when a record holds no decision matrix.
+	ErrNoMatrix = errors.New("no decision matrix")
+
+	// ErrInvalidMatrix is returned when a decision matrix cannot be read,
+	// or its weights and scores do not add up.
+	ErrInvalidMatrix = errors.New("invalid decision matrix")
+)
+
+var (
+	matrixStart = regexp.MustCompile(`^<!-- docula:matrix(?: scale=(\S+))? -->$`)
+	scaleRange  = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?:-|\.\.)\s*(\d+(?:\.\d+)?)$`)
+	separator   = regexp.MustCompile(`^:?-+:?$`)
+)
+
+// DefaultScale is the scale of a matrix that does not declare one.
+var DefaultScale = Scale{Min: 1, Max: 5}
+
+// Scale is the range the scores of a matrix must fall within.
+type Scale struct {
+	Min float64
+	Max float64
+}
+
+// ParseScale parses a scale such as 1-5 or 0..10.
+func ParseScale(value string) (Scale, error) {
+	match := scaleRange.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return Scale{}, fmt.Errorf("%w: scale %q is not a range such as 1-5", ErrInvalidMatrix, value)
+	}
+
+	lo, _ := strconv.ParseFloat(match[1], 64)
+	hi, _ := strconv.ParseFloat(match[2], 64)
+
+	if lo >= hi {
+		return Scale{}, fmt.Errorf("%w: scale %q is empty", ErrInvalidMatrix, value)
+	}
+
+	return Scale{Min: lo, Max: hi}, nil
+}
+
+func (s Scale) String() string {
+	return formatNumber(s.Min) + "-" + formatNumber(s.Max)
+}
+
+// UnmarshalYAML reads the scale from a range such as 1-5.
+func (s *Scale) UnmarshalYAML(value *yaml.Node) error {
+	var raw string
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	scale, err := ParseScale(raw)
+	if err != nil {
+		return err
+	}
+
+	*s = scale
+
+	return nil
+}
+
+// Criterion is a criterion the options of a matrix are scored against.
+type Criterion struct {
+	Name string `yaml:"name"`
+	// Weight is the importance of the criterion. It defaults to 1 when it
+	// is not set, while a weight of 0 leaves the criterion out of the totals.
+	Weight *float64 `yaml:"weight,omitempty"`
+}
+
+func (c Criterion) weight() float64 {
+	if c.Weight == nil {
+		return 1
+	}
+
+	return *c.Weight
+}
+
+// MatrixOption is an option of a matrix, along with its score on every
+// criterion, keyed by the name of the criterion.
+type MatrixOption struct {
+	Name   string             `yaml:"name"`
+	Scores map[string]float64 `yaml:"scores"`
+}
+
+// score returns the score of the option on the criterion, matching the
+// name of the criterion without regard to case.
+func (o MatrixOption) score(criterion string) (float64, bool) {
+	if v, ok := o.Scores[criterion]; ok {
+		return v, true
+	}
+
+	for name, v := range o.Scores {
+		if strings.EqualFold(name, criterion) {
+			return v, true
+		}
+	}
+
+	return 0, false
+}
+
+// Matrix is a weighted decision matrix, which scores the options of a
+// decision against a set of weighted criteria.
+type Matrix struct {
+	// Scale is the range of the scores. It defaults to DefaultScale.
+	Scale    Scale          `yaml:"scale"`
+	Criteria []Criterion    `yaml:"criteria"`
+	Options  []MatrixOption `yaml:"options"`
+}
+
+func (m Matrix) scale() Scale {
+	if m.Scale == (Scale{}) {
+		return DefaultScale
+	}
+
+	return m.Scale
+}
+
+// Validate checks that the criteria and options are named once, that the
+// weights are not negative and at least one of them is positive, and that
+// every option has a score within the scale on every criterion, and on
+// nothing else.
+func (m Matrix) Validate() error {
+	if len(m.Criteria) == 0 {
+		return fmt.Errorf("%w: no criteria", ErrInvalidMatrix)
+	}
+
+	if len(m.Options) == 0 {
+		return fmt.Errorf("%w: no options", ErrInvalidMatrix)
+	}
+
+	for i, c := range m.Criteria {
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("%w: criterion %d has no name", ErrInvalidMatrix, i+1)
+		}
+
+		if c.weight() < 0 {
+			return fmt.Errorf("%w: weight of %s is negative", ErrInvalidMatrix, c.Name)
+		}
+
+		for _, other := range m.Criteria[:i] {
+			if strings.EqualFold(other.Name, c.Name) {
+				return fmt.Errorf("%w: criterion %s is listed twice", ErrInvalidMatrix, c.Name)
+			}
+		}
+	}
+
+	if !m.weighted() {
+		return fmt.Errorf("%w: no criterion has a positive weight", ErrInvalidMatrix)
+	}
+
+	scale := m.scale()
+
+	for i, o := range m.Options {
+		if strings.TrimSpace(o.Name) == "" {
+			return fmt.Errorf("%w: option %d has no name", ErrInvalidMatrix, i+1)
+		}
+
+		for _, other := range m.Options[:i] {
+			if strings.EqualFold(other.Name, o.Name) {
+				return fmt.Errorf("%w: option %s is listed twice", ErrInvalidMatrix, o.Name)
+			}
+		}
+
+		for _, c := range m.Criteria {
+			v, ok := o.score(c.Name)
+
+			switch {
+			case !ok:
+				return fmt.Errorf("%w: %s has no score for %s", ErrInvalidMatrix, o.Name, c.Name)
+			case v < scale.Min || v > scale.Max:
+				return fmt.Errorf("%w: score %s of %s for %s is outside the scale %s",
+					ErrInvalidMatrix, formatNumber(v), o.Name, c.Name, scale)
+			}
+		}
+
+		names := make([]string, 0, len(o.Scores))
+		for name := range o.Scores {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if _, ok := m.criterion(name); !ok {
+				return fmt.Errorf("%w: %s is scored for unknown criterion %s", ErrInvalidMatrix, o.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// weighted reports whether any of the criteria counts towards the totals.
+func (m Matrix) weighted() bool {
+	for _, c := range m.Criteria {
+		if c.weight() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m Matrix) criterion(name string) (Criterion, bool) {
+	for _, c := range m.Criteria {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+
+	return Criterion{}, false
+}
+
+// Totals returns the weighted total of every option, in the order of the
+// options.
+func (m Matrix) Totals() []float64 {
+	totals := make([]float64, len(m.Options))
+
+	for i, o := range m.Options {
+		for _, c := range m.Criteria {
+			v, _ := o.score(c.Name)
+			totals[i] += c.weight() * v
+		}
+	}
+
+	return totals
+}
+
+// MaxTotal returns the weighted total of an option that has the highest
+// score of the scale on every criterion.
+func (m Matrix) MaxTotal() float64 {
+	var total float64
+
+	for _, c := range m.Criteria {
+		total += c.weight() * m.scale().Max
+	}
+
+	return total
+}
+
+// Table renders the matrix as a markdown table, with a criterion on every
+// row and an option in every column. The last row holds the weighted totals,
+// with the highest total in bold.
+func (m Matrix) Table() []string {
+	totals := m.Totals()
+
+	var best float64
+
+	for i, total := range totals {
+		if i == 0 || total > best {
+			best = total
+		}
+	}
+
+	header := []string{"Criterion", "Weight"}
+	for _, o := range m.Options {
+		header = append(header, o.Name)
+	}
+
+	rows := [][]string{header}
+
+	for _, c := range m.Criteria {
+		row := []string{c.Name, formatNumber(c.weight())}
+
+		for _, o := range m.Options {
+			v, _ := o.score(c.Name)
+			row = append(row, formatNumber(v))
+		}
+
+		rows = append(rows, row)
+	}
+
+	footer := []string{"**Total**", ""}
+
+	for _, total := range totals {
+		cell := formatNumber(total)
+		if total == best {
+			cell = "**" + cell + "**"
+		}
+
+		footer = append(footer, cell)
+	}
+
+	rows = append(rows, footer)
+
+	widths := make([]int, len(header))
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	format := func(row []string) string {
+		cells := make([]string, len(row))
+
+		for i, cell := range row {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+
+			if i == 0 {
+				cells[i] = cell + pad
+			} else {
+				cells[i] = pad + cell
+			}
+		}
+
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	rules := make([]string, len(widths))
+
+	for i, width := range widths {
+		if width < 3 {
+			widths[i] = 3
+		}
+
+		if i == 0 {
+			rules[i] = strings.Repeat("-", widths[i])
+		} else {
+			rules[i] = strings.Repeat("-", widths[i]-1) + ":"
+		}
+	}
+
+	lines := []string{format(rows[0]), "| " + strings.Join(rules, " | ") + " |"}
+
+	for _, row := range rows[1:] {
+		lines = append(lines, format(row))
+	}
+
+	return lines
+}
+
+// formatNumber formats a weight, score or total, rounded to two decimals.
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// matrixBlocks holds the location of the matrix within the lines of a
+// record, where a line index of -1 means the part is missing.
+type matrixBlocks struct {
+	fenceStart, fenceEnd int
+	tableStart, tableEnd int
+	scale                string
+}
+
+func findMatrix(lines []string) (matrixBlocks, error) {
+	b := matrixBlocks{fenceStart: -1, fenceEnd: -1, tableStart: -1, tableEnd: -1}
+
+	inFence := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case inFence && trimmed == "```":
+			inFence = false
+			b.fenceEnd = i
+		case inFence:
+		case strings.HasPrefix(trimmed, "```") && strings.Contains(trimmed, matrixFence):
+			if b.fenceStart >= 0 {
+				return b, fmt.Errorf("%w: more than one matrix block", ErrInvalidMatrix)
+			}
+
+			inFence = true
+			b.fenceStart = i
+		case matrixStart.MatchString(trimmed):
+			if b.tableStart >= 0 {
+				return b, fmt.Errorf("%w: more than one matrix table", ErrInvalidMatrix)
+			}
+
+			b.tableStart = i
+			b.scale = matrixStart.FindStringSubmatch(trimmed)[1]
+		case trimmed == matrixEnd && b.tableStart >= 0 && b.tableEnd < 0:
+			b.tableEnd = i
+		}
+	}
+
+	switch {
+	case inFence:
+		return b, fmt.Errorf("%w: unterminated matrix block", ErrInvalidMatrix)
+	case b.tableStart >= 0 && b.tableEnd < 0:
+		return b, fmt.Errorf("%w: unterminated matrix table", ErrInvalidMatrix)
+	case b.fenceStart < 0 && b.tableStart < 0:
+		return b, ErrNoMatrix
+	}
+
+	return b, nil
+}
+
+// ParseMatrix reads the decision matrix of the record markdown. The matrix
+// is read from a fenced YAML code block marked with docula:matrix if the
+// record has one. Otherwise it is read from a markdown table surrounded by
+// docula:matrix comments, where the opening comment may declare the scale,
+// such as <!-- docula:matrix scale=1-10 -->.
+func ParseMatrix(data []byte) (Matrix, error) {
+	_, body, _ := splitFrontmatter(data)
+	lines := strings.Split(string(body), "\n")
+
+	b, err := findMatrix(lines)
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	if b.fenceStart >= 0 {
+		var m Matrix
+
+		block := strings.Join(lines[b.fenceStart+1:b.fenceEnd], "\n")
+
+		if err := yaml.Unmarshal([]byte(block), &m); err != nil {
+			if errors.Is(err, ErrInvalidMatrix) {
+				return Matrix{}, err
+			}
+
+			return Matrix{}, fmt.Errorf("%w: %s", ErrInvalidMatrix, err)
+		}
+
+		return m, nil
+	}
+
+	m, err := parseTable(lines[b.tableStart+1 : b.tableEnd])
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	if b.scale != "" {
+		if m.Scale, err = ParseScale(b.scale); err != nil {
+			return Matrix{}, err
+		}
+	}
+
+	return m, nil
+}
+
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+
+	return cells
+}
+
+func isRule(cells []string) bool {
+	for _, cell := range cells {
+		if !separator.MatchString(cell) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseTable reads a matrix from the rows of a markdown table. The first
+// column holds the criteria and the second their weights, followed by a
+// column for every option. A row of totals is left out.
+func parseTable(lines []string) (Matrix, error) {
+	var (
+		m      Matrix
+		header []string
+	)
+
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "|") {
+			continue
+		}
+
+		cells := splitRow(line)
+
+		switch {
+		case header == nil:
+			if len(cells) < 3 || !strings.EqualFold(cells[1], "weight") {
+				return Matrix{}, fmt.Errorf("%w: the table needs a criterion, a weight and an option column", ErrInvalidMatrix)
+			}
+
+			header = cells
+
+			for _, name := range cells[2:] {
+				m.Options = append(m.Options, MatrixOption{Name: strings.Trim(name, "*"), Scores: map[string]float64{}})
+			}
+
+			continue
+		case isRule(cells):
+			continue
+		case len(cells) != len(header):
+			return Matrix{}, fmt.Errorf("%w: row %q has %d cells instead of %d", ErrInvalidMatrix, cells[0], len(cells), len(header))
+		case strings.EqualFold(strings.Trim(cells[0], "*"), "total"):
+			continue
+		}
+
+		c := Criterion{Name: strings.Trim(cells[0], "*")}
+
+		if cells[1] != "" {
+			weight, err := strconv.ParseFloat(cells[1], 64)
+			if err != nil {
+				return Matrix{}, fmt.Errorf("%w: weight %q of %s is not a number", ErrInvalidMatrix, cells[1], c.Name)
+			}
+
+			c.Weight = &weight
+		}
+
+		m.Criteria = append(m.Criteria, c)
+
+		for i, cell := range cells[2:] {
+			cell = strings.Trim(cell, "*")
+			if cell == "" {
+				continue
+			}
+
+			v, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return Matrix{}, fmt.Errorf("%w: score %q of %s for %s is not a number",
+					ErrInvalidMatrix, cell, m.Options[i].Name, c.Name)
+			}
+
+			m.Options[i].Scores[c.Name] = v
+		}
+	}
+
+	if header == nil {
+		return Matrix{}, fmt.Errorf("%w: the matrix table is empty", ErrInvalidMatrix)
+	}
+
+	return m, nil
+}
+
+// RenderMatrix writes the matrix into the record markdown as a normalized
+// table surrounded by docula:matrix comments. An existing table is replaced,
+// and otherwise the table is added below the YAML block of the matrix.
+func RenderMatrix(data []byte, m Matrix) ([]byte, error) {
+	front, body, hasFront := splitFrontmatter(data)
+	lines := strings.Split(string(body), "\n")
+
+	b, err := findMatrix(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	table := append([]string{fmt.Sprintf("<!-- %s scale=%s -->", matrixFence, m.scale())}, m.Table()...)
+	table = append(table, matrixEnd)
+
+	if b.tableStart >= 0 {
+		rest := append(table, lines[b.tableEnd+1:]...)
+		lines = append(lines[:b.tableStart], rest...)
+	} else {
+		if b.fenceEnd+1 < len(lines) && strings.TrimSpace(lines[b.fenceEnd+1]) != "" {
+			table = append(table, "")
+		}
+
+		lines = insert(lines, b.fenceEnd+1, append([]string{""}, table...)...)
+	}
+
+	if hasFront {
+		return joinFrontmatter(front, join(lines)), nil
+	}
+
+	return join(lines), nil
+}
@@ -0,0 +1,299 @@
+package adr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func weight(w float64) *float64 {
+	return &w
+}
+
+func TestParseMatrix(t *testing.T) {
+	type want struct {
+		matrix adr.Matrix
+		err    error
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		wants want
+	}{
+		{
+			name: "yaml block",
+			input: "# Use PostgreSQL\n\n## Options\n\n" +
+				"```yaml docula:matrix\n" +
+				"scale: 0-10\n" +
+				"criteria:\n" +
+				"  - {name: Cost, weight: 3}\n" +
+				"  - {name: Operations}\n" +
+				"options:\n" +
+				"  - name: PostgreSQL\n" +
+				"    scores: {cost: 8, Operations: 7}\n" +
+				"```\n",
+			wants: want{
+				matrix: adr.Matrix{
+					Scale:    adr.Scale{Min: 0, Max: 10},
+					Criteria: []adr.Criterion{{Name: "Cost", Weight: weight(3)}, {Name: "Operations"}},
+					Options: []adr.MatrixOption{
+						{Name: "PostgreSQL", Scores: map[string]float64{"cost": 8, "Operations": 7}},
+					},
+				},
+			},
+		},
+		{
+			name: "marked table",
+			input: "---\nstatus: Proposed\n---\n# Use PostgreSQL\n\n" +
+				"<!-- docula:matrix scale=1..5 -->\n" +
+				"| Criterion | Weight | PostgreSQL | **MySQL** |\n" +
+				"|---|---|---|---|\n" +
+				"| Cost | 3 | 4 | 5 |\n" +
+				"| Operations | | 4.5 | |\n" +
+				"| **Total** | | **16.5** | 15 |\n" +
+				"<!-- /docula:matrix -->\n",
+			wants: want{
+				matrix: adr.Matrix{
+					Scale:    adr.Scale{Min: 1, Max: 5},
+					Criteria: []adr.Criterion{{Name: "Cost", Weight: weight(3)}, {Name: "Operations"}},
+					Options: []adr.MatrixOption{
+						{Name: "PostgreSQL", Scores: map[string]float64{"Cost": 4, "Operations": 4.5}},
+						{Name: "MySQL", Scores: map[string]float64{"Cost": 5}},
+					},
+				},
+			},
+		},
+		{
+			name:  "no matrix",
+			input: "# Use PostgreSQL\n\n```yaml docula:imports\n- from: a\n```\n",
+			wants: want{err: adr.ErrNoMatrix},
+		},
+		{
+			name:  "invalid scale",
+			input: "# Use PostgreSQL\n\n```yaml docula:matrix\nscale: high\n```\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name:  "invalid yaml",
+			input: "# Use PostgreSQL\n\n```yaml docula:matrix\ncriteria: [\n```\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name:  "unterminated table",
+			input: "# Use PostgreSQL\n\n<!-- docula:matrix -->\n| Criterion | Weight | A |\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name:  "table without weights",
+			input: "<!-- docula:matrix -->\n| Criterion | A | B |\n<!-- /docula:matrix -->\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name:  "score that is not a number",
+			input: "<!-- docula:matrix -->\n| Criterion | Weight | A |\n| Cost | 1 | high |\n<!-- /docula:matrix -->\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name:  "row with missing cells",
+			input: "<!-- docula:matrix -->\n| Criterion | Weight | A | B |\n| Cost | 1 | 2 |\n<!-- /docula:matrix -->\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+		{
+			name: "two matrices",
+			input: "<!-- docula:matrix -->\n| Criterion | Weight | A |\n<!-- /docula:matrix -->\n" +
+				"<!-- docula:matrix -->\n| Criterion | Weight | B |\n<!-- /docula:matrix -->\n",
+			wants: want{err: adr.ErrInvalidMatrix},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := adr.ParseMatrix([]byte(tt.input))
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants.matrix, m)
+		})
+	}
+}
+
+func TestMatrixValidate(t *testing.T) {
+	valid := func() adr.Matrix {
+		return adr.Matrix{
+			Criteria: []adr.Criterion{{Name: "Cost", Weight: weight(3)}, {Name: "Operations"}},
+			Options: []adr.MatrixOption{
+				{Name: "PostgreSQL", Scores: map[string]float64{"Cost": 4, "operations": 5}},
+				{Name: "MySQL", Scores: map[string]float64{"Cost": 5, "Operations": 3}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(m *adr.Matrix)
+		wants  string
+	}{
+		{name: "valid", modify: func(m *adr.Matrix) {}},
+		{name: "no criteria", modify: func(m *adr.Matrix) { m.Criteria = nil }, wants: "no criteria"},
+		{name: "no options", modify: func(m *adr.Matrix) { m.Options = nil }, wants: "no options"},
+		{
+			name:   "negative weight",
+			modify: func(m *adr.Matrix) { m.Criteria[1].Weight = weight(-1) },
+			wants:  "weight of Operations is negative",
+		},
+		{
+			name: "no positive weight",
+			modify: func(m *adr.Matrix) {
+				m.Criteria[0].Weight = weight(0)
+				m.Criteria[1].Weight = weight(0)
+			},
+			wants: "no criterion has a positive weight",
+		},
+		{
+			name:   "duplicate criterion",
+			modify: func(m *adr.Matrix) { m.Criteria[1].Name = "cost" },
+			wants:  "criterion cost is listed twice",
+		},
+		{
+			name:   "duplicate option",
+			modify: func(m *adr.Matrix) { m.Options[1].Name = "postgresql" },
+			wants:  "option postgresql is listed twice",
+		},
+		{
+			name:   "missing score",
+			modify: func(m *adr.Matrix) { delete(m.Options[1].Scores, "Operations") },
+			wants:  "MySQL has no score for Operations",
+		},
+		{
+			name:   "score outside the default scale",
+			modify: func(m *adr.Matrix) { m.Options[0].Scores["Cost"] = 7 },
+			wants:  "score 7 of PostgreSQL for Cost is outside the scale 1-5",
+		},
+		{
+			name: "score within a declared scale",
+			modify: func(m *adr.Matrix) {
+				m.Scale = adr.Scale{Min: 0, Max: 10}
+				m.Options[0].Scores["Cost"] = 7
+			},
+		},
+		{
+			name:   "unknown criterion",
+			modify: func(m *adr.Matrix) { m.Options[1].Scores["Speed"] = 2 },
+			wants:  "MySQL is scored for unknown criterion Speed",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(&m)
+
+			err := m.Validate()
+
+			if tt.wants == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, adr.ErrInvalidMatrix)
+			assert.Contains(t, err.Error(), tt.wants)
+		})
+	}
+}
+
+func TestRenderMatrix(t *testing.T) {
+	input := "# Use PostgreSQL\n" +
+		"\n" +
+		"```yaml docula:matrix\n" +
+		"criteria:\n" +
+		"  - {name: Cost, weight: 3}\n" +
+		"  - {name: Operations, weight: 1.5}\n" +
+		"options:\n" +
+		"  - {name: PostgreSQL, scores: {Cost: 4, Operations: 5}}\n" +
+		"  - {name: SQLite, scores: {Cost: 5, Operations: 2}}\n" +
+		"```\n" +
+		"## Decision\n"
+
+	wants := "# Use PostgreSQL\n" +
+		"\n" +
+		"```yaml docula:matrix\n" +
+		"criteria:\n" +
+		"  - {name: Cost, weight: 3}\n" +
+		"  - {name: Operations, weight: 1.5}\n" +
+		"options:\n" +
+		"  - {name: PostgreSQL, scores: {Cost: 4, Operations: 5}}\n" +
+		"  - {name: SQLite, scores: {Cost: 5, Operations: 2}}\n" +
+		"```\n" +
+		"\n" +
+		"<!-- docula:matrix scale=1-5 -->\n" +
+		"| Criterion  | Weight | PostgreSQL | SQLite |\n" +
+		"| ---------- | -----: | ---------: | -----: |\n" +
+		"| Cost       |      3 |          4 |      5 |\n" +
+		"| Operations |    1.5 |          5 |      2 |\n" +
+		"| **Total**  |        |   **19.5** |     18 |\n" +
+		"<!-- /docula:matrix -->\n" +
+		"\n" +
+		"## Decision\n"
+
+	m, err := adr.ParseMatrix([]byte(input))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, []float64{19.5, 18}, m.Totals())
+	assert.Equal(t, 22.5, m.MaxTotal())
+
+	res, err := adr.RenderMatrix([]byte(input), m)
+	assert.NoError(t, err)
+	assert.Equal(t, wants, string(res))
+
+	again, err := adr.RenderMatrix(res, m)
+	assert.NoError(t, err)
+	assert.Equal(t, wants, string(again))
+
+	fromTable, err := adr.ParseMatrix([]byte(wants[strings.Index(wants, "<!-- docula:matrix"):]))
+	assert.NoError(t, err)
+	assert.Equal(t, m.Totals(), fromTable.Totals())
+}
+
+func TestMatrixZeroWeight(t *testing.T) {
+	input := "```yaml docula:matrix\n" +
+		"criteria:\n" +
+		"  - {name: Cost, weight: 0}\n" +
+		"  - {name: Operations, weight: 2}\n" +
+		"  - {name: Community}\n" +
+		"options:\n" +
+		"  - {name: K8s, scores: {Cost: 2, Operations: 3, Community: 4}}\n" +
+		"  - {name: Nomad, scores: {Cost: 5, Operations: 2, Community: 2}}\n" +
+		"```\n"
+
+	m, err := adr.ParseMatrix([]byte(input))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, []adr.Criterion{
+		{Name: "Cost", Weight: weight(0)},
+		{Name: "Operations", Weight: weight(2)},
+		{Name: "Community"},
+	}, m.Criteria)
+	assert.Equal(t, []float64{10, 6}, m.Totals())
+	assert.Equal(t, 15.0, m.MaxTotal())
+
+	res, err := adr.RenderMatrix([]byte(input), m)
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), "| Cost       |      0 |")
+
+	fromTable, err := adr.ParseMatrix(res[strings.Index(string(res), "<!-- docula:matrix"):])
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{10, 6}, fromTable.Totals())
+	assert.Equal(t, weight(0), fromTable.Criteria[0].Weight)
+}
@@ -0,0 +1,202 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The policies a record can declare for a Go module.
+const (
+	// PolicyAllowed approves the module, optionally only in the versions
+	// of its constraint.
+	PolicyAllowed = "allowed"
+	// PolicyBanned forbids the module, or only the versions of its
+	// constraint if it has one.
+	PolicyBanned = "banned"
+	// PolicyPreferred approves the module over the modules it replaces.
+	PolicyPreferred = "preferred"
+)
+
+var (
+	// ErrInvalidModulePolicy is returned when the module policies of a
+	// record cannot be read, or a policy is incomplete.
+	ErrInvalidModulePolicy = errors.New("invalid module policy")
+
+	// ErrInvalidVersion is returned when a version or version constraint
+	// cannot be parsed.
+	ErrInvalidVersion = errors.New("invalid version")
+)
+
+// ModulePolicy governs the use of a Go module. Modules are given as module
+// paths, and may use the "..." wildcard of the go command.
+type ModulePolicy struct {
+	Module string `yaml:"module"`
+	Policy string `yaml:"policy"`
+	// Version constrains the versions the policy applies to, as comma
+	// separated comparisons such as ">= v1.2.0, < v2".
+	Version string `yaml:"version,omitempty"`
+	// Instead names the module to use in place of a banned module.
+	Instead string `yaml:"instead,omitempty"`
+	// Over lists the modules that a preferred module replaces.
+	Over []string `yaml:"over,omitempty"`
+}
+
+// Validate checks that the policy names a module and a known policy, and
+// that its version constraint can be parsed.
+func (p ModulePolicy) Validate() error {
+	if strings.TrimSpace(p.Module) == "" {
+		return fmt.Errorf("%w: missing module", ErrInvalidModulePolicy)
+	}
+
+	switch p.Policy {
+	case PolicyAllowed, PolicyBanned, PolicyPreferred:
+	default:
+		return fmt.Errorf("%w: unknown policy %q for %s", ErrInvalidModulePolicy, p.Policy, p.Module)
+	}
+
+	if _, err := p.Matches("v0.0.0"); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidModulePolicy, err)
+	}
+
+	return nil
+}
+
+// Matches reports whether the version satisfies the version constraint of
+// the policy. Every version satisfies an empty constraint.
+func (p ModulePolicy) Matches(version string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, comparison := range strings.Split(p.Version, ",") {
+		comparison = strings.TrimSpace(comparison)
+		if comparison == "" {
+			continue
+		}
+
+		op := comparison[:len(comparison)-len(strings.TrimLeft(comparison, "<>=!"))]
+
+		bound, err := parseVersion(strings.TrimSpace(comparison[len(op):]))
+		if err != nil {
+			return false, err
+		}
+
+		c := compareVersions(v, bound)
+
+		var ok bool
+
+		switch op {
+		case "", "=", "==":
+			ok = c == 0
+		case "!=":
+			ok = c != 0
+		case "<":
+			ok = c < 0
+		case "<=":
+			ok = c <= 0
+		case ">":
+			ok = c > 0
+		case ">=":
+			ok = c >= 0
+		default:
+			return false, fmt.Errorf("%w: unknown comparison %q", ErrInvalidVersion, comparison)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// version represents a semantic version, such as v1.2.3-rc.1. Build
+// metadata, such as +incompatible, is dropped.
+type version struct {
+	numbers    [3]int
+	prerelease []string
+}
+
+// parseVersion parses a semantic version. The leading "v" is optional, and
+// missing minor and patch numbers are zero, so "v2" is v2.0.0.
+func parseVersion(value string) (version, error) {
+	var v version
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	value, _, _ = strings.Cut(value, "+")
+
+	core, pre, hasPre := strings.Cut(value, "-")
+
+	parts := strings.Split(core, ".")
+	if core == "" || len(parts) > 3 {
+		return version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, value)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, value)
+		}
+
+		v.numbers[i] = n
+	}
+
+	if hasPre {
+		v.prerelease = strings.Split(pre, ".")
+	}
+
+	return v, nil
+}
+
+// compareVersions orders versions by semantic versioning precedence, where
+// a prerelease, including a pseudo-version, comes before its release.
+func compareVersions(a, b version) int {
+	for i := range a.numbers {
+		if a.numbers[i] != b.numbers[i] {
+			return sign(a.numbers[i] - b.numbers[i])
+		}
+	}
+
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		x, errX := strconv.Atoi(a.prerelease[i])
+		y, errY := strconv.Atoi(b.prerelease[i])
+
+		switch {
+		case errX == nil && errY == nil:
+			if x != y {
+				return sign(x - y)
+			}
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		case a.prerelease[i] != b.prerelease[i]:
+			return strings.Compare(a.prerelease[i], b.prerelease[i])
+		}
+	}
+
+	return sign(len(a.prerelease) - len(b.prerelease))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,91 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestModulePolicyMatches(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		version    string
+		wants      bool
+		err        error
+	}{
+		{constraint: "", version: "v1.2.3", wants: true},
+		{constraint: "v1.2.3", version: "v1.2.3", wants: true},
+		{constraint: "= v1.2", version: "v1.2.0", wants: true},
+		{constraint: ">= v1.2.0, < v2", version: "v1.9.9", wants: true},
+		{constraint: ">= v1.2.0, < v2", version: "v2.0.0", wants: false},
+		{constraint: ">= v1.2.0, < v2", version: "v1.1.0", wants: false},
+		{constraint: "< v1.10.0", version: "v1.10.0-rc.1", wants: true},
+		{constraint: "> v0.0.0", version: "v0.0.0-20221010120000-abcdef123456", wants: false},
+		{constraint: "< 1.0.0-beta", version: "v1.0.0-alpha.1", wants: true},
+		{constraint: "< 1.0.0-alpha.2", version: "v1.0.0-alpha.10", wants: false},
+		{constraint: "!= v5.0.0", version: "v5.0.0+incompatible", wants: false},
+		{constraint: "~> v1", version: "v1.0.0", err: adr.ErrInvalidVersion},
+		{constraint: ">= latest", version: "v1.0.0", err: adr.ErrInvalidVersion},
+		{constraint: "", version: "main", err: adr.ErrInvalidVersion},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			ok, err := adr.ModulePolicy{Version: tt.constraint}.Matches(tt.version)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, ok)
+		})
+	}
+}
+
+func TestModulePolicyValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy adr.ModulePolicy
+		wants  error
+	}{
+		{
+			name:   "valid",
+			policy: adr.ModulePolicy{Module: "github.com/lib/pq", Policy: adr.PolicyBanned, Instead: "github.com/jackc/pgx/v5"},
+		},
+		{
+			name:   "missing module",
+			policy: adr.ModulePolicy{Policy: adr.PolicyAllowed},
+			wants:  adr.ErrInvalidModulePolicy,
+		},
+		{
+			name:   "unknown policy",
+			policy: adr.ModulePolicy{Module: "github.com/lib/pq", Policy: "tolerated"},
+			wants:  adr.ErrInvalidModulePolicy,
+		},
+		{
+			name:   "invalid constraint",
+			policy: adr.ModulePolicy{Module: "github.com/lib/pq", Policy: adr.PolicyAllowed, Version: ">= one"},
+			wants:  adr.ErrInvalidModulePolicy,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package adr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plural returns the count followed by the noun, in its plural form unless
+// the count is one, such as "1 entry" or "2 entries". Nouns ending in a
+// consonant and a y, such as "policy", take ies in the plural.
+func Plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	if l := len(noun); l > 1 && noun[l-1] == 'y' && !strings.ContainsRune("aeiou", rune(noun[l-2])) {
+		return fmt.Sprintf("%d %sies", n, noun[:l-1])
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
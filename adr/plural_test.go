@@ -0,0 +1,17 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestPlural(t *testing.T) {
+	assert.Equal(t, "1 entry", adr.Plural(1, "entry"))
+	assert.Equal(t, "2 entries", adr.Plural(2, "entry"))
+	assert.Equal(t, "0 module policies", adr.Plural(0, "module policy"))
+	assert.Equal(t, "3 days", adr.Plural(3, "day"))
+	assert.Equal(t, "2 errors", adr.Plural(2, "error"))
+}
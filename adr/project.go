@@ -0,0 +1,16 @@
+package adr
+
+// skippedDirs hold third party code or test data, in the way of the go
+// command.
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"testdata":     true,
+	"node_modules": true,
+}
+
+// SkipDir reports whether the commands that walk the project skip the dir
+// of the given name wherever it appears, as it holds third party code or
+// test data rather than code of the project.
+func SkipDir(name string) bool {
+	return skippedDirs[name]
+}
@@ -0,0 +1,17 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestSkipDir(t *testing.T) {
+	assert.True(t, adr.SkipDir("vendor"))
+	assert.True(t, adr.SkipDir("testdata"))
+	assert.True(t, adr.SkipDir("node_modules"))
+	assert.False(t, adr.SkipDir("internal"))
+	assert.False(t, adr.SkipDir("vendored"))
+}
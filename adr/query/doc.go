@@ -0,0 +1,9 @@
+// Package query provides a small expression language for selecting decision
+// records by their metadata, such as
+//
+//	status = accepted and tag in (db, storage) and date > today - 90d
+//
+// Comparisons are combined with and, or, not and parentheses. Dates can be
+// written as YYYY-MM-DD or today, optionally shifted by a number of days,
+// weeks, months or years.
+package query
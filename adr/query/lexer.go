@@ -0,0 +1,104 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+
+	return fmt.Sprintf("%q at %d", t.value, t.pos+1)
+}
+
+// is reports whether the token is the given keyword, ignoring case. Quoted
+// strings are never keywords.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()=!<>,'"`, r)
+}
+
+// lex splits the expression into tokens. Words run until whitespace or an
+// operator, so dates and durations such as 2025-01-01 and -90d are single
+// words.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: i})
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", ErrSyntax, i+1)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: string(runes[i+1 : end]), pos: i})
+			i = end + 1
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+
+			if op == "!" {
+				return nil, fmt.Errorf("%w: unexpected \"!\" at %d", ErrSyntax, i+1)
+			}
+
+			tokens = append(tokens, token{kind: tokenOp, value: op, pos: i})
+			i += len(op)
+		default:
+			end := i
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[i:end]), pos: i})
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
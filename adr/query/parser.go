@@ -0,0 +1,224 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parser is a recursive descent parser over the tokens of a query. The
+// operators bind, from loosest to tightest, as or, and, not, comparison.
+type parser struct {
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = or{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		p.next()
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = and{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	switch t := p.peek(); {
+	case t.is("not"):
+		p.next()
+
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return not{node: n}, nil
+	case t.kind == tokenLParen:
+		p.next()
+
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("%w: expected \")\", found %s", ErrSyntax, t)
+		}
+
+		return n, nil
+	}
+
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	field := p.next()
+	if field.kind != tokenWord && field.kind != tokenString {
+		return nil, fmt.Errorf("%w: expected a field, found %s", ErrSyntax, field)
+	}
+
+	c := comparison{field: strings.ToLower(field.value)}
+
+	op := p.next()
+
+	negate := op.is("not")
+	if negate {
+		if op = p.next(); !op.is("in") && !op.is("contains") {
+			return nil, fmt.Errorf("%w: expected \"in\" or \"contains\", found %s", ErrSyntax, op)
+		}
+	}
+
+	switch {
+	case op.kind == tokenOp:
+		c.op = op.value
+		if c.op == "==" {
+			c.op = "="
+		}
+	case op.is("in"), op.is("contains"):
+		c.op = strings.ToLower(op.value)
+	default:
+		return nil, fmt.Errorf("%w: expected an operator, found %s", ErrSyntax, op)
+	}
+
+	var err error
+
+	if c.op == "in" {
+		err = p.list(&c)
+	} else {
+		err = p.value(&c)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if negate {
+		return not{node: c}, nil
+	}
+
+	return c, nil
+}
+
+// list reads the parenthesised, comma separated values of an in comparison.
+func (p *parser) list(c *comparison) error {
+	if t := p.next(); t.kind != tokenLParen {
+		return fmt.Errorf("%w: expected \"(\", found %s", ErrSyntax, t)
+	}
+
+	for {
+		if err := p.value(c); err != nil {
+			return err
+		}
+
+		switch t := p.next(); t.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return nil
+		default:
+			return fmt.Errorf("%w: expected \",\" or \")\", found %s", ErrSyntax, t)
+		}
+	}
+}
+
+// value reads a single value of the comparison. Values compared with the
+// date are date expressions, which may be split over several words, as in
+// today - 90d.
+func (p *parser) value(c *comparison) error {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return fmt.Errorf("%w: expected a value, found %s", ErrSyntax, t)
+	}
+
+	if c.field != FieldDate {
+		c.values = append(c.values, t.value)
+		return nil
+	}
+
+	if c.op == "contains" {
+		return fmt.Errorf("%w: %s cannot be used with %s", ErrSyntax, c.op, c.field)
+	}
+
+	expr := t.value
+
+	for {
+		next := p.peek()
+		if next.kind != tokenWord {
+			break
+		}
+
+		if next.value == "+" || next.value == "-" {
+			p.next()
+
+			amount := p.next()
+			if amount.kind != tokenWord {
+				return fmt.Errorf("%w: expected a duration, found %s", ErrSyntax, amount)
+			}
+
+			expr += next.value + amount.value
+
+			continue
+		}
+
+		if !strings.HasPrefix(next.value, "+") && !strings.HasPrefix(next.value, "-") {
+			break
+		}
+
+		expr += p.next().value
+	}
+
+	date, err := parseDate(expr, p.now)
+	if err != nil {
+		return err
+	}
+
+	c.dates = append(c.dates, date)
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// The fields of a record that a query can refer to. Any other field name is
+// looked up in the custom fields of the record, ignoring case.
+const (
+	FieldID        = "id"
+	FieldTitle     = "title"
+	FieldStatus    = "status"
+	FieldDate      = "date"
+	FieldTag       = "tag"
+	FieldDecider   = "decider"
+	FieldConsulted = "consulted"
+	FieldInformed  = "informed"
+	FieldDir       = "dir"
+	FieldPath      = "path"
+	FieldStyle     = "style"
+	fieldTags      = "tags"
+	fieldDeciders  = "deciders"
+)
+
+var (
+	// ErrSyntax is returned when a query cannot be parsed.
+	ErrSyntax = errors.New("invalid query")
+
+	// ErrInvalidDate is returned when a date field is compared with a value
+	// that is not a date.
+	ErrInvalidDate = errors.New("invalid date")
+)
+
+var (
+	dateExpr  = regexp.MustCompile(`^(?i)(today|\d{4}-\d{2}-\d{2})((?:[+-]\d+[dwmy])*)$`)
+	dateShift = regexp.MustCompile(`(?i)([+-])(\d+)([dwmy])`)
+)
+
+// Query represents a parsed query expression.
+type Query struct {
+	root node
+}
+
+// Match reports whether the record is selected by the query.
+func (q Query) Match(rec adr.Record) bool {
+	return q.root.match(rec)
+}
+
+// Parse parses the query expression. Relative dates, such as today - 30d,
+// are resolved against the given time.
+func Parse(expr string, now time.Time) (Query, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return Query{}, err
+	}
+
+	p := &parser{tokens: tokens, now: now}
+
+	root, err := p.or()
+	if err != nil {
+		return Query{}, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return Query{}, fmt.Errorf("%w: unexpected %s", ErrSyntax, t)
+	}
+
+	return Query{root: root}, nil
+}
+
+type node interface {
+	match(rec adr.Record) bool
+}
+
+type and struct{ left, right node }
+
+func (n and) match(rec adr.Record) bool { return n.left.match(rec) && n.right.match(rec) }
+
+type or struct{ left, right node }
+
+func (n or) match(rec adr.Record) bool { return n.left.match(rec) || n.right.match(rec) }
+
+type not struct{ node node }
+
+func (n not) match(rec adr.Record) bool { return !n.node.match(rec) }
+
+// comparison compares a field of the record with one or more values. Fields
+// holding several values, such as tags, match if any of them match.
+type comparison struct {
+	field  string
+	op     string
+	values []string
+	dates  []time.Time
+}
+
+func (c comparison) match(rec adr.Record) bool {
+	if c.dates != nil {
+		return c.matchDate(rec.Date)
+	}
+
+	fieldValues := lookup(rec, c.field)
+
+	if c.op == "!=" {
+		return !comparison{field: c.field, op: "=", values: c.values}.match(rec)
+	}
+
+	for _, have := range fieldValues {
+		for _, want := range c.values {
+			if c.matchValue(have, want) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c comparison) matchValue(have string, want string) bool {
+	switch c.op {
+	case "=", "in":
+		switch c.field {
+		case FieldID:
+			return adr.SameID(have, want)
+		case FieldStatus:
+			return adr.Record{Status: have}.HasStatus(want)
+		}
+
+		return strings.EqualFold(have, want)
+	case "contains":
+		return strings.Contains(strings.ToLower(have), strings.ToLower(want))
+	}
+
+	return ordered(c.op, compare(have, want))
+}
+
+func (c comparison) matchDate(date time.Time) bool {
+	if date.IsZero() {
+		return c.op == "!="
+	}
+
+	for _, want := range c.dates {
+		cmp := 0
+
+		switch {
+		case date.Before(want):
+			cmp = -1
+		case date.After(want):
+			cmp = 1
+		}
+
+		switch c.op {
+		case "=", "in":
+			if cmp == 0 {
+				return true
+			}
+		case "!=":
+			if cmp == 0 {
+				return false
+			}
+		default:
+			if ordered(c.op, cmp) {
+				return true
+			}
+		}
+	}
+
+	return c.op == "!="
+}
+
+func ordered(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// compare orders two values numerically if both are numbers, and otherwise
+// alphabetically, ignoring case.
+func compare(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+
+	if errX != nil || errY != nil {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+// lookup returns the values of the field of the record.
+func lookup(rec adr.Record, field string) []string {
+	switch field {
+	case FieldID:
+		return []string{rec.ID}
+	case FieldTitle:
+		return []string{rec.Title}
+	case FieldStatus:
+		return []string{rec.Status}
+	case FieldTag, fieldTags:
+		return rec.Tags
+	case FieldDecider, fieldDeciders:
+		return rec.Deciders
+	case FieldConsulted:
+		return rec.Consulted
+	case FieldInformed:
+		return rec.Informed
+	case FieldDir:
+		return []string{rec.Dir}
+	case FieldPath:
+		return []string{rec.Path}
+	case FieldStyle:
+		return []string{rec.Style}
+	}
+
+	for key, value := range rec.Fields {
+		if strings.EqualFold(key, field) {
+			return []string{value}
+		}
+	}
+
+	return nil
+}
+
+// parseDate resolves a date expression, which is a date or today followed by
+// any number of shifts such as -90d, +2w, -6m or +1y.
+func parseDate(value string, now time.Time) (time.Time, error) {
+	match := dateExpr.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDate, value)
+	}
+
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if !strings.EqualFold(match[1], "today") {
+		var err error
+
+		if date, err = time.Parse(adr.DateFormat, match[1]); err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDate, value)
+		}
+	}
+
+	for _, shift := range dateShift.FindAllStringSubmatch(match[2], -1) {
+		n, _ := strconv.Atoi(shift[2])
+		if shift[1] == "-" {
+			n = -n
+		}
+
+		switch strings.ToLower(shift[3]) {
+		case "d":
+			date = date.AddDate(0, 0, n)
+		case "w":
+			date = date.AddDate(0, 0, 7*n)
+		case "m":
+			date = date.AddDate(0, n, 0)
+		case "y":
+			date = date.AddDate(n, 0, 0)
+		}
+	}
+
+	return date, nil
+}
@@ -0,0 +1,106 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/query"
+)
+
+var (
+	now = time.Date(2025, 6, 15, 13, 30, 0, 0, time.UTC)
+
+	records = []adr.Record{
+		{
+			ID:       "0001",
+			Title:    "Use PostgreSQL",
+			Status:   "Accepted",
+			Date:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Tags:     []string{"db", "storage"},
+			Deciders: []string{"Jane Doe"},
+			Dir:      "platform",
+			Fields:   map[string]string{"Budget": "1200"},
+		},
+		{
+			ID:     "0002",
+			Title:  "Use Redis for caching",
+			Status: "Superseded by [0003. Use Memcached](0003-use-memcached.md)",
+			Date:   time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC),
+			Tags:   []string{"cache"},
+			Dir:    "platform",
+		},
+		{
+			ID:        "0012",
+			Title:     "Add mTLS",
+			Status:    "Proposed",
+			Consulted: []string{"@platform"},
+			Informed:  []string{"@sre", "@platform"},
+			Dir:       "security",
+			Fields:    map[string]string{"Budget": "300"},
+		},
+	}
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		expr  string
+		wants []string
+		err   error
+	}{
+		{expr: "status = accepted", wants: []string{"0001"}},
+		{expr: "status = superseded", wants: []string{"0002"}},
+		{expr: "status != accepted", wants: []string{"0002", "0012"}},
+		{expr: "status = accepted and tag in (db, storage) and date > 2025-01-01", wants: []string{"0001"}},
+		{expr: "tag in (cache, db)", wants: []string{"0001", "0002"}},
+		{expr: "tags not in (cache, db)", wants: []string{"0012"}},
+		{expr: "title contains 'redis' or dir = security", wants: []string{"0002", "0012"}},
+		{expr: "not (title contains use)", wants: []string{"0012"}},
+		{expr: "status contains memcached", wants: []string{"0002"}},
+		{expr: "id = 12", wants: []string{"0012"}},
+		{expr: "id >= 2", wants: []string{"0002", "0012"}},
+		{expr: "date > today - 120d", wants: []string{"0001"}},
+		{expr: "date >= today-1y", wants: []string{"0001", "0002"}},
+		{expr: "date < 2025-03-01 + 1m -1w", wants: []string{"0001", "0002"}},
+		{expr: "date != 2025-03-01", wants: []string{"0002", "0012"}},
+		{expr: "budget > 500", wants: []string{"0001"}},
+		{expr: `"Budget" = 300 or decider = 'jane doe'`, wants: []string{"0001", "0012"}},
+		{expr: "consulted = @platform or informed = @sre", wants: []string{"0012"}},
+		{expr: "status = accepted or status = proposed and dir = platform", wants: []string{"0001"}},
+		{expr: "status = accepted and", err: query.ErrSyntax},
+		{expr: "status accepted", err: query.ErrSyntax},
+		{expr: "tag in (db", err: query.ErrSyntax},
+		{expr: "(status = accepted", err: query.ErrSyntax},
+		{expr: "title = 'open", err: query.ErrSyntax},
+		{expr: "status = accepted)", err: query.ErrSyntax},
+		{expr: "", err: query.ErrSyntax},
+		{expr: "date > last-week", err: query.ErrInvalidDate},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.expr, func(t *testing.T) {
+			q, err := query.Parse(tt.expr, now)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var ids []string
+
+			for _, rec := range records {
+				if q.Match(rec) {
+					ids = append(ids, rec.ID)
+				}
+			}
+
+			assert.Equal(t, tt.wants, ids)
+		})
+	}
+}
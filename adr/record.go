@@ -0,0 +1,319 @@
+package adr
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFormat is the layout used for dates within a record.
+const DateFormat = "2006-01-02"
+
+// ErrNotRecord is returned when a file name does not describe a record.
+var ErrNotRecord = errors.New("not a decision record")
+
+var (
+	recordFile    = regexp.MustCompile(`^(\d+)-.*\.md$`)
+	numberedTitle = regexp.MustCompile(`^\d+\.\s+`)
+	fieldKey      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9 _-]{0,31}$`)
+)
+
+// Record represents a single decision record within an adr directory.
+type Record struct {
+	ID     string
+	Title  string
+	Status string
+	Date   time.Time
+	Tags   []string
+
+	// ReviewBy is the date the decision is due for review, if it is set.
+	ReviewBy time.Time
+
+	// Deciders holds the people or teams that made the decision.
+	Deciders []string
+	// Consulted holds the people or teams whose opinions were sought.
+	Consulted []string
+	// Informed holds the people or teams kept up to date on the decision.
+	Informed []string
+	// Fields holds any other metadata of the record, keyed by name.
+	Fields map[string]string
+
+	// History holds the status changes of the record, oldest first.
+	History []StatusChange
+	// SignOffs holds the approvals of and objections to the decision,
+	// oldest first.
+	SignOffs []SignOff
+	// Links holds the links from this record to other records.
+	Links []Link
+	// Imports holds the import rules that the decision declares.
+	Imports []ImportRule
+	// Modules holds the module policies that the decision declares.
+	Modules []ModulePolicy
+
+	// Dir is the name of the adr directory the record belongs to.
+	Dir string
+	// Path is the location of the record, relative to the state dir.
+	Path string
+	// Style is the metadata style the record is written in.
+	Style string
+}
+
+// RecordID returns the identifier of the record described by the given file
+// name. If the file name does not describe a record, then ErrNotRecord is
+// returned.
+func RecordID(name string) (string, error) {
+	match := recordFile.FindStringSubmatch(name)
+	if match == nil {
+		return "", ErrNotRecord
+	}
+
+	return match[1], nil
+}
+
+// CompareIDs compares two record identifiers, treating numeric identifiers
+// as numbers so that "9" sorts before "12" and "12" equals "0012". The
+// result is -1, 0 or +1.
+func CompareIDs(a, b string) int {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+
+	if errX != nil || errY != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+// SameID reports whether two record identifiers are equal, treating numeric
+// identifiers as numbers so that "12" matches "0012".
+func SameID(a, b string) bool {
+	return CompareIDs(a, b) == 0
+}
+
+// IDNumber returns the number of the record identifier without its leading
+// zeros, so that "12" and "0012" give the same number. Identifiers that are
+// not numbers are returned as they are.
+func IDNumber(id string) string {
+	if n, err := strconv.Atoi(id); err == nil {
+		return strconv.Itoa(n)
+	}
+
+	return id
+}
+
+// HasStatus reports whether the record has the given status, ignoring case.
+// A status with trailing detail, such as "Superseded by 12", also matches
+// its leading status.
+func (r Record) HasStatus(status string) bool {
+	return strings.EqualFold(r.Status, status) || strings.EqualFold(BaseStatus(r.Status), status)
+}
+
+// HasTag reports whether the record has the given tag, ignoring case.
+func (r Record) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseRecord parses the markdown of a record. The metadata is read from the
+// YAML frontmatter of the record if it has one. Otherwise it is read from the
+// Nygard style layout that docula writes, where the status is the first line
+// of the "Status" section, or from inline fields below the title.
+func ParseRecord(name string, data []byte) (Record, error) {
+	id, err := RecordID(name)
+	if err != nil {
+		return Record{}, err
+	}
+
+	front, body, ok := splitFrontmatter(data)
+
+	rec, err := parseMarkdown(body)
+	if err != nil {
+		return Record{}, err
+	}
+
+	rec.ID = id
+	rec.Style = StyleInline
+
+	if ok {
+		if err = applyFrontmatter(&rec, front); err != nil {
+			return Record{}, err
+		}
+	}
+
+	return rec, nil
+}
+
+func parseMarkdown(data []byte) (Record, error) {
+	var rec Record
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	var (
+		section   string
+		inHistory bool
+		inSignOff bool
+		block     []string
+		fence     string
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		opens, isFence := blockFence(line)
+
+		switch {
+		case fence != "" && line == "```":
+			if err := parseBlock(&rec, fence, block); err != nil {
+				return Record{}, err
+			}
+
+			fence, block = "", nil
+
+			continue
+		case fence != "":
+			block = append(block, scanner.Text())
+			continue
+		case isFence:
+			fence = opens
+			continue
+		case line == historyStart:
+			inHistory = true
+			continue
+		case line == historyEnd:
+			inHistory = false
+			continue
+		case inHistory:
+			if change, ok := parseStatusChange(line); ok {
+				rec.History = append(rec.History, change)
+			}
+
+			continue
+		case line == signOffStart:
+			inSignOff = true
+			continue
+		case line == signOffEnd:
+			inSignOff = false
+			continue
+		case inSignOff:
+			if s, ok := parseSignOff(line); ok {
+				rec.SignOffs = append(rec.SignOffs, s)
+			}
+
+			continue
+		}
+
+		if link, ok := parseLink(line); ok {
+			rec.Links = append(rec.Links, link)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# ") && rec.Title == "":
+			rec.Title = numberedTitle.ReplaceAllString(strings.TrimSpace(line[2:]), "")
+		case strings.HasPrefix(line, "## "):
+			section = strings.ToLower(strings.TrimSpace(line[3:]))
+		case line == "":
+		case section == "status" && rec.Status == "":
+			rec.Status = respell(line)
+		case section == "":
+			parseField(&rec, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Record{}, err
+	}
+
+	return rec, nil
+}
+
+// Normalize converts the markdown of a record into the layout docula writes.
+// The number is dropped from the title and supersede is spelt with an s in
+// the links and status, as written by adr-tools, and the status history is
+// started from the date and status of the record.
+func Normalize(data []byte, rec Record) ([]byte, error) {
+	front, body, ok := splitFrontmatter(data)
+
+	lines := strings.Split(string(body), "\n")
+
+	for i, line := range lines {
+		if strings.HasPrefix(line, "# ") {
+			lines[i] = "# " + numberedTitle.ReplaceAllString(strings.TrimSpace(line[2:]), "")
+			break
+		}
+	}
+
+	for i, line := range lines {
+		if _, ok := parseLink(strings.TrimSpace(line)); ok {
+			lines[i] = respell(line)
+		}
+	}
+
+	if ok {
+		return SeedHistory(joinFrontmatter(front, join(lines)), rec)
+	}
+
+	return SeedHistory(join(lines), rec)
+}
+
+func parseField(rec *Record, line string) {
+	line = strings.TrimLeft(line, "*- ")
+
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return
+	}
+
+	value = strings.TrimSpace(value)
+
+	key = strings.TrimSpace(key)
+
+	switch strings.ToLower(key) {
+	case "date":
+		if date, err := parseDate(value); err == nil {
+			rec.Date = date
+		}
+	case "review-by":
+		if date, err := parseDate(value); err == nil {
+			rec.ReviewBy = date
+		}
+	case "status":
+		rec.Status = respell(value)
+	case "tags":
+		rec.Tags = append(rec.Tags, splitList(value)...)
+	case "deciders":
+		rec.Deciders = append(rec.Deciders, splitList(value)...)
+	case "consulted":
+		rec.Consulted = append(rec.Consulted, splitList(value)...)
+	case "informed":
+		rec.Informed = append(rec.Informed, splitList(value)...)
+	default:
+		if !fieldKey.MatchString(key) || value == "" {
+			return
+		}
+
+		if rec.Fields == nil {
+			rec.Fields = map[string]string{}
+		}
+
+		rec.Fields[key] = value
+	}
+}
+
+func parseDate(value string) (time.Time, error) {
+	return time.Parse(DateFormat, value)
+}
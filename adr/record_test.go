@@ -0,0 +1,312 @@
+package adr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestParseRecord(t *testing.T) {
+	type want struct {
+		record adr.Record
+		err    error
+	}
+
+	testCases := []struct {
+		name  string
+		file  string
+		input string
+		wants want
+	}{
+		{
+			name: "docula record",
+			file: "0002-use-postgresql.md",
+			input: `# Use PostgreSQL
+
+Date: 2022-07-14
+Tags: db, storage
+
+## Status
+
+Proposed
+
+## Context
+
+Status: not a field
+`,
+			wants: want{
+				record: adr.Record{
+					ID:     "0002",
+					Title:  "Use PostgreSQL",
+					Status: "Proposed",
+					Date:   time.Date(2022, 7, 14, 0, 0, 0, 0, time.UTC),
+					Tags:   []string{"db", "storage"},
+					Style:  adr.StyleInline,
+				},
+			},
+		},
+		{
+			name: "numbered title",
+			file: "0001-record-architecture-decisions.md",
+			input: `# 1. Record architecture decisions
+
+Date: 2016-02-12
+
+## Status
+
+Accepted
+`,
+			wants: want{
+				record: adr.Record{
+					ID:     "0001",
+					Title:  "Record architecture decisions",
+					Status: "Accepted",
+					Date:   time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC),
+					Style:  adr.StyleInline,
+				},
+			},
+		},
+		{
+			name: "inline status",
+			file: "20220714093000-rotate-keys.md",
+			input: `# Rotate keys
+
+Status: Accepted
+`,
+			wants: want{
+				record: adr.Record{
+					ID:     "20220714093000",
+					Title:  "Rotate keys",
+					Status: "Accepted",
+					Style:  adr.StyleInline,
+				},
+			},
+		},
+		{
+			name: "madr fields",
+			file: "0003-use-grpc.md",
+			input: `# Use gRPC
+
+* Status: accepted
+* Deciders: Jane Doe, John Smith
+* Consulted: @platform-team
+* Informed: @security, Ops
+* Date: 2022-08-01
+* Review-by: 2023-08-01
+
+Technical Story: PLAT-123
+
+## Context and Problem Statement
+`,
+			wants: want{
+				record: adr.Record{
+					ID:        "0003",
+					Title:     "Use gRPC",
+					Status:    "accepted",
+					Date:      time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+					ReviewBy:  time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+					Deciders:  []string{"Jane Doe", "John Smith"},
+					Consulted: []string{"@platform-team"},
+					Informed:  []string{"@security", "Ops"},
+					Fields:    map[string]string{"Technical Story": "PLAT-123"},
+					Style:     adr.StyleInline,
+				},
+			},
+		},
+		{
+			name: "frontmatter",
+			file: "0004-use-kafka.md",
+			input: `---
+title: Use Kafka
+status: Superseded by [0005. Use NATS](0005-use-nats.md)
+date: 2022-09-01
+review-by: 2023-09-01
+deciders: [Jane Doe]
+consulted: "@platform-team, @dba"
+informed: ["@security"]
+tags: messaging, infra
+links:
+  - type: amends
+    title: 0002. Use PostgreSQL
+    target: 0002-use-postgresql.md
+history:
+  - date: 2022-09-01
+    status: Accepted
+    by: Jane Doe
+  - date: 2022-10-01
+    status: Superseded
+ticket: PLAT-7
+budget: 1200
+reviewers: [a, b]
+---
+
+# Kafka
+
+Some context.
+`,
+			wants: want{
+				record: adr.Record{
+					ID:        "0004",
+					Title:     "Use Kafka",
+					Status:    "Superseded by [0005. Use NATS](0005-use-nats.md)",
+					Date:      time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+					ReviewBy:  time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+					Tags:      []string{"messaging", "infra"},
+					Deciders:  []string{"Jane Doe"},
+					Consulted: []string{"@platform-team", "@dba"},
+					Informed:  []string{"@security"},
+					Fields:    map[string]string{"ticket": "PLAT-7", "budget": "1200"},
+					History: []adr.StatusChange{
+						{Date: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), Status: "Accepted", By: "Jane Doe"},
+						{Date: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), Status: "Superseded"},
+					},
+					Links: []adr.Link{
+						{Type: adr.LinkSupersededBy, Title: "0005. Use NATS", Target: "0005-use-nats.md"},
+						{Type: adr.LinkAmends, Title: "0002. Use PostgreSQL", Target: "0002-use-postgresql.md"},
+					},
+					Style: adr.StyleFrontmatter,
+				},
+			},
+		},
+		{
+			name:  "frontmatter without a title",
+			file:  "0005-use-nats.md",
+			input: "---\nstatus: Proposed\n---\n# Use NATS\n",
+			wants: want{
+				record: adr.Record{
+					ID:     "0005",
+					Title:  "Use NATS",
+					Status: "Proposed",
+					Style:  adr.StyleFrontmatter,
+				},
+			},
+		},
+		{
+			name:  "invalid frontmatter",
+			file:  "0006-broken.md",
+			input: "---\n- not\n- a mapping\n---\n# Broken\n",
+			wants: want{
+				err: adr.ErrInvalidFrontmatter,
+			},
+		},
+		{
+			name: "import rules",
+			file: "0007-keep-billing-apart.md",
+			input: "# Keep billing apart\n\n## Status\n\nAccepted\n\n" +
+				"```yaml docula:imports\n- from: internal/billing/...\n  deny: [internal/auth/...]\n```\n",
+			wants: want{
+				record: adr.Record{
+					ID:      "0007",
+					Title:   "Keep billing apart",
+					Status:  "Accepted",
+					Imports: []adr.ImportRule{{From: "internal/billing/...", Deny: []string{"internal/auth/..."}}},
+					Style:   adr.StyleInline,
+				},
+			},
+		},
+		{
+			name:  "invalid import rules",
+			file:  "0008-broken.md",
+			input: "# Broken\n\n```yaml docula:imports\nfrom: [\n```\n",
+			wants: want{
+				err: adr.ErrInvalidImportRule,
+			},
+		},
+		{
+			name:  "invalid module policies",
+			file:  "0009-broken.md",
+			input: "# Broken\n\n```yaml docula:modules\nmodule: [\n```\n",
+			wants: want{
+				err: adr.ErrInvalidModulePolicy,
+			},
+		},
+		{
+			name:  "not a record",
+			file:  "README.md",
+			input: "# Decisions",
+			wants: want{
+				err: adr.ErrNotRecord,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := adr.ParseRecord(tt.file, []byte(tt.input))
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.record, rec)
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	input := `# 1. Use PostgreSQL
+
+Date: 2016-02-12
+
+## Status
+
+Accepted
+
+## Context
+`
+
+	wants := `# Use PostgreSQL
+
+Date: 2016-02-12
+
+## Status
+
+Accepted
+
+<!-- docula:status-history -->
+- 2016-02-12: Accepted
+<!-- /docula:status-history -->
+
+## Context
+`
+
+	rec, err := adr.ParseRecord("0001-use-postgresql.md", []byte(input))
+	assert.NoError(t, err)
+
+	res, err := adr.Normalize([]byte(input), rec)
+	assert.NoError(t, err)
+	assert.Equal(t, wants, string(res))
+
+	again, err := adr.Normalize(res, rec)
+	assert.NoError(t, err)
+	assert.Equal(t, wants, string(again))
+}
+
+func TestCompareIDs(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		wants int
+	}{
+		{a: "9", b: "12", wants: -1},
+		{a: "0012", b: "12", wants: 0},
+		{a: "12", b: "0009", wants: 1},
+		{a: "abc", b: "abd", wants: -1},
+		{a: "12", b: "abc", wants: -1},
+	}
+
+	for _, tt := range testCases {
+		assert.Equal(t, tt.wants, adr.CompareIDs(tt.a, tt.b), "%s %s", tt.a, tt.b)
+		assert.Equal(t, tt.wants == 0, adr.SameID(tt.a, tt.b), "%s %s", tt.a, tt.b)
+	}
+
+	assert.Equal(t, "12", adr.IDNumber("0012"))
+	assert.Equal(t, "0", adr.IDNumber("0000"))
+	assert.Equal(t, "abc", adr.IDNumber("abc"))
+}
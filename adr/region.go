@@ -0,0 +1,49 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnbalancedMarkers is returned when a document contains only one of the
+// markers surrounding a generated region, or contains them out of order.
+var ErrUnbalancedMarkers = errors.New("unbalanced markers")
+
+// ReplaceRegion replaces the region between the start and end markers of the
+// document with the given region, which holds the markers itself. A document
+// without markers has the region appended to it, and an empty region removes
+// the markers along with everything between them.
+func ReplaceRegion(data []byte, start, end, region string) ([]byte, error) {
+	content := string(data)
+
+	from := strings.Index(content, start)
+	to := strings.Index(content, end)
+
+	switch {
+	case from < 0 && to < 0:
+		if region == "" {
+			return data, nil
+		}
+
+		content = strings.TrimRight(content, "\n")
+		if strings.TrimSpace(content) == "" {
+			return []byte(region + "\n"), nil
+		}
+
+		return []byte(content + "\n\n" + region + "\n"), nil
+	case from < 0 || to < from:
+		return nil, fmt.Errorf("%w: %s", ErrUnbalancedMarkers, start)
+	case region == "":
+		before := strings.TrimRight(content[:from], "\n")
+		after := strings.TrimLeft(content[to+len(end):], "\n")
+
+		if after == "" {
+			return []byte(before + "\n"), nil
+		}
+
+		return []byte(before + "\n\n" + after), nil
+	}
+
+	return []byte(content[:from] + region + content[to+len(end):]), nil
+}
@@ -0,0 +1,90 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestReplaceRegion(t *testing.T) {
+	const (
+		start  = "<!-- start -->"
+		end    = "<!-- end -->"
+		region = start + "\nnew\n" + end
+	)
+
+	testCases := []struct {
+		name   string
+		data   string
+		region string
+		wants  string
+		err    error
+	}{
+		{
+			name:   "empty document",
+			data:   "",
+			region: region,
+			wants:  region + "\n",
+		},
+		{
+			name:   "document without markers",
+			data:   "# Title\n\nText\n",
+			region: region,
+			wants:  "# Title\n\nText\n\n" + region + "\n",
+		},
+		{
+			name:   "document with markers",
+			data:   "# Title\n\n" + start + "\nold\n" + end + "\n\nAfter\n",
+			region: region,
+			wants:  "# Title\n\n" + region + "\n\nAfter\n",
+		},
+		{
+			name:   "removing the region",
+			data:   "# Title\n\n" + start + "\nold\n" + end + "\n\nAfter\n",
+			region: "",
+			wants:  "# Title\n\nAfter\n",
+		},
+		{
+			name:   "removing the region at the end",
+			data:   "# Title\n\n" + start + "\nold\n" + end + "\n",
+			region: "",
+			wants:  "# Title\n",
+		},
+		{
+			name:   "removing a missing region",
+			data:   "# Title\n",
+			region: "",
+			wants:  "# Title\n",
+		},
+		{
+			name:   "end marker only",
+			data:   end + "\n",
+			region: region,
+			err:    adr.ErrUnbalancedMarkers,
+		},
+		{
+			name:   "markers out of order",
+			data:   end + "\n" + start + "\n",
+			region: region,
+			err:    adr.ErrUnbalancedMarkers,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := adr.ReplaceRegion([]byte(tt.data), start, end, tt.region)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, string(res))
+		})
+	}
+}
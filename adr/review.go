@@ -0,0 +1,117 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInterval is returned when an interval is not made of a number
+// of days, weeks, months and years, such as 90d or 1y6m.
+var ErrInvalidInterval = errors.New("invalid interval")
+
+var (
+	intervalExpr = regexp.MustCompile(`^(?i)(\d+[dwmy])+$`)
+	intervalPart = regexp.MustCompile(`(?i)(\d+)([dwmy])`)
+)
+
+// Interval represents a span of calendar time, such as the time after which
+// a decision is due for review.
+type Interval struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParseInterval parses an interval made of a number of days, weeks, months
+// and years, such as 30d, 2w, 6m or 1y6m.
+func ParseInterval(value string) (Interval, error) {
+	value = strings.TrimSpace(value)
+
+	if !intervalExpr.MatchString(value) {
+		return Interval{}, fmt.Errorf("%w: %q", ErrInvalidInterval, value)
+	}
+
+	var i Interval
+
+	for _, part := range intervalPart.FindAllStringSubmatch(value, -1) {
+		n, err := strconv.Atoi(part[1])
+		if err != nil {
+			return Interval{}, fmt.Errorf("%w: %q", ErrInvalidInterval, value)
+		}
+
+		switch strings.ToLower(part[2]) {
+		case "d":
+			i.Days += n
+		case "w":
+			i.Days += 7 * n
+		case "m":
+			i.Months += n
+		case "y":
+			i.Years += n
+		}
+	}
+
+	return i, nil
+}
+
+// After returns the time the interval after the given time.
+func (i Interval) After(t time.Time) time.Time {
+	return t.AddDate(i.Years, i.Months, i.Days)
+}
+
+// Before returns the time the interval before the given time.
+func (i Interval) Before(t time.Time) time.Time {
+	return t.AddDate(-i.Years, -i.Months, -i.Days)
+}
+
+// StatusDate returns the date the record moved to its current status, which
+// is the date of the last entry of its status history, or the date of the
+// record if it has no history.
+func (r Record) StatusDate() time.Time {
+	if len(r.History) > 0 {
+		return r.History[len(r.History)-1].Date
+	}
+
+	return r.Date
+}
+
+// ReviewDate returns the date the decision of the record is due for review,
+// if it has one. Only accepted records are reviewed. A review-by date of the
+// record takes precedence over the review interval of the directory, which
+// is counted from the date the record was accepted.
+func (d Directory) ReviewDate(rec Record) (time.Time, bool, error) {
+	if !rec.HasStatus(StatusAccepted) {
+		return time.Time{}, false, nil
+	}
+
+	if !rec.ReviewBy.IsZero() {
+		return rec.ReviewBy, true, nil
+	}
+
+	if d.Review == "" {
+		return time.Time{}, false, nil
+	}
+
+	interval, err := ParseInterval(d.Review)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("dir %s: review %w", d.Path, err)
+	}
+
+	accepted := rec.Date
+
+	for _, c := range rec.History {
+		if strings.EqualFold(BaseStatus(c.Status), StatusAccepted) {
+			accepted = c.Date
+		}
+	}
+
+	if accepted.IsZero() {
+		return time.Time{}, false, nil
+	}
+
+	return interval.After(accepted), true, nil
+}
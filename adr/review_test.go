@@ -0,0 +1,104 @@
+package adr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestParseInterval(t *testing.T) {
+	testCases := []struct {
+		input string
+		wants adr.Interval
+		err   error
+	}{
+		{input: "30d", wants: adr.Interval{Days: 30}},
+		{input: "2w", wants: adr.Interval{Days: 14}},
+		{input: "6m", wants: adr.Interval{Months: 6}},
+		{input: "1Y6m", wants: adr.Interval{Years: 1, Months: 6}},
+		{input: "", err: adr.ErrInvalidInterval},
+		{input: "1 year", err: adr.ErrInvalidInterval},
+		{input: "-30d", err: adr.ErrInvalidInterval},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.input, func(t *testing.T) {
+			interval, err := adr.ParseInterval(tt.input)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.wants, interval)
+		})
+	}
+}
+
+func TestReviewDate(t *testing.T) {
+	date := func(month, day int) time.Time {
+		return time.Date(2024, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name   string
+		dir    adr.Directory
+		record adr.Record
+		wants  time.Time
+		err    error
+	}{
+		{
+			name:   "review-by date",
+			dir:    adr.Directory{Review: "1y"},
+			record: adr.Record{Status: "Accepted", Date: date(1, 10), ReviewBy: date(3, 1)},
+			wants:  date(3, 1),
+		},
+		{
+			name: "interval from acceptance",
+			dir:  adr.Directory{Review: "6m"},
+			record: adr.Record{
+				Status: "Accepted",
+				Date:   date(1, 10),
+				History: []adr.StatusChange{
+					{Date: date(1, 10), Status: "Proposed"},
+					{Date: date(2, 20), Status: "Accepted"},
+				},
+			},
+			wants: date(8, 20),
+		},
+		{
+			name:   "interval from record date",
+			dir:    adr.Directory{Review: "2w"},
+			record: adr.Record{Status: "accepted", Date: date(1, 10)},
+			wants:  date(1, 24),
+		},
+		{
+			name:   "no interval",
+			record: adr.Record{Status: "Accepted", Date: date(1, 10)},
+		},
+		{
+			name:   "not accepted",
+			dir:    adr.Directory{Review: "1y"},
+			record: adr.Record{Status: "Superseded by 0007", Date: date(1, 10), ReviewBy: date(3, 1)},
+		},
+		{
+			name:   "invalid interval",
+			dir:    adr.Directory{Review: "soon"},
+			record: adr.Record{Status: "Accepted", Date: date(1, 10)},
+			err:    adr.ErrInvalidInterval,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			review, ok, err := tt.dir.ReviewDate(tt.record)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, !tt.wants.IsZero(), ok)
+			assert.Equal(t, tt.wants, review)
+		})
+	}
+}
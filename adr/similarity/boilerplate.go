@@ -0,0 +1,37 @@
+package similarity
+
+import "strings"
+
+// Boilerplate holds the lines of the templates that documents are written
+// from. Records that are left with the text of their template would
+// otherwise resemble each other, whatever they decide.
+type Boilerplate map[string]bool
+
+// NewBoilerplate collects the lines of the rendered templates.
+func NewBoilerplate(templates ...[]byte) Boilerplate {
+	b := Boilerplate{}
+
+	for _, tmpl := range templates {
+		for _, line := range strings.Split(string(tmpl), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				b[line] = true
+			}
+		}
+	}
+
+	return b
+}
+
+// Strip removes the lines of the text that are part of the boilerplate.
+func (b Boilerplate) Strip(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+
+	for _, line := range lines {
+		if !b[strings.TrimSpace(line)] {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,4 @@
+// Package similarity finds decision records that resemble a text, such as
+// the title of a new record. The records are compared by the cosine of their
+// TF-IDF vectors, which is computed locally without any external model.
+package similarity
@@ -0,0 +1,211 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// titleWeight is the number of times the words of a title are counted, so
+// that titles weigh more than the text that follows them.
+const titleWeight = 3
+
+// stopWords holds the common English words that carry no meaning of their
+// own, along with the words and headings every record uses. Words are held
+// in their singular form, as plurals are reduced to it before the check.
+var stopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "an": true,
+	"and": true, "any": true, "are": true, "as": true, "at": true, "be": true, "been": true,
+	"but": true, "by": true, "can": true, "consequence": true, "context": true, "could": true,
+	"date": true, "decision": true, "do": true, "does": true, "for": true, "from": true,
+	"had": true, "has": true, "have": true, "how": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "more": true, "must": true, "no": true, "not": true,
+	"of": true, "on": true, "option": true, "or": true, "our": true, "should": true, "so": true, "some": true,
+	"status": true, "such": true, "than": true, "that": true, "the": true, "their": true,
+	"them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"those": true, "to": true, "up": true, "use": true, "used": true, "using": true,
+	"was": true, "we": true, "were": true, "what": true, "when": true, "which": true,
+	"while": true, "who": true, "why": true, "will": true, "with": true, "would": true,
+	"you": true, "your": true,
+}
+
+// Document is a text to compare against, such as a decision record.
+type Document struct {
+	// ID identifies the document within the matches.
+	ID    string
+	Title string
+	Text  string
+}
+
+// Match is a document that resembles the searched text.
+type Match struct {
+	ID string
+	// Score is the cosine similarity of the document and the text, from 0
+	// for nothing in common to 1 for the same words.
+	Score float64
+}
+
+// Index holds the TF-IDF vectors of a set of documents.
+type Index struct {
+	ids     []string
+	vectors []map[string]float64
+	idf     map[string]float64
+	size    int
+}
+
+// NewIndex computes the TF-IDF vectors of the documents.
+func NewIndex(docs []Document) *Index {
+	idx := &Index{idf: map[string]float64{}, size: len(docs)}
+
+	counts := make([]map[string]float64, len(docs))
+	df := map[string]int{}
+
+	for i, doc := range docs {
+		counts[i] = termCounts(doc.Title, doc.Text)
+
+		for term := range counts[i] {
+			df[term]++
+		}
+	}
+
+	for term, n := range df {
+		idx.idf[term] = inverse(len(docs), n)
+	}
+
+	for i, doc := range docs {
+		idx.ids = append(idx.ids, doc.ID)
+		idx.vectors = append(idx.vectors, idx.weigh(counts[i]))
+	}
+
+	return idx
+}
+
+// inverse returns the inverse document frequency of a term that occurs in n
+// of the size documents. Terms that occur in every document tell nothing
+// about any of them, so they weigh nothing, unless there is only a single
+// document to compare with. Terms that occur in none of them weigh the most.
+func inverse(size int, n int) float64 {
+	switch {
+	case size <= 1:
+		return 1
+	case n == 0:
+		return math.Log(float64(size + 1))
+	}
+
+	return math.Log(float64(size) / float64(n))
+}
+
+// weigh turns the counts of the terms into a TF-IDF vector of unit length.
+// Terms that no document holds weigh more than the rarest terms.
+func (x *Index) weigh(counts map[string]float64) map[string]float64 {
+	vector := make(map[string]float64, len(counts))
+
+	var norm float64
+
+	for term, count := range counts {
+		idf, ok := x.idf[term]
+		if !ok {
+			idf = inverse(x.size, 0)
+		}
+
+		w := (1 + math.Log(count)) * idf
+		vector[term] = w
+		norm += w * w
+	}
+
+	if norm == 0 {
+		return vector
+	}
+
+	norm = math.Sqrt(norm)
+
+	for term := range vector {
+		vector[term] /= norm
+	}
+
+	return vector
+}
+
+// Search returns the documents that share any words with the title and text,
+// most similar first.
+func (x *Index) Search(title string, text string) []Match {
+	counts := termCounts(title, text)
+	if len(counts) == 0 {
+		return nil
+	}
+
+	query := x.weigh(counts)
+
+	var matches []Match
+
+	for i, vector := range x.vectors {
+		var score float64
+
+		for term, w := range query {
+			score += w * vector[term]
+		}
+
+		if score > 0 {
+			matches = append(matches, Match{ID: x.ids[i], Score: math.Min(score, 1)})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+
+	return matches
+}
+
+func termCounts(title string, text string) map[string]float64 {
+	counts := map[string]float64{}
+
+	for _, term := range Terms(title) {
+		counts[term] += titleWeight
+	}
+
+	for _, term := range Terms(text) {
+		counts[term]++
+	}
+
+	return counts
+}
+
+// Terms splits the text into the terms it is compared by. Words are lower
+// cased and reduced to their singular form, while numbers and stop words are
+// left out.
+func Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+
+	for _, word := range words {
+		if len(word) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+
+		term := stem(word)
+		if stopWords[word] || stopWords[term] {
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// stem reduces the plural of a word to its singular, such as caches to
+// cache and policies to policy.
+func stem(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return strings.TrimSuffix(word, "s")
+	}
+
+	return word
+}
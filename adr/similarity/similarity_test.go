@@ -0,0 +1,90 @@
+package similarity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr/similarity"
+)
+
+var docs = []similarity.Document{
+	{
+		ID:    "0001",
+		Title: "Use PostgreSQL as the primary database",
+		Text:  "We need a relational database with strong consistency for billing.",
+	},
+	{
+		ID:    "0002",
+		Title: "Use Redis for caching",
+		Text:  "Caches reduce the load on the database for hot reads.",
+	},
+	{
+		ID:    "0003",
+		Title: "Publish events with Kafka",
+		Text:  "Services publish domain events to topics and consumers replay them.",
+	},
+	{
+		ID:    "0004",
+		Title: "Empty",
+		Text:  "",
+	},
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t,
+		[]string{"cache", "policy", "redis", "v2", "report"},
+		similarity.Terms("Use the caches' policies: Redis v2, 2024-01-01 (status reports)"),
+	)
+
+	assert.Equal(t,
+		[]string{"outcome"},
+		similarity.Terms("Decisions, Consequences and Options: outcomes"),
+	)
+}
+
+func TestSearch(t *testing.T) {
+	idx := similarity.NewIndex(docs)
+
+	testCases := []struct {
+		name  string
+		title string
+		text  string
+		wants []string
+	}{
+		{name: "similar title", title: "Adopt a Redis cache", wants: []string{"0002"}},
+		{name: "more shared words rank higher", title: "Cache the billing database", wants: []string{"0001", "0002"}},
+		{name: "text of a record", title: "Stream events", text: "Consumers replay the topics of Kafka", wants: []string{"0003"}},
+		{name: "nothing in common", title: "Rotate keys", wants: nil},
+		{name: "only stop words", title: "Use the", wants: nil},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+
+			for _, m := range idx.Search(tt.title, tt.text) {
+				assert.True(t, m.Score > 0 && m.Score <= 1)
+				ids = append(ids, m.ID)
+			}
+
+			assert.Equal(t, tt.wants, ids)
+		})
+	}
+
+	same := idx.Search(docs[0].Title, docs[0].Text)
+	assert.Equal(t, "0001", same[0].ID)
+	assert.InDelta(t, 1, same[0].Score, 1e-9)
+}
+
+func TestBoilerplate(t *testing.T) {
+	b := similarity.NewBoilerplate([]byte("# \n\n## Context\n\nWhat is the issue?\n"), []byte("## Options\n"))
+
+	assert.Equal(t, "# Use Kafka\n\n\nEvents are lost.\n\nKafka.\n",
+		b.Strip("# Use Kafka\n\n## Context\n\nEvents are lost.\nWhat is the issue?\n\n  ## Options\nKafka.\n"))
+
+	var none similarity.Boilerplate
+	assert.Equal(t, "## Context\n", none.Strip("## Context\n"))
+}
@@ -1,13 +1,127 @@
 package adr
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDirNotFound is returned when a name or path does not match any of the
+// configured adr directories.
+var ErrDirNotFound = errors.New("adr dir not found")
+
 // Directory represents a configured adr directory.
 type Directory struct {
 	Path  string `yaml:"path"`
 	Name  string `yaml:"name"`
 	Index string `yaml:"index"`
+
+	// Template is the name of a built-in template, or the path of a custom
+	// template relative to the state dir. The default template is used when
+	// it is not set.
+	Template string `yaml:"template,omitempty"`
+
+	// Workflow optionally declares the statuses of the directory. The
+	// default workflow is used when it is not set.
+	Workflow *Workflow `yaml:"workflow,omitempty"`
+
+	// Review optionally sets the interval after which accepted records are
+	// due for review, such as 1y. Records may set a review-by date instead.
+	Review string `yaml:"review,omitempty"`
+
+	// Quorum optionally sets the sign-offs a record needs before it can be
+	// accepted.
+	Quorum *Quorum `yaml:"quorum,omitempty"`
 }
 
 // State repesents the internal state configuration of the adr commands
 type State struct {
 	Directories []Directory `yaml:"dirs"`
 }
+
+// FindDirectory returns the directory whose name or path matches the given
+// key.
+func (s State) FindDirectory(key string) (Directory, bool) {
+	for _, dir := range s.Directories {
+		if dir.Name == key || dir.Path == key {
+			return dir, true
+		}
+	}
+
+	return Directory{}, false
+}
+
+// LookupDirectory returns the directory whose name or path matches the given
+// key. A key that matches no directory is normalized with the given function
+// and matched again as a path, so that a dir can be given relative to the
+// working directory.
+func (s State) LookupDirectory(key string, normalize func(string) (string, error)) (Directory, error) {
+	if dir, ok := s.FindDirectory(key); ok {
+		return dir, nil
+	}
+
+	path, err := normalize(key)
+	if err != nil {
+		return Directory{}, fmt.Errorf("normalize path: %w", err)
+	}
+
+	if dir, ok := s.FindDirectory(path); ok {
+		return dir, nil
+	}
+
+	return Directory{}, fmt.Errorf("%w: %s", ErrDirNotFound, key)
+}
+
+// SelectDirectories returns the directory matching the given key, as found
+// by LookupDirectory, or every directory when the key is empty.
+func (s State) SelectDirectories(key string, normalize func(string) (string, error)) ([]Directory, error) {
+	if key == "" {
+		return s.Directories, nil
+	}
+
+	dir, err := s.LookupDirectory(key, normalize)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Directory{dir}, nil
+}
+
+// Validate checks that the directory configuration is usable.
+func (d Directory) Validate() error {
+	switch d.IndexType() {
+	case IndexTimestamp, IndexSequential:
+	default:
+		return fmt.Errorf("%w %q for dir %s", ErrUnknownIndex, d.Index, d.Path)
+	}
+
+	if d.Workflow != nil {
+		if err := d.Workflow.Validate(); err != nil {
+			return fmt.Errorf("dir %s: %w", d.Path, err)
+		}
+	}
+
+	if d.Quorum != nil {
+		if err := d.Quorum.Validate(); err != nil {
+			return fmt.Errorf("dir %s: %w", d.Path, err)
+		}
+	}
+
+	if d.Review != "" {
+		if _, err := ParseInterval(d.Review); err != nil {
+			return fmt.Errorf("dir %s: review %w", d.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that every configured directory is usable.
+func (s State) Validate() error {
+	for _, dir := range s.Directories {
+		if err := dir.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
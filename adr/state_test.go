@@ -0,0 +1,154 @@
+package adr_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestStateValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input adr.State
+		wants error
+	}{
+		{
+			name:  "no dirs",
+			input: adr.State{},
+		},
+		{
+			name: "known index types",
+			input: adr.State{
+				Directories: []adr.Directory{
+					{Path: "foo", Index: adr.IndexSequential},
+					{Path: "bar", Index: adr.IndexTimestamp},
+					{Path: "baz"},
+				},
+			},
+		},
+		{
+			name: "unknown index type",
+			input: adr.State{
+				Directories: []adr.Directory{
+					{Path: "foo", Index: adr.IndexSequential},
+					{Path: "bar", Index: "uuid"},
+				},
+			},
+			wants: adr.ErrUnknownIndex,
+		},
+		{
+			name: "invalid workflow",
+			input: adr.State{
+				Directories: []adr.Directory{
+					{Path: "foo", Workflow: &adr.Workflow{}},
+				},
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "invalid quorum",
+			input: adr.State{
+				Directories: []adr.Directory{
+					{Path: "foo", Quorum: &adr.Quorum{Approvals: 0}},
+				},
+			},
+			wants: adr.ErrInvalidQuorum,
+		},
+		{
+			name: "invalid review interval",
+			input: adr.State{
+				Directories: []adr.Directory{
+					{Path: "foo", Review: "1 year"},
+				},
+			},
+			wants: adr.ErrInvalidInterval,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSelectDirectories(t *testing.T) {
+	platform := adr.Directory{Path: "docs/adr", Name: "platform"}
+	security := adr.Directory{Path: "security", Name: "security"}
+	s := adr.State{Directories: []adr.Directory{platform, security}}
+
+	errOutside := errors.New("outside of the state dir")
+
+	normalize := func(path string) (string, error) {
+		if strings.HasPrefix(path, "..") {
+			return "", errOutside
+		}
+
+		return strings.TrimPrefix(path, "./"), nil
+	}
+
+	testCases := []struct {
+		name  string
+		key   string
+		wants []adr.Directory
+		err   error
+	}{
+		{name: "every dir", wants: []adr.Directory{platform, security}},
+		{name: "name", key: "security", wants: []adr.Directory{security}},
+		{name: "path", key: "docs/adr", wants: []adr.Directory{platform}},
+		{name: "normalized path", key: "./docs/adr", wants: []adr.Directory{platform}},
+		{name: "unknown dir", key: "nope", err: adr.ErrDirNotFound},
+		{name: "path that cannot be normalized", key: "../docs/adr", err: errOutside},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dirs, err := s.SelectDirectories(tt.key, normalize)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants, dirs)
+		})
+	}
+}
+
+func TestInferIndex(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input []string
+		wants string
+	}{
+		{name: "no ids", wants: ""},
+		{name: "sequential", input: []string{"0001", "0002", "0012"}, wants: adr.IndexSequential},
+		{name: "timestamps", input: []string{"20220714093000", "20220715101500"}, wants: adr.IndexTimestamp},
+		{name: "log4brains dates", input: []string{"20210412", "20210501"}, wants: adr.IndexTimestamp},
+		{name: "mixed", input: []string{"20220714093000", "0002"}, wants: adr.IndexSequential},
+		{name: "not a date", input: []string{"12345678"}, wants: adr.IndexSequential},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wants, adr.InferIndex(tt.input))
+		})
+	}
+}
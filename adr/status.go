@@ -0,0 +1,270 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The statuses of the default decision lifecycle.
+const (
+	StatusDraft      = "Draft"
+	StatusProposed   = "Proposed"
+	StatusAccepted   = "Accepted"
+	StatusRejected   = "Rejected"
+	StatusDeprecated = "Deprecated"
+	StatusSuperseded = "Superseded"
+)
+
+// The markers that surround the status history of a record.
+const (
+	historyStart = "<!-- docula:status-history -->"
+	historyEnd   = "<!-- /docula:status-history -->"
+)
+
+var (
+	// ErrIllegalTransition is returned when a record is not allowed to move
+	// from its current status to the requested status.
+	ErrIllegalTransition = errors.New("illegal status transition")
+
+	// ErrNoStatus is returned when a record has no status that can be
+	// changed.
+	ErrNoStatus = errors.New("record has no status")
+)
+
+var (
+	historyEntry = regexp.MustCompile(`^- (\d{4}-\d{2}-\d{2}): (.+?)(?: by (.+))?$`)
+	inlineStatus = regexp.MustCompile(`^(?i)((?:[*-]\s+)?status:\s*)`)
+)
+
+// StatusChange represents a single entry of the status history of a record.
+type StatusChange struct {
+	Date   time.Time
+	Status string
+	By     string
+
+	// Detail is written after the status in the status line of the record,
+	// such as a link to the record that superseded it. It is not kept in
+	// the status history.
+	Detail string
+}
+
+func (c StatusChange) String() string {
+	entry := fmt.Sprintf("- %s: %s", c.Date.Format(DateFormat), c.Status)
+
+	if c.By != "" {
+		entry = fmt.Sprintf("%s by %s", entry, c.By)
+	}
+
+	return entry
+}
+
+func parseStatusChange(line string) (StatusChange, bool) {
+	match := historyEntry.FindStringSubmatch(line)
+	if match == nil {
+		return StatusChange{}, false
+	}
+
+	date, err := time.Parse(DateFormat, match[1])
+	if err != nil {
+		return StatusChange{}, false
+	}
+
+	return StatusChange{Date: date, Status: match[2], By: match[3]}, true
+}
+
+// BaseStatus returns the status without any trailing detail, such as the
+// record that superseded it.
+func BaseStatus(status string) string {
+	if fields := strings.Fields(status); len(fields) > 0 {
+		return fields[0]
+	}
+
+	return ""
+}
+
+// SetStatus rewrites the status of the record markdown and appends the change
+// to the status history of the record. If the record has no history yet, the
+// current status is recorded first using the given date of the record.
+// Records with a frontmatter have the change written into the frontmatter.
+func SetStatus(data []byte, rec Record, change StatusChange) ([]byte, error) {
+	if _, _, ok := splitFrontmatter(data); ok {
+		return editFrontmatter(data, func(mapping *yaml.Node) {
+			status := change.Status
+			if change.Detail != "" {
+				status = fmt.Sprintf("%s %s", status, change.Detail)
+			}
+
+			setScalar(mapping, "status", status)
+
+			history := sequence(mapping, "history")
+
+			if len(history.Content) == 0 && !rec.Date.IsZero() && rec.Status != "" {
+				history.Content = append(history.Content, changeEntry(StatusChange{Date: rec.Date, Status: BaseStatus(rec.Status)}))
+			}
+
+			history.Content = append(history.Content, changeEntry(change))
+		})
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	statusLine := findStatusLine(lines)
+	if statusLine < 0 {
+		return nil, ErrNoStatus
+	}
+
+	status := change.Status
+	if change.Detail != "" {
+		status = fmt.Sprintf("%s %s", status, change.Detail)
+	}
+
+	prefix := inlineStatus.FindString(lines[statusLine])
+	lines[statusLine] = prefix + status
+
+	start, end := findHistory(lines)
+	if start >= 0 {
+		return join(insert(lines, end, change.String())), nil
+	}
+
+	var entries []string
+
+	if !rec.Date.IsZero() && rec.Status != "" {
+		entries = append(entries, StatusChange{Date: rec.Date, Status: BaseStatus(rec.Status)}.String())
+	}
+
+	return join(addHistory(lines, statusLine, append(entries, change.String()))), nil
+}
+
+// SeedHistory starts the status history of a record that has none, using
+// the date and status of the record as its first entry. The date of a record
+// in a terminal status, such as Superseded, is when it was decided, so the
+// status it held then is seeded instead, and the unknown date of the final
+// change is left out. Records that already have a history, or that lack a
+// date or status, are returned unchanged.
+func SeedHistory(data []byte, rec Record) ([]byte, error) {
+	if _, _, ok := splitFrontmatter(data); ok {
+		if len(rec.History) > 0 || rec.Date.IsZero() || rec.Status == "" {
+			return data, nil
+		}
+
+		return editFrontmatter(data, func(mapping *yaml.Node) {
+			history := sequence(mapping, "history")
+			history.Content = append(history.Content, changeEntry(seed(rec)))
+		})
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	statusLine := findStatusLine(lines)
+	if statusLine < 0 {
+		return nil, ErrNoStatus
+	}
+
+	if start, _ := findHistory(lines); start >= 0 || rec.Date.IsZero() || rec.Status == "" {
+		return data, nil
+	}
+
+	return join(addHistory(lines, statusLine, []string{seed(rec).String()})), nil
+}
+
+// seed returns the first entry of the status history of an imported record,
+// which is the status the record held on its date.
+func seed(rec Record) StatusChange {
+	return StatusChange{Date: rec.Date, Status: DefaultWorkflow().PriorStatus(BaseStatus(rec.Status))}
+}
+
+// addHistory writes a new status history block holding the entries. The
+// block follows the status of a status section, or ends the preamble of a
+// record with an inline status field.
+func addHistory(lines []string, statusLine int, entries []string) []string {
+	return addBlock(lines, statusLine, append(append([]string{historyStart}, entries...), historyEnd))
+}
+
+// addBlock writes the block after the status of a status section, or at the
+// end of the preamble of a record with an inline status field.
+func addBlock(lines []string, statusLine int, block []string) []string {
+	if !inlineStatus.MatchString(strings.TrimSpace(lines[statusLine])) {
+		return insert(lines, statusLine+1, append([]string{""}, block...)...)
+	}
+
+	return insert(lines, endOfPreamble(lines, statusLine), append(block, "")...)
+}
+
+// endOfPreamble returns the index of the first section heading after the
+// given line, which is where blocks belonging to an inline status field are
+// written. The end of the document is returned if there are no sections.
+func endOfPreamble(lines []string, from int) int {
+	for i := from; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "## ") {
+			return i
+		}
+	}
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		return len(lines) - 1
+	}
+
+	return len(lines)
+}
+
+// findStatusLine returns the index of the line that holds the status, which
+// is either the first line of the status section or an inline status field.
+func findStatusLine(lines []string) int {
+	var section string
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "## "):
+			section = strings.ToLower(strings.TrimSpace(trimmed[3:]))
+		case trimmed == "" || trimmed == historyStart:
+		case section == "status":
+			return i
+		case section == "" && inlineStatus.MatchString(trimmed):
+			return i
+		}
+	}
+
+	return -1
+}
+
+func findHistory(lines []string) (int, int) {
+	return findBlock(lines, historyStart, historyEnd)
+}
+
+// findBlock returns the indexes of the lines holding the markers of the
+// first block surrounded by them, or -1 if there is none.
+func findBlock(lines []string, startMarker string, endMarker string) (int, int) {
+	start := -1
+
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case startMarker:
+			start = i
+		case endMarker:
+			if start >= 0 {
+				return start, i
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+func insert(lines []string, at int, values ...string) []string {
+	res := make([]string, 0, len(lines)+len(values))
+	res = append(res, lines[:at]...)
+	res = append(res, values...)
+
+	return append(res, lines[at:]...)
+}
+
+func join(lines []string) []byte {
+	return []byte(strings.Join(lines, "\n"))
+}
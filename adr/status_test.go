@@ -0,0 +1,266 @@
+package adr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestDefaultWorkflowCanTransition(t *testing.T) {
+	testCases := []struct {
+		from  string
+		to    string
+		wants bool
+	}{
+		{from: "Draft", to: "Proposed", wants: true},
+		{from: "proposed", to: "Accepted", wants: true},
+		{from: "Proposed", to: "rejected", wants: true},
+		{from: "Accepted", to: "Deprecated", wants: true},
+		{from: "Accepted", to: "Superseded", wants: true},
+		{from: "Proposed", to: "Deprecated", wants: false},
+		{from: "Rejected", to: "Accepted", wants: false},
+		{from: "Superseded by 12", to: "Accepted", wants: false},
+		{from: "", to: "Accepted", wants: false},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.wants, adr.DefaultWorkflow().CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	change := adr.StatusChange{
+		Date:   time.Date(2022, 7, 20, 0, 0, 0, 0, time.UTC),
+		Status: "Deprecated",
+		By:     "John Smith",
+	}
+
+	testCases := []struct {
+		name   string
+		record adr.Record
+		input  string
+		wants  string
+		err    error
+	}{
+		{
+			name: "existing history",
+			input: `# Use PostgreSQL
+
+## Status
+
+Accepted
+
+<!-- docula:status-history -->
+- 2022-07-01: Proposed
+- 2022-07-14: Accepted by Jane Doe
+<!-- /docula:status-history -->
+
+## Context
+`,
+			wants: `# Use PostgreSQL
+
+## Status
+
+Deprecated
+
+<!-- docula:status-history -->
+- 2022-07-01: Proposed
+- 2022-07-14: Accepted by Jane Doe
+- 2022-07-20: Deprecated by John Smith
+<!-- /docula:status-history -->
+
+## Context
+`,
+		},
+		{
+			name: "inline status without a date",
+			record: adr.Record{
+				Status: "Accepted",
+			},
+			input: `# Use PostgreSQL
+
+Status: Accepted
+
+## Context
+`,
+			wants: `# Use PostgreSQL
+
+Status: Deprecated
+
+<!-- docula:status-history -->
+- 2022-07-20: Deprecated by John Smith
+<!-- /docula:status-history -->
+
+## Context
+`,
+		},
+		{
+			name: "madr status bullet",
+			record: adr.Record{
+				Status: "Accepted",
+				Date:   time.Date(2022, 7, 14, 0, 0, 0, 0, time.UTC),
+			},
+			input: `# Use PostgreSQL
+
+* Status: Accepted
+* Date: 2022-07-14
+
+## Context and Problem Statement
+`,
+			wants: `# Use PostgreSQL
+
+* Status: Deprecated
+* Date: 2022-07-14
+
+<!-- docula:status-history -->
+- 2022-07-14: Accepted
+- 2022-07-20: Deprecated by John Smith
+<!-- /docula:status-history -->
+
+## Context and Problem Statement
+`,
+		},
+		{
+			name: "frontmatter",
+			record: adr.Record{
+				Status: "Accepted",
+				Date:   time.Date(2022, 7, 14, 0, 0, 0, 0, time.UTC),
+			},
+			input: `---
+title: Use PostgreSQL
+status: Accepted
+date: 2022-07-14
+---
+
+# Use PostgreSQL
+`,
+			wants: `---
+title: Use PostgreSQL
+status: Deprecated
+date: 2022-07-14
+history:
+  - date: "2022-07-14"
+    status: Accepted
+  - date: "2022-07-20"
+    status: Deprecated
+    by: John Smith
+---
+
+# Use PostgreSQL
+`,
+		},
+		{
+			name:  "no status",
+			input: "# Use PostgreSQL\n\n## Context\n\nStatus: ignored\n",
+			err:   adr.ErrNoStatus,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := adr.SetStatus([]byte(tt.input), tt.record, change)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, string(res))
+
+			rec, err := adr.ParseRecord("0001-use-postgresql.md", res)
+			assert.NoError(t, err)
+			assert.Equal(t, "Deprecated", rec.Status)
+			assert.Equal(t, change, rec.History[len(rec.History)-1])
+		})
+	}
+}
+
+func TestSeedHistory(t *testing.T) {
+	date := time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		record adr.Record
+		input  string
+		wants  string
+		err    error
+	}{
+		{
+			name:   "adr-tools record",
+			record: adr.Record{Status: "Superseded by [2. Use Redis](0002-use-redis.md)", Date: date},
+			input: `# 1. Use PostgreSQL
+
+Date: 2016-02-12
+
+## Status
+
+Superseded by [2. Use Redis](0002-use-redis.md)
+
+## Context
+`,
+			wants: `# 1. Use PostgreSQL
+
+Date: 2016-02-12
+
+## Status
+
+Superseded by [2. Use Redis](0002-use-redis.md)
+
+<!-- docula:status-history -->
+- 2016-02-12: Accepted
+<!-- /docula:status-history -->
+
+## Context
+`,
+		},
+		{
+			name:   "rejected record",
+			record: adr.Record{Status: "Rejected", Date: date},
+			input:  "# Use PostgreSQL\n\n## Status\n\nRejected\n",
+			wants:  "# Use PostgreSQL\n\n## Status\n\nRejected\n\n<!-- docula:status-history -->\n- 2016-02-12: Proposed\n<!-- /docula:status-history -->\n",
+		},
+		{
+			name:   "existing history",
+			record: adr.Record{Status: "Accepted", Date: date},
+			input:  "# Use PostgreSQL\n\nStatus: Accepted\n\n<!-- docula:status-history -->\n- 2016-02-12: Accepted\n<!-- /docula:status-history -->\n",
+			wants:  "# Use PostgreSQL\n\nStatus: Accepted\n\n<!-- docula:status-history -->\n- 2016-02-12: Accepted\n<!-- /docula:status-history -->\n",
+		},
+		{
+			name:   "no date",
+			record: adr.Record{Status: "Accepted"},
+			input:  "# Use PostgreSQL\n\n## Status\n\nAccepted\n",
+			wants:  "# Use PostgreSQL\n\n## Status\n\nAccepted\n",
+		},
+		{
+			name:  "no status",
+			input: "# Use PostgreSQL\n",
+			err:   adr.ErrNoStatus,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := adr.SeedHistory([]byte(tt.input), tt.record)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, string(res))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=store -mock_names FileSystem=mockFileSystem,File=mockFile
+
+package store
+
+import (
+	"io"
+	"os"
+)
+
+// FileSystem represents a type that is able to manipulate the filesystem.
+// This interface is typically a wrapper around the os package methods and
+// is used to allow for improved testing.
+type FileSystem interface {
+	Create(name string) (File, error)
+	ReadDir(name string) ([]os.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Remove(name string) error
+	Rename(oldpath string, newpath string) error
+}
+
+// File provides an interface for an os.File to allow for testing without
+// making modifications to the file system.
+type File interface {
+	Write([]byte) (int, error)
+	io.Closer
+}
@@ -0,0 +1,3 @@
+// Package store provides access to the decision records that are kept within
+// the configured adr directories.
+package store
@@ -0,0 +1,25 @@
+package store
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) Create(name string) (File, error) {
+	return os.Create(name)
+}
+
+func (f *defaultFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (f *defaultFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (f *defaultFileSystem) Rename(oldpath string, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
@@ -0,0 +1,12 @@
+package store
+
+// Option represents a type that is able to override the default resources of
+// the store. These options are mainly used in a testing capacity.
+type Option func(s *Store)
+
+// WithFileSystem is used to override the internal FileSystem of the store.
+func WithFileSystem(fs FileSystem) Option {
+	return func(s *Store) {
+		s.fs = fs
+	}
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+)
+
+var (
+	// ErrRecordNotFound is returned when no record matches an identifier.
+	ErrRecordNotFound = errors.New("record not found")
+
+	// ErrAmbiguousID is returned when an identifier matches records in more
+	// than one adr directory.
+	ErrAmbiguousID = errors.New("identifier matches more than one record")
+)
+
+// Store provides access to the records of the adr directories.
+type Store struct {
+	fs FileSystem
+}
+
+// New acts as the default constructor for the Store type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Store {
+	s := &Store{
+		fs: &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// InvalidRecord represents a file that is named like a record, but whose
+// metadata cannot be parsed.
+type InvalidRecord struct {
+	// Path is the location of the file, relative to the state dir.
+	Path string
+	Err  error
+}
+
+// Scan parses every record found within the given adr directory. Files that
+// are not named like a record, such as a README, are ignored. Records that
+// cannot be parsed are returned separately, so that one broken record does
+// not hide the others.
+func (s *Store) Scan(stateDir string, dir adr.Directory) ([]adr.Record, []InvalidRecord, error) {
+	entries, err := s.fs.ReadDir(fmt.Sprintf("%s%s", stateDir, dir.Path))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read adr dir: %w", err)
+	}
+
+	records := make([]adr.Record, 0, len(entries))
+
+	var invalid []InvalidRecord
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := fmt.Sprintf("%s/%s", dir.Path, entry.Name())
+
+		if _, err = adr.RecordID(entry.Name()); errors.Is(err, adr.ErrNotRecord) {
+			continue
+		}
+
+		data, err := s.fs.ReadFile(stateDir + path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read record: %w", err)
+		}
+
+		rec, err := adr.ParseRecord(entry.Name(), data)
+		if err != nil {
+			invalid = append(invalid, InvalidRecord{Path: path, Err: err})
+			continue
+		}
+
+		rec.Dir = dir.Name
+		rec.Path = path
+
+		records = append(records, rec)
+	}
+
+	return records, invalid, nil
+}
+
+// List parses every record found within the given adr directory, like Scan,
+// skipping the records that cannot be parsed.
+func (s *Store) List(stateDir string, dir adr.Directory) ([]adr.Record, error) {
+	records, _, err := s.Scan(stateDir, dir)
+
+	return records, err
+}
+
+// Find returns the record with the given identifier from the given adr
+// directories. The identifier must match exactly one record, and it can be
+// qualified with the name or path of an adr directory, such as "security:12".
+func (s *Store) Find(stateDir string, dirs []adr.Directory, id string) (adr.Record, error) {
+	var found []adr.Record
+
+	if i := strings.LastIndex(id, ":"); i >= 0 {
+		dir, ok := adr.State{Directories: dirs}.FindDirectory(id[:i])
+		if !ok {
+			return adr.Record{}, fmt.Errorf("%w: %s", adr.ErrDirNotFound, id[:i])
+		}
+
+		dirs = []adr.Directory{dir}
+		id = id[i+1:]
+	}
+
+	for _, dir := range dirs {
+		records, err := s.List(stateDir, dir)
+		if err != nil {
+			return adr.Record{}, err
+		}
+
+		for _, rec := range records {
+			if adr.SameID(rec.ID, id) {
+				found = append(found, rec)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return adr.Record{}, fmt.Errorf("%w: %s", ErrRecordNotFound, id)
+	case 1:
+		return found[0], nil
+	}
+
+	return adr.Record{}, fmt.Errorf("%w: %s", ErrAmbiguousID, id)
+}
+
+// Read returns the contents of the file at the given path.
+func (s *Store) Read(path string) ([]byte, error) {
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read record: %w", err)
+	}
+
+	return data, nil
+}
+
+// Write replaces the contents of the file at the given path. The data is
+// written to a temporary file first, which is then renamed over the original
+// file, so the file is left untouched if the write fails.
+func (s *Store) Write(path string, data []byte) error {
+	tmpPath := fmt.Sprintf("%s.tmp", path)
+
+	if err := s.writeTmp(tmpPath, data); err != nil {
+		return err
+	}
+
+	if err := s.fs.Rename(tmpPath, path); err != nil {
+		s.fs.Remove(tmpPath)
+		return fmt.Errorf("renaming tmp buffer: %w", err)
+	}
+
+	return nil
+}
+
+// Change represents the new contents of a file.
+type Change struct {
+	Path string
+	Data []byte
+}
+
+// Apply writes each of the changes such that either all of them or none of
+// them take effect. Every change is written to a temporary file before any
+// file is replaced, and replaced files are restored if a later one fails.
+func (s *Store) Apply(changes ...Change) error {
+	originals := make([][]byte, len(changes))
+
+	for i, change := range changes {
+		data, err := s.fs.ReadFile(change.Path)
+		if err != nil {
+			return fmt.Errorf("read original: %w", err)
+		}
+
+		originals[i] = data
+	}
+
+	tmpPaths := make([]string, 0, len(changes))
+
+	removeTmps := func() {
+		for _, tmpPath := range tmpPaths {
+			s.fs.Remove(tmpPath)
+		}
+	}
+
+	for _, change := range changes {
+		tmpPath := fmt.Sprintf("%s.tmp", change.Path)
+
+		if err := s.writeTmp(tmpPath, change.Data); err != nil {
+			removeTmps()
+			return err
+		}
+
+		tmpPaths = append(tmpPaths, tmpPath)
+	}
+
+	for i, change := range changes {
+		if err := s.fs.Rename(tmpPaths[i], change.Path); err != nil {
+			removeTmps()
+
+			for j := 0; j < i; j++ {
+				s.Write(changes[j].Path, originals[j])
+			}
+
+			return fmt.Errorf("renaming tmp buffer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) writeTmp(tmpPath string, data []byte) error {
+	tmp, err := s.fs.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("creating tmp buffer: %w", err)
+	}
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		s.fs.Remove(tmpPath)
+		return fmt.Errorf("writing to buffer: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		s.fs.Remove(tmpPath)
+		return fmt.Errorf("closing tmp buffer: %w", err)
+	}
+
+	return nil
+}
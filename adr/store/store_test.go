@@ -0,0 +1,437 @@
+package store_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+)
+
+type dirEntry struct {
+	name string
+	dir  bool
+}
+
+func (d dirEntry) Name() string               { return d.name }
+func (d dirEntry) IsDir() bool                { return d.dir }
+func (d dirEntry) Type() fs.FileMode          { return 0 }
+func (d dirEntry) Info() (fs.FileInfo, error) { return nil, nil }
+
+var dir = adr.Directory{Path: "docs/adr", Name: "platform"}
+
+func TestStoreList(t *testing.T) {
+	type want struct {
+		records []adr.Record
+		err     error
+	}
+
+	testCases := []struct {
+		name  string
+		setup func(ctrl *gomock.Controller) store.FileSystem
+		wants want
+	}{
+		{
+			name: "records are parsed and other files ignored",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadDir("/home/me/docs/adr").Return([]os.DirEntry{
+					dirEntry{name: "0001-record-decisions.md"},
+					dirEntry{name: "0002-assets", dir: true},
+					dirEntry{name: "README.md"},
+				}, nil)
+				fs.EXPECT().ReadFile("/home/me/docs/adr/0001-record-decisions.md").Return(
+					[]byte("# Record decisions\n\n## Status\n\nAccepted\n"), nil,
+				)
+				return fs
+			},
+			wants: want{
+				records: []adr.Record{
+					{
+						ID:     "0001",
+						Title:  "Record decisions",
+						Status: "Accepted",
+						Dir:    "platform",
+						Path:   "docs/adr/0001-record-decisions.md",
+						Style:  adr.StyleInline,
+					},
+				},
+			},
+		},
+		{
+			name: "failing to read the dir",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadDir("/home/me/docs/adr").Return(nil, os.ErrNotExist)
+				return fs
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+		{
+			name: "failing to read a record",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadDir("/home/me/docs/adr").Return([]os.DirEntry{
+					dirEntry{name: "0001-record-decisions.md"},
+				}, nil)
+				fs.EXPECT().ReadFile("/home/me/docs/adr/0001-record-decisions.md").Return(nil, os.ErrPermission)
+				return fs
+			},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := store.New(store.WithFileSystem(tt.setup(ctrl)))
+
+			records, err := s.List("/home/me/", dir)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.records, records)
+		})
+	}
+}
+
+func TestStoreScan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fs := store.NewmockFileSystem(ctrl)
+	fs.EXPECT().ReadDir("/docs/adr").Return([]os.DirEntry{
+		dirEntry{name: "0001-record-decisions.md"},
+		dirEntry{name: "0002-use-kafka.md"},
+	}, nil).Times(2)
+	fs.EXPECT().ReadFile("/docs/adr/0001-record-decisions.md").Return(
+		[]byte("---\ntitle: [Record decisions\n---\n"), nil,
+	).Times(2)
+	fs.EXPECT().ReadFile("/docs/adr/0002-use-kafka.md").Return(
+		[]byte("# Use Kafka\n\n## Status\n\nAccepted\n"), nil,
+	).Times(2)
+
+	s := store.New(store.WithFileSystem(fs))
+
+	kafka := adr.Record{
+		ID:     "0002",
+		Title:  "Use Kafka",
+		Status: "Accepted",
+		Dir:    "platform",
+		Path:   "docs/adr/0002-use-kafka.md",
+		Style:  adr.StyleInline,
+	}
+
+	records, invalid, err := s.Scan("/", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []adr.Record{kafka}, records)
+	assert.Len(t, invalid, 1)
+	assert.Equal(t, "docs/adr/0001-record-decisions.md", invalid[0].Path)
+	assert.ErrorIs(t, invalid[0].Err, adr.ErrInvalidFrontmatter)
+
+	records, err = s.List("/", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []adr.Record{kafka}, records)
+}
+
+func TestStoreFind(t *testing.T) {
+	other := adr.Directory{Path: "security", Name: "security"}
+
+	setup := func(ctrl *gomock.Controller) store.FileSystem {
+		fs := store.NewmockFileSystem(ctrl)
+		fs.EXPECT().ReadDir("/docs/adr").Return([]os.DirEntry{
+			dirEntry{name: "0001-record-decisions.md"},
+			dirEntry{name: "0012-use-grpc.md"},
+		}, nil).AnyTimes()
+		fs.EXPECT().ReadDir("/security").Return([]os.DirEntry{
+			dirEntry{name: "0001-add-mtls.md"},
+		}, nil).AnyTimes()
+		fs.EXPECT().ReadFile(gomock.Any()).Return([]byte("# Title\n"), nil).AnyTimes()
+		return fs
+	}
+
+	type want struct {
+		path string
+		err  error
+	}
+
+	testCases := []struct {
+		name  string
+		id    string
+		wants want
+	}{
+		{
+			name: "unpadded id",
+			id:   "12",
+			wants: want{
+				path: "docs/adr/0012-use-grpc.md",
+			},
+		},
+		{
+			name: "ambiguous id",
+			id:   "0001",
+			wants: want{
+				err: store.ErrAmbiguousID,
+			},
+		},
+		{
+			name: "missing id",
+			id:   "7",
+			wants: want{
+				err: store.ErrRecordNotFound,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := store.New(store.WithFileSystem(setup(ctrl)))
+
+			rec, err := s.Find("/", []adr.Directory{dir, other}, tt.id)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.path, rec.Path)
+		})
+	}
+}
+
+func TestStoreWrite(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(ctrl *gomock.Controller) store.FileSystem
+		wants error
+	}{
+		{
+			name: "happy path",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				f := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().Create("/docs/0001-foo.md.tmp").Return(f, nil),
+					f.EXPECT().Write([]byte("foo")).Return(3, nil),
+					f.EXPECT().Close().Return(nil),
+					fs.EXPECT().Rename("/docs/0001-foo.md.tmp", "/docs/0001-foo.md").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "failing to create tmp buffer",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				fs.EXPECT().Create("/docs/0001-foo.md.tmp").Return(nil, os.ErrPermission)
+				return fs
+			},
+			wants: os.ErrPermission,
+		},
+		{
+			name: "failing to write to tmp buffer",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				f := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().Create("/docs/0001-foo.md.tmp").Return(f, nil),
+					f.EXPECT().Write([]byte("foo")).Return(0, os.ErrInvalid),
+					f.EXPECT().Close().Return(nil),
+					fs.EXPECT().Remove("/docs/0001-foo.md.tmp").Return(nil),
+				)
+
+				return fs
+			},
+			wants: os.ErrInvalid,
+		},
+		{
+			name: "failing to rename tmp buffer",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				f := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().Create("/docs/0001-foo.md.tmp").Return(f, nil),
+					f.EXPECT().Write([]byte("foo")).Return(3, nil),
+					f.EXPECT().Close().Return(nil),
+					fs.EXPECT().Rename("/docs/0001-foo.md.tmp", "/docs/0001-foo.md").Return(os.ErrInvalid),
+					fs.EXPECT().Remove("/docs/0001-foo.md.tmp").Return(nil),
+				)
+
+				return fs
+			},
+			wants: os.ErrInvalid,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := store.New(store.WithFileSystem(tt.setup(ctrl)))
+
+			err := s.Write("/docs/0001-foo.md", []byte("foo"))
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStoreFindQualified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	other := adr.Directory{Path: "security", Name: "security"}
+
+	fs := store.NewmockFileSystem(ctrl)
+	fs.EXPECT().ReadDir("/security").Return([]os.DirEntry{
+		dirEntry{name: "0001-add-mtls.md"},
+	}, nil)
+	fs.EXPECT().ReadFile("/security/0001-add-mtls.md").Return([]byte("# Add mTLS\n"), nil)
+
+	s := store.New(store.WithFileSystem(fs))
+
+	rec, err := s.Find("/", []adr.Directory{dir, other}, "security:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "security/0001-add-mtls.md", rec.Path)
+
+	_, err = s.Find("/", []adr.Directory{dir, other}, "nope:1")
+	assert.ErrorIs(t, err, adr.ErrDirNotFound)
+}
+
+func TestStoreApply(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(ctrl *gomock.Controller) store.FileSystem
+		wants error
+	}{
+		{
+			name: "happy path",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				a := store.NewmockFile(ctrl)
+				b := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().ReadFile("/a.md").Return([]byte("a"), nil),
+					fs.EXPECT().ReadFile("/b.md").Return([]byte("b"), nil),
+					fs.EXPECT().Create("/a.md.tmp").Return(a, nil),
+					a.EXPECT().Write([]byte("A")).Return(1, nil),
+					a.EXPECT().Close().Return(nil),
+					fs.EXPECT().Create("/b.md.tmp").Return(b, nil),
+					b.EXPECT().Write([]byte("B")).Return(1, nil),
+					b.EXPECT().Close().Return(nil),
+					fs.EXPECT().Rename("/a.md.tmp", "/a.md").Return(nil),
+					fs.EXPECT().Rename("/b.md.tmp", "/b.md").Return(nil),
+				)
+
+				return fs
+			},
+		},
+		{
+			name: "failing to write the second file",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				a := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().ReadFile("/a.md").Return([]byte("a"), nil),
+					fs.EXPECT().ReadFile("/b.md").Return([]byte("b"), nil),
+					fs.EXPECT().Create("/a.md.tmp").Return(a, nil),
+					a.EXPECT().Write([]byte("A")).Return(1, nil),
+					a.EXPECT().Close().Return(nil),
+					fs.EXPECT().Create("/b.md.tmp").Return(nil, os.ErrPermission),
+					fs.EXPECT().Remove("/a.md.tmp").Return(nil),
+				)
+
+				return fs
+			},
+			wants: os.ErrPermission,
+		},
+		{
+			name: "failing to rename the second file restores the first",
+			setup: func(ctrl *gomock.Controller) store.FileSystem {
+				fs := store.NewmockFileSystem(ctrl)
+				a := store.NewmockFile(ctrl)
+				b := store.NewmockFile(ctrl)
+				restore := store.NewmockFile(ctrl)
+
+				gomock.InOrder(
+					fs.EXPECT().ReadFile("/a.md").Return([]byte("a"), nil),
+					fs.EXPECT().ReadFile("/b.md").Return([]byte("b"), nil),
+					fs.EXPECT().Create("/a.md.tmp").Return(a, nil),
+					a.EXPECT().Write([]byte("A")).Return(1, nil),
+					a.EXPECT().Close().Return(nil),
+					fs.EXPECT().Create("/b.md.tmp").Return(b, nil),
+					b.EXPECT().Write([]byte("B")).Return(1, nil),
+					b.EXPECT().Close().Return(nil),
+					fs.EXPECT().Rename("/a.md.tmp", "/a.md").Return(nil),
+					fs.EXPECT().Rename("/b.md.tmp", "/b.md").Return(os.ErrInvalid),
+					fs.EXPECT().Remove("/a.md.tmp").Return(os.ErrNotExist),
+					fs.EXPECT().Remove("/b.md.tmp").Return(nil),
+					fs.EXPECT().Create("/a.md.tmp").Return(restore, nil),
+					restore.EXPECT().Write([]byte("a")).Return(1, nil),
+					restore.EXPECT().Close().Return(nil),
+					fs.EXPECT().Rename("/a.md.tmp", "/a.md").Return(nil),
+				)
+
+				return fs
+			},
+			wants: os.ErrInvalid,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := store.New(store.WithFileSystem(tt.setup(ctrl)))
+
+			err := s.Apply(
+				store.Change{Path: "/a.md", Data: []byte("A")},
+				store.Change{Path: "/b.md", Data: []byte("B")},
+			)
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package adr
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	openTask = regexp.MustCompile(`^\s*[-*+] \[ \]\s+(.+)$`)
+	mention  = regexp.MustCompile(`(?:^|[\s(,])(@[A-Za-z0-9][A-Za-z0-9._/-]*[A-Za-z0-9]|@[A-Za-z0-9])`)
+)
+
+// Task is an unchecked item of a task list within a record, such as a follow
+// up of its consequences.
+type Task struct {
+	// Line is the line number of the task within the record, starting at 1.
+	Line int
+	// Text is the text of the task, without its checkbox.
+	Text string
+	// Owners holds the people or teams mentioned by the task, such as @jane.
+	Owners []string
+}
+
+// OpenTasks returns the unchecked task list items of the record markdown,
+// in the order they appear. Items within code blocks are left out.
+func OpenTasks(data []byte) []Task {
+	_, body, _ := splitFrontmatter(data)
+	offset := strings.Count(string(data[:len(data)-len(body)]), "\n")
+
+	var (
+		tasks []Task
+		fence bool
+	)
+
+	for i, line := range strings.Split(string(body), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fence = !fence
+			continue
+		}
+
+		match := openTask.FindStringSubmatch(line)
+		if fence || match == nil {
+			continue
+		}
+
+		task := Task{Line: offset + i + 1, Text: strings.TrimSpace(match[1])}
+
+		for _, m := range mention.FindAllStringSubmatch(task.Text, -1) {
+			if _, ok := find(task.Owners, m[1]); !ok {
+				task.Owners = append(task.Owners, m[1])
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
@@ -0,0 +1,36 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+)
+
+func TestOpenTasks(t *testing.T) {
+	input := "---\n" +
+		"status: Accepted\n" +
+		"---\n" +
+		"# Use Kafka\n" +
+		"\n" +
+		"## Consequences\n" +
+		"\n" +
+		"- [ ] Add consumer lag alerts @sre, @Jane\n" +
+		"- [x] Provision the cluster @sre\n" +
+		"  * [ ] Document the topics (@org/platform-team) and mail jane@example.com\n" +
+		"- [ ]\n" +
+		"\n" +
+		"```\n" +
+		"- [ ] not a task\n" +
+		"```\n" +
+		"+ [ ] Migrate the billing events @jane.\n"
+
+	assert.Equal(t, []adr.Task{
+		{Line: 8, Text: "Add consumer lag alerts @sre, @Jane", Owners: []string{"@sre", "@Jane"}},
+		{Line: 10, Text: "Document the topics (@org/platform-team) and mail jane@example.com", Owners: []string{"@org/platform-team"}},
+		{Line: 16, Text: "Migrate the billing events @jane.", Owners: []string{"@jane"}},
+	}, adr.OpenTasks([]byte(input)))
+
+	assert.Empty(t, adr.OpenTasks([]byte("# Use Kafka\n\n- [X] Done\n")))
+}
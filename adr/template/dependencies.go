@@ -0,0 +1,10 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=template -mock_names FileSystem=mockFileSystem
+
+package template
+
+// FileSystem represents a type that is able to read from the filesystem.
+// This interface is typically a wrapper around the os package methods and
+// is used to allow for improved testing.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+}
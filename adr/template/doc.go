@@ -0,0 +1,4 @@
+// Package template provides the templates that new decision records are
+// rendered from. Docula ships with a set of built-in templates, and an adr
+// directory can instead use a custom template file from within the project.
+package template
@@ -0,0 +1,9 @@
+package template
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
@@ -0,0 +1,46 @@
+package template
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// Infer returns the name of the built-in template that the record appears
+// to have been written from, based on its section headings and fields. An
+// empty string is returned when the layout is not recognised.
+func Infer(data []byte) string {
+	sections := map[string]bool{}
+
+	var deciders, yStatement bool
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+
+		switch {
+		case strings.HasPrefix(line, "## "):
+			sections[strings.TrimSpace(line[3:])] = true
+		case strings.HasPrefix(strings.TrimLeft(line, "*- "), "deciders:"):
+			deciders = true
+		case strings.HasPrefix(line, "in the context of"):
+			yStatement = true
+		}
+	}
+
+	switch {
+	case sections["context and problem statement"] || sections["decision outcome"]:
+		if deciders || sections["decision drivers"] || sections["pros and cons of the options"] {
+			return MADR
+		}
+
+		return MADRMinimal
+	case yStatement && sections["decision"] && !sections["context"]:
+		return YStatement
+	case sections["context"] && sections["decision"]:
+		return Nygard
+	}
+
+	return ""
+}
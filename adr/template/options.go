@@ -0,0 +1,12 @@
+package template
+
+// Option represents a type that is able to override the default resources of
+// the renderer. These options are mainly used in a testing capacity.
+type Option func(r *Renderer)
+
+// WithFileSystem is used to override the internal FileSystem of the renderer.
+func WithFileSystem(fs FileSystem) Option {
+	return func(r *Renderer) {
+		r.fs = fs
+	}
+}
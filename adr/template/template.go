@@ -0,0 +1,151 @@
+package template
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// The names of the built-in templates.
+const (
+	Nygard      = "nygard"
+	MADR        = "madr"
+	MADRMinimal = "madr-minimal"
+	YStatement  = "y-statement"
+
+	// Default is the template used by directories that do not choose one.
+	Default = Nygard
+)
+
+// ErrInvalidTemplate is returned when a custom template lies outside of the
+// project, or cannot be parsed.
+var ErrInvalidTemplate = errors.New("invalid template")
+
+//go:embed builtin/*.md.tmpl
+var builtin embed.FS
+
+// Data represents the values that are available to a template.
+type Data struct {
+	Dir    adr.Directory
+	ID     string
+	Title  string
+	Status string
+	Date   time.Time
+
+	// Deciders, Consulted and Informed hold the people or teams involved in
+	// the decision, if any were given.
+	Deciders  []string
+	Consulted []string
+	Informed  []string
+}
+
+// funcs are the functions available to a template, in addition to the
+// built-in functions of text/template.
+var funcs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// Names returns the names of the built-in templates.
+func Names() []string {
+	return []string{Nygard, MADR, MADRMinimal, YStatement}
+}
+
+// IsBuiltin reports whether the name refers to a built-in template.
+func IsBuiltin(name string) bool {
+	for _, n := range Names() {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Used returns the names of the built-in templates along with the custom
+// templates of the adr dirs, which are all the templates their records can
+// have been written from.
+func Used(dirs []adr.Directory) []string {
+	names := Names()
+
+	for _, dir := range dirs {
+		if dir.Template != "" && !IsBuiltin(dir.Template) {
+			names = append(names, dir.Template)
+		}
+	}
+
+	return names
+}
+
+// Renderer renders new records from the built-in or custom templates.
+type Renderer struct {
+	fs FileSystem
+}
+
+// New acts as the default constructor for the Renderer type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Renderer {
+	r := &Renderer{
+		fs: &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Source returns the source of the named template. The name is either one
+// of the built-in templates, or the path of a custom template relative to
+// the state dir. An empty name refers to the default template.
+func (r *Renderer) Source(stateDir string, name string) ([]byte, error) {
+	if name == "" {
+		name = Default
+	}
+
+	if IsBuiltin(name) {
+		return builtin.ReadFile(fmt.Sprintf("builtin/%s.md.tmpl", name))
+	}
+
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return nil, fmt.Errorf("%w: %s is outside of the project", ErrInvalidTemplate, name)
+	}
+
+	data, err := r.fs.ReadFile(stateDir + clean)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+
+	return data, nil
+}
+
+// Render renders a record from the named template.
+func (r *Renderer) Render(stateDir string, name string, data Data) ([]byte, error) {
+	source, err := r.Source(stateDir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTemplate, err)
+	}
+
+	buffer := &bytes.Buffer{}
+
+	if err = tmpl.Execute(buffer, data); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,178 @@
+package template_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/template"
+)
+
+func TestRenderBuiltin(t *testing.T) {
+	data := template.Data{
+		Dir:    adr.Directory{Name: "default", Path: "docs/adr"},
+		ID:     "0001",
+		Title:  "Use PostgreSQL",
+		Status: "Proposed",
+		Date:   time.Date(2022, 7, 14, 0, 0, 0, 0, time.UTC),
+
+		Deciders:  []string{"@jane", "@john"},
+		Consulted: []string{"@dba"},
+		Informed:  []string{"@platform/sre"},
+	}
+
+	for _, name := range append(template.Names(), "") {
+		name := name
+
+		t.Run("template "+name, func(t *testing.T) {
+			res, err := template.New().Render("/", name, data)
+			assert.NoError(t, err)
+
+			rec, err := adr.ParseRecord("0001-use-postgresql.md", res)
+			assert.NoError(t, err)
+			assert.Equal(t, "Use PostgreSQL", rec.Title)
+			assert.Equal(t, "Proposed", rec.Status)
+			assert.Equal(t, data.Deciders, rec.Deciders)
+			assert.Equal(t, data.Consulted, rec.Consulted)
+			assert.Equal(t, data.Informed, rec.Informed)
+		})
+	}
+}
+
+func TestRenderBuiltinWithoutPeople(t *testing.T) {
+	data := template.Data{
+		Dir:    adr.Directory{Name: "default", Path: "docs/adr"},
+		ID:     "0001",
+		Title:  "Use PostgreSQL",
+		Status: "Proposed",
+		Date:   time.Date(2022, 7, 14, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, name := range append(template.Names(), "") {
+		name := name
+
+		t.Run("template "+name, func(t *testing.T) {
+			res, err := template.New().Render("/", name, data)
+			assert.NoError(t, err)
+
+			rec, err := adr.ParseRecord("0001-use-postgresql.md", res)
+			assert.NoError(t, err)
+			assert.Equal(t, "Proposed", rec.Status)
+			assert.Empty(t, rec.Deciders)
+			assert.Empty(t, rec.Consulted)
+			assert.Empty(t, rec.Informed)
+		})
+	}
+}
+
+func TestRenderCustom(t *testing.T) {
+	data := template.Data{ID: "0002", Title: "Use Redis", Status: "Draft"}
+
+	testCases := []struct {
+		name  string
+		tmpl  string
+		fs    func(ctrl *gomock.Controller) template.FileSystem
+		wants string
+		err   error
+	}{
+		{
+			name: "template in the project",
+			tmpl: "docs/templates/../decision.md",
+			fs: func(ctrl *gomock.Controller) template.FileSystem {
+				fs := template.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadFile("/project/docs/decision.md").Return(
+					[]byte("# {{ .ID }}. {{ .Title }}\n\nStatus: {{ .Status }}\n"), nil,
+				)
+				return fs
+			},
+			wants: "# 0002. Use Redis\n\nStatus: Draft\n",
+		},
+		{
+			name: "template outside of the project",
+			tmpl: "../decision.md",
+			fs: func(ctrl *gomock.Controller) template.FileSystem {
+				return template.NewmockFileSystem(ctrl)
+			},
+			err: template.ErrInvalidTemplate,
+		},
+		{
+			name: "absolute template path",
+			tmpl: "/etc/decision.md",
+			fs: func(ctrl *gomock.Controller) template.FileSystem {
+				return template.NewmockFileSystem(ctrl)
+			},
+			err: template.ErrInvalidTemplate,
+		},
+		{
+			name: "missing template",
+			tmpl: "decision.md",
+			fs: func(ctrl *gomock.Controller) template.FileSystem {
+				fs := template.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadFile("/project/decision.md").Return(nil, os.ErrNotExist)
+				return fs
+			},
+			err: os.ErrNotExist,
+		},
+		{
+			name: "unparsable template",
+			tmpl: "decision.md",
+			fs: func(ctrl *gomock.Controller) template.FileSystem {
+				fs := template.NewmockFileSystem(ctrl)
+				fs.EXPECT().ReadFile("/project/decision.md").Return([]byte("# {{ .Title"), nil)
+				return fs
+			},
+			err: template.ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := template.New(template.WithFileSystem(tt.fs(ctrl)))
+
+			res, err := r.Render("/project/", tt.tmpl, data)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wants, string(res))
+		})
+	}
+}
+
+func TestInfer(t *testing.T) {
+	data := template.Data{Title: "Use PostgreSQL", Status: "Proposed"}
+
+	for _, name := range template.Names() {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			res, err := template.New().Render("/", name, data)
+			assert.NoError(t, err)
+
+			assert.Equal(t, name, template.Infer(res))
+		})
+	}
+
+	t.Run("log4brains", func(t *testing.T) {
+		res := template.Infer([]byte("# Use PostgreSQL\n\n- Status: accepted\n- Deciders: Jane\n\n" +
+			"## Context and Problem Statement\n\n## Decision Outcome\n"))
+
+		assert.Equal(t, template.MADR, res)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "", template.Infer([]byte("# Notes\n\nSome text.\n")))
+	})
+}
@@ -0,0 +1,178 @@
+package adr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownStatus is returned when a status is not part of the workflow
+	// of a directory.
+	ErrUnknownStatus = errors.New("unknown status")
+
+	// ErrInvalidWorkflow is returned when the workflow of a directory is not
+	// consistent, such as a transition to a status that is not declared.
+	ErrInvalidWorkflow = errors.New("invalid workflow")
+)
+
+// Workflow describes the statuses a record of an adr directory can have, and
+// the transitions that are allowed between them.
+type Workflow struct {
+	// Statuses lists every status a record can have.
+	Statuses []string `yaml:"statuses"`
+	// Initial is the status of a new record. It defaults to the first status.
+	Initial string `yaml:"initial,omitempty"`
+	// Transitions maps a status to the statuses a record can move to.
+	Transitions map[string][]string `yaml:"transitions"`
+	// Terminal lists the statuses a record cannot move on from.
+	Terminal []string `yaml:"terminal,omitempty"`
+}
+
+// DefaultWorkflow returns the workflow used by directories that do not
+// declare their own.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []string{
+			StatusDraft, StatusProposed, StatusAccepted,
+			StatusRejected, StatusDeprecated, StatusSuperseded,
+		},
+		Initial: StatusProposed,
+		Transitions: map[string][]string{
+			StatusDraft:    {StatusProposed},
+			StatusProposed: {StatusAccepted, StatusRejected},
+			StatusAccepted: {StatusDeprecated, StatusSuperseded},
+		},
+		Terminal: []string{StatusRejected, StatusDeprecated, StatusSuperseded},
+	}
+}
+
+// StatusWorkflow returns the workflow of the directory, which is the default
+// workflow unless the directory declares its own.
+func (d Directory) StatusWorkflow() Workflow {
+	if d.Workflow == nil {
+		return DefaultWorkflow()
+	}
+
+	return *d.Workflow
+}
+
+func find(values []string, value string) (string, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Status returns the status as it is spelled in the workflow. Any trailing
+// detail of the status, such as the record that superseded it, is ignored.
+func (w Workflow) Status(status string) (string, bool) {
+	if s, ok := find(w.Statuses, status); ok {
+		return s, true
+	}
+
+	return find(w.Statuses, BaseStatus(status))
+}
+
+// InitialStatus returns the status of a new record.
+func (w Workflow) InitialStatus() string {
+	if w.Initial != "" {
+		return w.Initial
+	}
+
+	if len(w.Statuses) > 0 {
+		return w.Statuses[0]
+	}
+
+	return StatusProposed
+}
+
+// IsTerminal reports whether a record cannot move on from the status.
+func (w Workflow) IsTerminal(status string) bool {
+	s, ok := w.Status(status)
+	if !ok {
+		return false
+	}
+
+	_, ok = find(w.Terminal, s)
+
+	return ok
+}
+
+// CanTransition reports whether a record is allowed to move from one status
+// to another. Statuses are compared without regard to case.
+func (w Workflow) CanTransition(from, to string) bool {
+	from, ok := w.Status(from)
+	if !ok || w.IsTerminal(from) {
+		return false
+	}
+
+	for status, next := range w.Transitions {
+		if !strings.EqualFold(status, from) {
+			continue
+		}
+
+		if _, ok := find(next, to); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PriorStatus returns the status a record held before it reached the given
+// terminal status, such as Accepted for Superseded, which is the first
+// status in the order of Statuses that moves on to it. Any other status is
+// returned unchanged.
+func (w Workflow) PriorStatus(status string) string {
+	if !w.IsTerminal(status) {
+		return status
+	}
+
+	for _, from := range w.Statuses {
+		if w.CanTransition(from, status) {
+			return from
+		}
+	}
+
+	return status
+}
+
+// Validate checks that the workflow only refers to the statuses it declares,
+// and that terminal statuses have no transitions.
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("%w: no statuses declared", ErrInvalidWorkflow)
+	}
+
+	if _, ok := find(w.Statuses, w.InitialStatus()); !ok {
+		return fmt.Errorf("%w: initial status %q is not declared", ErrInvalidWorkflow, w.Initial)
+	}
+
+	for _, status := range w.Terminal {
+		if _, ok := find(w.Statuses, status); !ok {
+			return fmt.Errorf("%w: terminal status %q is not declared", ErrInvalidWorkflow, status)
+		}
+	}
+
+	for from, next := range w.Transitions {
+		if _, ok := find(w.Statuses, from); !ok {
+			return fmt.Errorf("%w: status %q is not declared", ErrInvalidWorkflow, from)
+		}
+
+		if _, ok := find(w.Terminal, from); ok && len(next) > 0 {
+			return fmt.Errorf("%w: terminal status %q has transitions", ErrInvalidWorkflow, from)
+		}
+
+		for _, to := range next {
+			if _, ok := find(w.Statuses, to); !ok {
+				return fmt.Errorf("%w: status %q is not declared", ErrInvalidWorkflow, to)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package adr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/docula-io/docula/adr"
+)
+
+const securityWorkflow = `
+statuses: [proposed, under-review, accepted, rejected]
+transitions:
+  proposed: [under-review, rejected]
+  under-review: [accepted, rejected]
+terminal: [rejected]
+`
+
+func TestWorkflowCanTransition(t *testing.T) {
+	var workflow adr.Workflow
+
+	assert.NoError(t, yaml.Unmarshal([]byte(securityWorkflow), &workflow))
+	assert.NoError(t, workflow.Validate())
+
+	testCases := []struct {
+		from  string
+		to    string
+		wants bool
+	}{
+		{from: "Proposed", to: "under-review", wants: true},
+		{from: "under-review", to: "Accepted", wants: true},
+		{from: "proposed", to: "accepted", wants: false},
+		{from: "rejected", to: "proposed", wants: false},
+		{from: "accepted", to: "deprecated", wants: false},
+		{from: "unknown", to: "accepted", wants: false},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.wants, workflow.CanTransition(tt.from, tt.to))
+		})
+	}
+
+	assert.Equal(t, "proposed", workflow.InitialStatus())
+	assert.True(t, workflow.IsTerminal("Rejected"))
+	assert.False(t, workflow.IsTerminal("accepted"))
+}
+
+func TestWorkflowStatus(t *testing.T) {
+	workflow := adr.DefaultWorkflow()
+
+	status, ok := workflow.Status("superseded by [0012. Foo](0012-foo.md)")
+	assert.True(t, ok)
+	assert.Equal(t, adr.StatusSuperseded, status)
+
+	_, ok = workflow.Status("under-review")
+	assert.False(t, ok)
+}
+
+func TestWorkflowPriorStatus(t *testing.T) {
+	workflow := adr.DefaultWorkflow()
+
+	assert.Equal(t, adr.StatusAccepted, workflow.PriorStatus(adr.StatusSuperseded))
+	assert.Equal(t, adr.StatusAccepted, workflow.PriorStatus(adr.StatusDeprecated))
+	assert.Equal(t, adr.StatusProposed, workflow.PriorStatus(adr.StatusRejected))
+	assert.Equal(t, adr.StatusAccepted, workflow.PriorStatus(adr.StatusAccepted))
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input adr.Workflow
+		wants error
+	}{
+		{
+			name:  "default workflow",
+			input: adr.DefaultWorkflow(),
+		},
+		{
+			name:  "no statuses",
+			input: adr.Workflow{},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "undeclared initial status",
+			input: adr.Workflow{
+				Statuses: []string{"a"},
+				Initial:  "b",
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "undeclared transition target",
+			input: adr.Workflow{
+				Statuses:    []string{"a"},
+				Transitions: map[string][]string{"a": {"b"}},
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "undeclared transition source",
+			input: adr.Workflow{
+				Statuses:    []string{"a"},
+				Transitions: map[string][]string{"b": {"a"}},
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "undeclared terminal status",
+			input: adr.Workflow{
+				Statuses: []string{"a"},
+				Terminal: []string{"b"},
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+		{
+			name: "terminal status with transitions",
+			input: adr.Workflow{
+				Statuses:    []string{"a", "b"},
+				Transitions: map[string][]string{"b": {"a"}},
+				Terminal:    []string{"b"},
+			},
+			wants: adr.ErrInvalidWorkflow,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+
+			if tt.wants != nil {
+				assert.ErrorIs(t, err, tt.wants)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/linkcheck"
+)
+
+type linkCheckHandler func(ctx context.Context, out io.Writer, opts linkcheck.Options) error
+
+func checkLinksCmd(handler linkCheckHandler) *cobra.Command {
+	var opts linkcheck.Options
+
+	checkLinksCmd := &cobra.Command{
+		Use:   "check-links",
+		Short: "Checks the links of the documents in every registered directory.",
+		Long: "Checks the relative links, images, heading anchors and textual " +
+			"decision references, such as ADR-0012, of the markdown documents " +
+			"in every directory registered in the state file. Broken links are " +
+			"reported with their file and line, along with the likely new " +
+			"target when the linked file was renamed or moved. Web links are " +
+			"not followed, so the check works offline. The command fails if " +
+			"any link is broken.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("check links handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	checkLinksCmd.Flags().StringVar(&opts.Dir, "dir", "", "only check documents of this directory, by name or path")
+
+	return checkLinksCmd
+}
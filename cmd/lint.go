@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/lint"
+)
+
+type lintHandler func(ctx context.Context, out io.Writer, opts lint.Options) error
+
+func lintCmd(handler lintHandler) *cobra.Command {
+	var opts lint.Options
+
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Checks the decision records of every registered directory.",
+		Long: "Checks the decision records of every directory registered in " +
+			"the state file for missing or empty template sections, unknown " +
+			"statuses, duplicate numbers, file names that do not match the " +
+			"index type, dangling supersede links, and superseded records " +
+			"that are still accepted. The severity of each rule can be set " +
+			"to error, warning or off under lint.rules in the state file. " +
+			"The command fails if any error is found.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("lint handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	lintCmd.Flags().StringVar(&opts.Dir, "dir", "", "only report records of this directory, by name or path")
+
+	return lintCmd
+}
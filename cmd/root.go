@@ -7,6 +7,9 @@ import (
 	"github.com/spf13/cobra"
 
 	adrCmd "github.com/docula-io/docula/adr/cmd"
+	"github.com/docula-io/docula/linkcheck"
+	"github.com/docula-io/docula/lint"
+	"github.com/docula-io/docula/search"
 )
 
 func rootCmd() *cobra.Command {
@@ -17,7 +20,14 @@ func rootCmd() *cobra.Command {
 		Version: "0.1.0",
 	}
 
+	searchHandler := search.New()
+	lintHandler := lint.New()
+	linkCheckHandler := linkcheck.New()
+
 	rootCmd.AddCommand(adrCmd.RootCmd())
+	rootCmd.AddCommand(searchCmd(searchHandler.Handle))
+	rootCmd.AddCommand(lintCmd(lintHandler.Handle))
+	rootCmd.AddCommand(checkLinksCmd(linkCheckHandler.Handle))
 
 	return rootCmd
 }
@@ -18,6 +18,24 @@ func TestRootCommand(t *testing.T) {
 				"adr", "help",
 			},
 		},
+		{
+			name: "should have a search command",
+			args: []string{
+				"search", "--help",
+			},
+		},
+		{
+			name: "should have a lint command",
+			args: []string{
+				"lint", "--help",
+			},
+		},
+		{
+			name: "should have a check-links command",
+			args: []string{
+				"check-links", "--help",
+			},
+		},
 		{
 			name: "should not have a foobar command",
 			args: []string{
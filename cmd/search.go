@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docula-io/docula/search"
+)
+
+type searchHandler func(ctx context.Context, out io.Writer, opts search.Options) error
+
+func searchCmd(handler searchHandler) *cobra.Command {
+	var opts search.Options
+
+	searchCmd := &cobra.Command{
+		Use:   "search <terms>...",
+		Short: "Searches the documents of every registered directory.",
+		Long: "Searches the markdown documents of every directory registered " +
+			"in the state file, and prints the matching documents with the " +
+			"line and a snippet of the first match, most relevant first. " +
+			"Terms in double quotes are matched as a phrase, and a term or " +
+			"phrase can be scoped to a field with title:, status:, tag:, " +
+			"dir: or body:. The search index is cached in .docula-cache next " +
+			"to the state file, and only the documents that changed since " +
+			"the last search are indexed again.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Terms = strings.Join(args, " ")
+
+			if err := handler(cmd.Context(), cmd.OutOrStdout(), opts); err != nil {
+				return fmt.Errorf("search handler: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	searchCmd.Flags().StringVar(&opts.Dir, "dir", "", "only show documents of this directory, by name or path")
+	searchCmd.Flags().IntVarP(&opts.Limit, "limit", "n", search.DefaultLimit, "maximum number of results, or 0 for all of them")
+
+	return searchCmd
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/search"
+	"github.com/docula-io/docula/search/index"
+)
+
+func TestSearchCmd(t *testing.T) {
+	type want struct {
+		err  bool
+		opts search.Options
+	}
+
+	testCases := []struct {
+		name       string
+		handlerRet error
+		args       []string
+		wants      want
+	}{
+		{
+			name: "terms are joined",
+			args: []string{"title:kafka", `"event sourcing"`},
+			wants: want{
+				opts: search.Options{Terms: `title:kafka "event sourcing"`, Limit: search.DefaultLimit},
+			},
+		},
+		{
+			name: "single dir without a limit",
+			args: []string{"postgres", "--dir", "platform", "-n", "0"},
+			wants: want{
+				opts: search.Options{Terms: "postgres", Dir: "platform"},
+			},
+		},
+		{
+			name: "missing terms",
+			args: []string{},
+			wants: want{
+				err: true,
+			},
+		},
+		{
+			name:       "empty query",
+			handlerRet: index.ErrEmptyQuery,
+			args:       []string{`""`},
+			wants: want{
+				err:  true,
+				opts: search.Options{Terms: `""`, Limit: search.DefaultLimit},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var opts search.Options
+
+			h := func(ctx context.Context, out io.Writer, o search.Options) error {
+				opts = o
+				return tt.handlerRet
+			}
+
+			cmd := searchCmd(h)
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.wants.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.opts, opts)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoIdentity is returned when git has no user configured.
+var ErrNoIdentity = errors.New("no git user configured")
+
+// Identity represents a source of the name of the person making a change,
+// such as Config.
+type Identity interface {
+	UserName(ctx context.Context) (string, error)
+}
+
+// Runner represents a function that runs git with the given arguments and
+// returns its standard output.
+type Runner func(ctx context.Context, args ...string) ([]byte, error)
+
+// Config provides read access to the git configuration.
+type Config struct {
+	run Runner
+}
+
+// New acts as the default constructor for the Config type. This method
+// should be used over direct instantiation.
+func New(opts ...Option) *Config {
+	c := &Config{
+		run: runGit,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func runGit(ctx context.Context, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, "git", args...).Output()
+}
+
+func (c *Config) get(ctx context.Context, key string) (string, error) {
+	out, err := c.run(ctx, "config", "--get", key)
+	if err != nil {
+		return "", fmt.Errorf("git config %s: %w", key, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// UserName returns the configured user.name of git.
+func (c *Config) UserName(ctx context.Context) (string, error) {
+	name, err := c.get(ctx, "user.name")
+	if err != nil || name == "" {
+		return "", ErrNoIdentity
+	}
+
+	return name, nil
+}
+
+// UserEmail returns the configured user.email of git.
+func (c *Config) UserEmail(ctx context.Context) (string, error) {
+	email, err := c.get(ctx, "user.email")
+	if err != nil || email == "" {
+		return "", ErrNoIdentity
+	}
+
+	return email, nil
+}
+
+// Author returns the person making a change. The given person is used when
+// there is one, and otherwise the user name of the identity. The change is
+// left unattributed, with an empty name, if neither is known.
+func Author(ctx context.Context, identity Identity, by string) string {
+	if by != "" {
+		return by
+	}
+
+	name, err := identity.UserName(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
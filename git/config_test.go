@@ -0,0 +1,130 @@
+package git_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/git"
+)
+
+func TestConfigUserName(t *testing.T) {
+	type want struct {
+		name string
+		err  error
+	}
+
+	testCases := []struct {
+		name  string
+		out   string
+		err   error
+		wants want
+	}{
+		{
+			name: "configured user",
+			out:  "Jane Doe\n",
+			wants: want{
+				name: "Jane Doe",
+			},
+		},
+		{
+			name: "missing user",
+			err:  errors.New("exit status 1"),
+			wants: want{
+				err: git.ErrNoIdentity,
+			},
+		},
+		{
+			name: "empty user",
+			out:  "\n",
+			wants: want{
+				err: git.ErrNoIdentity,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var args []string
+
+			run := func(ctx context.Context, a ...string) ([]byte, error) {
+				args = a
+				return []byte(tt.out), tt.err
+			}
+
+			c := git.New(git.WithRunner(run))
+
+			name, err := c.UserName(context.Background())
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.name, name)
+			assert.Equal(t, []string{"config", "--get", "user.name"}, args)
+		})
+	}
+}
+
+func TestConfigUserEmail(t *testing.T) {
+	testCases := []struct {
+		name  string
+		out   string
+		err   error
+		wants string
+	}{
+		{
+			name:  "configured email",
+			out:   "jane@example.com\n",
+			wants: "jane@example.com",
+		},
+		{
+			name: "missing email",
+			err:  errors.New("exit status 1"),
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var args []string
+
+			run := func(ctx context.Context, a ...string) ([]byte, error) {
+				args = a
+				return []byte(tt.out), tt.err
+			}
+
+			email, err := git.New(git.WithRunner(run)).UserEmail(context.Background())
+
+			if tt.wants == "" {
+				assert.ErrorIs(t, err, git.ErrNoIdentity)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants, email)
+			assert.Equal(t, []string{"config", "--get", "user.email"}, args)
+		})
+	}
+}
+
+func TestAuthor(t *testing.T) {
+	configured := git.New(git.WithRunner(func(ctx context.Context, a ...string) ([]byte, error) {
+		return []byte("Jane Doe\n"), nil
+	}))
+
+	missing := git.New(git.WithRunner(func(ctx context.Context, a ...string) ([]byte, error) {
+		return nil, errors.New("exit status 1")
+	}))
+
+	assert.Equal(t, "John Roe", git.Author(context.Background(), configured, "John Roe"))
+	assert.Equal(t, "Jane Doe", git.Author(context.Background(), configured, ""))
+	assert.Equal(t, "", git.Author(context.Background(), missing, ""))
+}
@@ -0,0 +1,3 @@
+// Package git provides access to the git configuration of the project, such
+// as the identity of the current user.
+package git
@@ -0,0 +1,14 @@
+package git
+
+// Option provides a function that is able to override the internals of a
+// Config instance. These options should rarely be used for anything other
+// than testing.
+type Option func(*Config)
+
+// WithRunner provides an option to override the function that is used to
+// run the git command.
+func WithRunner(run Runner) Option {
+	return func(c *Config) {
+		c.run = run
+	}
+}
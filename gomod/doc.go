@@ -0,0 +1,3 @@
+// Package gomod reads the parts of go.mod files that docula checks: the
+// module path and the required modules.
+package gomod
@@ -0,0 +1,87 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// FileName is the name of the file that marks the root of a Go module.
+const FileName = "go.mod"
+
+// File represents a parsed go.mod file.
+type File struct {
+	// Module is the module path declared by the file.
+	Module   string
+	Requires []Require
+}
+
+// Require represents a single required module.
+type Require struct {
+	Path    string
+	Version string
+	// Indirect reports whether the requirement is marked as indirect.
+	Indirect bool
+	// Line is the line of the requirement, starting at 1.
+	Line int
+}
+
+// Parse reads the module path and the requirements of a go.mod file. Lines
+// that cannot be read, and any other directives, are skipped.
+func Parse(data []byte) File {
+	var (
+		f     File
+		block string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(text)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		directive := block
+
+		switch {
+		case block != "" && fields[0] == ")":
+			block = ""
+			continue
+		case block == "" && len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		case block == "":
+			directive, fields = fields[0], fields[1:]
+		}
+
+		switch directive {
+		case "module":
+			if len(fields) > 0 {
+				f.Module = unquote(fields[0])
+			}
+		case "require":
+			if len(fields) >= 2 {
+				f.Requires = append(f.Requires, Require{
+					Path:     unquote(fields[0]),
+					Version:  fields[1],
+					Indirect: strings.TrimSpace(comment) == "indirect",
+					Line:     line,
+				})
+			}
+		}
+	}
+
+	return f
+}
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+
+	return value
+}
@@ -0,0 +1,64 @@
+package gomod_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/gomod"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		wants gomod.File
+	}{
+		{
+			name: "blocks",
+			input: `module example.com/shop // the shop
+
+go 1.18
+
+require (
+	github.com/jackc/pgx/v5 v5.2.0
+	github.com/lib/pq v1.10.7 // indirect
+)
+
+require golang.org/x/text v0.5.0
+
+replace (
+	github.com/lib/pq => ../pq
+)
+`,
+			wants: gomod.File{
+				Module: "example.com/shop",
+				Requires: []gomod.Require{
+					{Path: "github.com/jackc/pgx/v5", Version: "v5.2.0", Line: 6},
+					{Path: "github.com/lib/pq", Version: "v1.10.7", Indirect: true, Line: 7},
+					{Path: "golang.org/x/text", Version: "v0.5.0", Line: 10},
+				},
+			},
+		},
+		{
+			name:  "quoted module path",
+			input: "module \"example.com/shop/tools\"\n",
+			wants: gomod.File{
+				Module: "example.com/shop/tools",
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+			wants: gomod.File{},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wants, gomod.Parse([]byte(tt.input)))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=linkcheck -mock_names FileSystem=mockFileSystem,StateManager=mockStateManager
+
+package linkcheck
+
+import (
+	"os"
+
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// FileSystem provides an interface that can interact with the file system.
+// This interface is primarily used for testing. All of these methods are
+// found in the `os` package.
+type FileSystem interface {
+	ReadDir(name string) ([]os.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Stat(name string) (os.FileInfo, error)
+}
@@ -0,0 +1,6 @@
+// Package linkcheck provides handler functionality for the check-links
+// command, which checks the relative links, images, heading anchors and
+// textual decision references of the documents in every directory that is
+// registered in the docula state file. The check runs against the local tree
+// only, so it works offline.
+package linkcheck
@@ -0,0 +1,17 @@
+package linkcheck
+
+import "os"
+
+type defaultFileSystem struct{}
+
+func (f *defaultFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (f *defaultFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (f *defaultFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
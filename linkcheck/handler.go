@@ -0,0 +1,378 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/linkcheck/markdown"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrBroken is returned when any of the checked links is broken.
+var ErrBroken = errors.New("broken links found")
+
+// Handler describes a type that is used to handle the check-links command.
+type Handler struct {
+	stateManager StateManager
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// tree holds the files of the registered dirs, relative to the state dir.
+type tree struct {
+	stateDir string
+	// files holds every file of the registered dirs.
+	files map[string]bool
+	// docs maps the markdown files to the name of their dir.
+	docs map[string]string
+	// numbers holds the numbers of the records, without leading zeros.
+	numbers map[string]bool
+	// parsed caches the parsed markdown of the files.
+	parsed map[string]markdown.Document
+	// exists caches whether files outside of the registered dirs exist.
+	exists map[string]bool
+}
+
+// problem represents a single broken link.
+type problem struct {
+	path    string
+	line    int
+	message string
+}
+
+// walk adds the files of the dir and its sub dirs to the tree, skipping
+// hidden files.
+func (h *Handler) walk(t *tree, dir adr.Directory, rel string) error {
+	entries, err := h.fs.ReadDir(t.stateDir + rel)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("read dir %s: %w", rel, err)
+	}
+
+	for _, entry := range entries {
+		filePath := path.Join(rel, entry.Name())
+
+		if strings.HasPrefix(entry.Name(), ".") || t.files[filePath] {
+			continue
+		}
+
+		if entry.IsDir() {
+			if err = h.walk(t, dir, filePath); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		t.files[filePath] = true
+
+		if path.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		t.docs[filePath] = dir.Name
+
+		if id, err := adr.RecordID(entry.Name()); err == nil {
+			t.numbers[adr.IDNumber(id)] = true
+		}
+	}
+
+	return nil
+}
+
+// parse returns the parsed markdown of the file, reading it if it has not
+// been read before.
+func (h *Handler) parse(t *tree, filePath string) (markdown.Document, error) {
+	if doc, ok := t.parsed[filePath]; ok {
+		return doc, nil
+	}
+
+	data, err := h.fs.ReadFile(t.stateDir + filePath)
+	if err != nil {
+		return markdown.Document{}, fmt.Errorf("read %s: %w", filePath, err)
+	}
+
+	doc := markdown.Parse(data)
+	t.parsed[filePath] = doc
+
+	return doc, nil
+}
+
+// exists reports whether the file exists. Only files outside of the
+// registered dirs are looked up on the file system.
+func (h *Handler) exists(t *tree, filePath string) (bool, error) {
+	if t.files[filePath] {
+		return true, nil
+	}
+
+	if ok, cached := t.exists[filePath]; cached {
+		return ok, nil
+	}
+
+	_, err := h.fs.Stat(t.stateDir + filePath)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		t.exists[filePath] = false
+	case err != nil:
+		return false, fmt.Errorf("stat %s: %w", filePath, err)
+	default:
+		t.exists[filePath] = true
+	}
+
+	return t.exists[filePath], nil
+}
+
+// check returns the broken links and references of the document.
+func (h *Handler) check(t *tree, docPath string) ([]problem, error) {
+	doc, err := h.parse(t, docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []problem
+
+	for _, link := range doc.Links {
+		if link.External() {
+			continue
+		}
+
+		message, err := h.checkLink(t, docPath, doc, link)
+		if err != nil {
+			return nil, err
+		}
+
+		if message != "" {
+			problems = append(problems, problem{path: docPath, line: link.Line, message: message})
+		}
+	}
+
+	for _, ref := range doc.References {
+		if !t.numbers[ref.Number] {
+			problems = append(problems, problem{
+				path:    docPath,
+				line:    ref.Line,
+				message: fmt.Sprintf("%s does not match any record", ref.Text),
+			})
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].line < problems[j].line
+	})
+
+	return problems, nil
+}
+
+// checkLink returns a description of the problem with the link, or an empty
+// string if the link resolves.
+func (h *Handler) checkLink(t *tree, docPath string, doc markdown.Document, link markdown.Link) (string, error) {
+	target, anchor := link.Split()
+
+	if target == "" {
+		if anchor == "" || doc.Anchors[anchor] {
+			return "", nil
+		}
+
+		return fmt.Sprintf("missing anchor #%s", anchor), nil
+	}
+
+	resolved := path.Clean(path.Join(path.Dir(docPath), target))
+	if strings.HasPrefix(target, "/") {
+		resolved = path.Clean(strings.TrimPrefix(target, "/"))
+	}
+
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Sprintf("%s points outside of the project", target), nil
+	}
+
+	ok, err := h.exists(t, resolved)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		message := fmt.Sprintf("broken link to %s", target)
+		if link.Image {
+			message = fmt.Sprintf("missing image %s", target)
+		}
+
+		if suggestion, found := suggest(t, docPath, resolved); found {
+			message = fmt.Sprintf("%s, did you mean %s?", message, suggestion)
+		}
+
+		return message, nil
+	}
+
+	if anchor == "" || path.Ext(resolved) != ".md" {
+		return "", nil
+	}
+
+	linked, err := h.parse(t, resolved)
+	if err != nil {
+		return "", err
+	}
+
+	if !linked.Anchors[anchor] {
+		return fmt.Sprintf("missing anchor #%s in %s", anchor, target), nil
+	}
+
+	return "", nil
+}
+
+// suggest returns the likely new target of a link to a file that was renamed
+// or moved. A record that was renamed keeps its number within its dir, while
+// any other file keeps its name. The suggestion is only made if a single
+// file matches, and is relative to the dir of the linking document.
+func suggest(t *tree, docPath string, missing string) (string, bool) {
+	var candidates []string
+
+	name := path.Base(missing)
+	id, err := adr.RecordID(name)
+
+	for filePath := range t.files {
+		switch {
+		case err == nil:
+			if other, e := adr.RecordID(path.Base(filePath)); e == nil && other == id && path.Dir(filePath) == path.Dir(missing) {
+				candidates = append(candidates, filePath)
+			}
+		case path.Base(filePath) == name:
+			candidates = append(candidates, filePath)
+		}
+	}
+
+	if len(candidates) != 1 {
+		return "", false
+	}
+
+	return relative(path.Dir(docPath), candidates[0]), true
+}
+
+// relative returns the path of the target relative to the dir.
+func relative(dir string, target string) string {
+	from, to := split(dir), split(target)
+
+	common := 0
+	for common < len(from) && common < len(to)-1 && from[common] == to[common] {
+		common++
+	}
+
+	parts := make([]string, 0, len(from)-common+len(to)-common)
+
+	for range from[common:] {
+		parts = append(parts, "..")
+	}
+
+	return path.Join(append(parts, to[common:]...)...)
+}
+
+func split(p string) []string {
+	if p == "." || p == "" {
+		return nil
+	}
+
+	return strings.Split(p, "/")
+}
+
+// Handle is the main Handler function. This function checks the links of
+// the documents within every registered dir, and prints those that are
+// broken. If any link is broken, then ErrBroken is returned.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	var only string
+
+	if opts.Dir != "" {
+		dir, err := s.ADR.LookupDirectory(opts.Dir, h.stateManager.NormalizePath)
+		if err != nil {
+			return err
+		}
+
+		only = dir.Name
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	t := &tree{
+		stateDir: stateDir,
+		files:    map[string]bool{},
+		docs:     map[string]string{},
+		numbers:  map[string]bool{},
+		parsed:   map[string]markdown.Document{},
+		exists:   map[string]bool{},
+	}
+
+	for _, dir := range s.ADR.Directories {
+		if err = h.walk(t, dir, dir.Path); err != nil {
+			return err
+		}
+	}
+
+	docs := make([]string, 0, len(t.docs))
+
+	for docPath, dir := range t.docs {
+		if only == "" || dir == only {
+			docs = append(docs, docPath)
+		}
+	}
+
+	sort.Strings(docs)
+
+	var problems []problem
+
+	for _, docPath := range docs {
+		found, err := h.check(t, docPath)
+		if err != nil {
+			return err
+		}
+
+		problems = append(problems, found...)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "no broken links found")
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Fprintf(out, "%s:%d: %s\n", p.path, p.line, p.message)
+	}
+
+	broken := adr.Plural(len(problems), "broken link")
+
+	fmt.Fprintln(out, broken)
+
+	return fmt.Errorf("%w: %s", ErrBroken, broken)
+}
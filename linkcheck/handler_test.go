@@ -0,0 +1,259 @@
+package linkcheck_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/linkcheck"
+	"github.com/docula-io/docula/state"
+)
+
+type dirEntry struct {
+	name string
+	dir  bool
+}
+
+func (d dirEntry) Name() string               { return d.name }
+func (d dirEntry) IsDir() bool                { return d.dir }
+func (d dirEntry) Type() fs.FileMode          { return 0 }
+func (d dirEntry) Info() (fs.FileInfo, error) { return nil, nil }
+
+type fileInfo struct{}
+
+func (f fileInfo) Name() string       { return "" }
+func (f fileInfo) Size() int64        { return 0 }
+func (f fileInfo) Mode() fs.FileMode  { return 0 }
+func (f fileInfo) ModTime() time.Time { return time.Time{} }
+func (f fileInfo) IsDir() bool        { return false }
+func (f fileInfo) Sys() interface{}   { return nil }
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	decisionsRecord = "# Record decisions\n" +
+		"\n" +
+		"## Context\n" +
+		"\n" +
+		"See [PostgreSQL](0002-use-postgres.md), [mTLS](../../security/0001-add-mtls.md#consequences), " +
+		"![flow](img/flow.png), [readme](../../README.md#usage) and ADR-0003.\n" +
+		"Jump to [context](#context) or [nowhere](#nope), [up](../../../x.md) and [web](https://example.com).\n"
+
+	// tree holds the files of the project, relative to the state dir.
+	tree = map[string]string{
+		"docs/adr/0001-record-decisions.md": decisionsRecord,
+		"docs/adr/0002-use-postgresql.md":   "# Use PostgreSQL\n",
+		"security/0001-add-mtls.md": "# Add mTLS\n\n## Consequences\n\n" +
+			"Back to [decisions](../docs/adr/0001-record-decisions.md#record-decisions) and ADR-1.\n",
+		"security/img/flow.png": "",
+	}
+)
+
+// project produces a file system holding the files of the tree, along with
+// a README next to the state file.
+func project(ctrl *gomock.Controller) linkcheck.FileSystem {
+	fs := linkcheck.NewmockFileSystem(ctrl)
+
+	fs.EXPECT().ReadDir(gomock.Any()).DoAndReturn(func(name string) ([]os.DirEntry, error) {
+		dir := strings.TrimPrefix(name, "/")
+		seen := map[string]bool{}
+
+		var entries []os.DirEntry
+
+		for filePath := range tree {
+			if !strings.HasPrefix(filePath, dir+"/") {
+				continue
+			}
+
+			rest := strings.TrimPrefix(filePath, dir+"/")
+			first, _, nested := strings.Cut(rest, "/")
+
+			if !seen[first] {
+				seen[first] = true
+				entries = append(entries, dirEntry{name: first, dir: nested})
+			}
+		}
+
+		if len(entries) == 0 {
+			return nil, os.ErrNotExist
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+		return entries, nil
+	}).AnyTimes()
+
+	fs.EXPECT().ReadFile(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+		if name == "/README.md" {
+			return []byte("# Docs\n\n## Install\n"), nil
+		}
+
+		if data, ok := tree[strings.TrimPrefix(name, "/")]; ok {
+			return []byte(data), nil
+		}
+
+		return nil, os.ErrNotExist
+	}).AnyTimes()
+
+	fs.EXPECT().Stat(gomock.Any()).DoAndReturn(func(name string) (os.FileInfo, error) {
+		if path.Clean(name) == "/README.md" {
+			return fileInfo{}, nil
+		}
+
+		return nil, os.ErrNotExist
+	}).AnyTimes()
+
+	return fs
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) linkcheck.StateManager
+		fs           func(ctrl *gomock.Controller) linkcheck.FileSystem
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(ctrl *gomock.Controller) linkcheck.StateManager {
+		sm := linkcheck.NewmockStateManager(ctrl)
+		sm.EXPECT().Load().Return(defaultState, nil)
+		sm.EXPECT().StateDir().Return("/", nil)
+
+		return sm
+	}
+
+	testCases := []struct {
+		name  string
+		opts  linkcheck.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			setup: setup{
+				stateManager: loaded,
+				fs:           project,
+			},
+			wants: want{
+				err: linkcheck.ErrBroken,
+				output: "docs/adr/0001-record-decisions.md:5: broken link to 0002-use-postgres.md, " +
+					"did you mean 0002-use-postgresql.md?\n" +
+					"docs/adr/0001-record-decisions.md:5: missing image img/flow.png, " +
+					"did you mean ../../security/img/flow.png?\n" +
+					"docs/adr/0001-record-decisions.md:5: missing anchor #usage in ../../README.md\n" +
+					"docs/adr/0001-record-decisions.md:5: ADR-0003 does not match any record\n" +
+					"docs/adr/0001-record-decisions.md:6: missing anchor #nope\n" +
+					"docs/adr/0001-record-decisions.md:6: ../../../x.md points outside of the project\n" +
+					"6 broken links\n",
+			},
+		},
+		{
+			name: "single dir",
+			opts: linkcheck.Options{Dir: "security"},
+			setup: setup{
+				stateManager: loaded,
+				fs:           project,
+			},
+			wants: want{
+				output: "no broken links found\n",
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: linkcheck.Options{Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) linkcheck.StateManager {
+					sm := linkcheck.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				fs: func(ctrl *gomock.Controller) linkcheck.FileSystem {
+					return linkcheck.NewmockFileSystem(ctrl)
+				},
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) linkcheck.StateManager {
+					sm := linkcheck.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				fs: func(ctrl *gomock.Controller) linkcheck.FileSystem {
+					return linkcheck.NewmockFileSystem(ctrl)
+				},
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+		{
+			name: "fail to read dir",
+			setup: setup{
+				stateManager: loaded,
+				fs: func(ctrl *gomock.Controller) linkcheck.FileSystem {
+					fs := linkcheck.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadDir("/docs/adr").Return(nil, os.ErrPermission)
+
+					return fs
+				},
+			},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := linkcheck.New(
+				linkcheck.WithStateManager(tt.setup.stateManager(ctrl)),
+				linkcheck.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package markdown extracts the links, heading anchors and textual decision
+// references of markdown documents, for the link checker.
+package markdown
@@ -0,0 +1,165 @@
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/docula-io/docula/adr"
+)
+
+var (
+	inlineLink  = regexp.MustCompile(`(!?)\[(?:[^\[\]]|\[[^\[\]]*\])*\]\(\s*(<[^>]*>|[^)\s]+)(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	definition  = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*(<[^>]*>|\S+)`)
+	reference   = regexp.MustCompile(`(?i)\bADR-(\d+)\b`)
+	inlineCode  = regexp.MustCompile("`+[^`]*`+")
+	scheme      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+	htmlComment = regexp.MustCompile(`<!--.*?-->`)
+)
+
+// Link represents a link or image within a document.
+type Link struct {
+	// Line is the line of the link, starting at 1.
+	Line int
+	// Target is the destination of the link as it is written.
+	Target string
+	// Image reports whether the link embeds an image.
+	Image bool
+}
+
+// External reports whether the link points outside of the local tree, such
+// as a web page or a mail address.
+func (l Link) External() bool {
+	return scheme.MatchString(l.Target) || strings.HasPrefix(l.Target, "//")
+}
+
+// Split separates the path of the link from its anchor. The path is empty
+// for links to an anchor within the same document.
+func (l Link) Split() (string, string) {
+	target, anchor, _ := strings.Cut(l.Target, "#")
+
+	if i := strings.Index(target, "?"); i >= 0 {
+		target = target[:i]
+	}
+
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+
+	return target, anchor
+}
+
+// Reference represents a textual reference to a decision record, such as
+// ADR-0012.
+type Reference struct {
+	// Line is the line of the reference, starting at 1.
+	Line int
+	// Text is the reference as it is written.
+	Text string
+	// Number is the number of the referenced record, without leading zeros.
+	Number string
+}
+
+// Document holds the links, references and anchors of a markdown document.
+// Code blocks and code spans are skipped.
+type Document struct {
+	Links      []Link
+	References []Reference
+	// Anchors holds the anchors of the headings of the document.
+	Anchors map[string]bool
+}
+
+// Parse extracts the links, references and heading anchors of the document.
+func Parse(data []byte) Document {
+	doc := Document{Anchors: map[string]bool{}}
+
+	var fence string
+
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		text := htmlComment.ReplaceAllString(inlineCode.ReplaceAllString(line, ""), "")
+
+		if heading, ok := headingText(htmlComment.ReplaceAllString(line, "")); ok {
+			addAnchor(doc.Anchors, Slug(heading))
+		}
+
+		for _, m := range inlineLink.FindAllStringSubmatch(text, -1) {
+			doc.Links = append(doc.Links, Link{Line: i + 1, Target: strings.Trim(m[2], "<>"), Image: m[1] == "!"})
+		}
+
+		if m := definition.FindStringSubmatch(text); m != nil {
+			doc.Links = append(doc.Links, Link{Line: i + 1, Target: strings.Trim(m[1], "<>")})
+		}
+
+		for _, m := range reference.FindAllStringSubmatch(text, -1) {
+			doc.References = append(doc.References, Reference{Line: i + 1, Text: m[0], Number: adr.IDNumber(m[1])})
+		}
+	}
+
+	return doc
+}
+
+func headingText(line string) (string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "", false
+	}
+
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > 6 || (len(trimmed) > level && trimmed[level] != ' ') {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(trimmed[level:]), "#")), true
+}
+
+// addAnchor adds the anchor to the set. Repeated headings get a numbered
+// suffix, in the way that GitHub renders them.
+func addAnchor(anchors map[string]bool, slug string) {
+	anchor := slug
+
+	for n := 1; anchors[anchor]; n++ {
+		anchor = fmt.Sprintf("%s-%d", slug, n)
+	}
+
+	anchors[anchor] = true
+}
+
+// Slug returns the anchor of a heading. The heading is lower cased, spaces
+// become hyphens and any punctuation apart from hyphens and underscores is
+// dropped. Links within the heading are reduced to their text.
+func Slug(heading string) string {
+	heading = inlineLink.ReplaceAllStringFunc(heading, func(link string) string {
+		start, end := strings.Index(link, "["), strings.LastIndex(link, "](")
+		return link[start+1 : end]
+	})
+
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/linkcheck/markdown"
+)
+
+func TestParse(t *testing.T) {
+	input := "# Use Kafka\n" +
+		"\n" +
+		"Supersedes [0002. Use PostgreSQL](0002-use-postgresql.md) and ADR-12, see adr-0003.\n" +
+		"![Diagram](img/flow%20chart.png \"Flow\")\n" +
+		"\n" +
+		"## Consequences & Risks\n" +
+		"\n" +
+		"See [above](#use-kafka), [the site](https://example.com) or [docs](<../docs/read me.md#setup>).\n" +
+		"`[not a link](nope.md)` nor ADR-9 <!-- ADR-10 -->\n" +
+		"\n" +
+		"```\n" +
+		"[fenced](nope.md) ADR-11\n" +
+		"```\n" +
+		"\n" +
+		"## Consequences & `Risks`\n" +
+		"\n" +
+		"[ref]: ../README.md\n"
+
+	doc := markdown.Parse([]byte(input))
+
+	assert.Equal(t, []markdown.Link{
+		{Line: 3, Target: "0002-use-postgresql.md"},
+		{Line: 4, Target: "img/flow%20chart.png", Image: true},
+		{Line: 8, Target: "#use-kafka"},
+		{Line: 8, Target: "https://example.com"},
+		{Line: 8, Target: "../docs/read me.md#setup"},
+		{Line: 17, Target: "../README.md"},
+	}, doc.Links)
+
+	assert.Equal(t, []markdown.Reference{
+		{Line: 3, Text: "ADR-12", Number: "12"},
+		{Line: 3, Text: "adr-0003", Number: "3"},
+		{Line: 9, Text: "ADR-9", Number: "9"},
+	}, doc.References)
+
+	assert.Equal(t, map[string]bool{
+		"use-kafka":             true,
+		"consequences--risks":   true,
+		"consequences--risks-1": true,
+	}, doc.Anchors)
+}
+
+func TestLink(t *testing.T) {
+	testCases := []struct {
+		name     string
+		link     markdown.Link
+		external bool
+		path     string
+		anchor   string
+	}{
+		{
+			name:   "relative",
+			link:   markdown.Link{Target: "0002-use-postgresql.md"},
+			path:   "0002-use-postgresql.md",
+			anchor: "",
+		},
+		{
+			name:   "anchor",
+			link:   markdown.Link{Target: "../README.md#getting-started"},
+			path:   "../README.md",
+			anchor: "getting-started",
+		},
+		{
+			name:   "same document",
+			link:   markdown.Link{Target: "#consequences"},
+			anchor: "consequences",
+		},
+		{
+			name: "escaped",
+			link: markdown.Link{Target: "img/flow%20chart.png?raw=true"},
+			path: "img/flow chart.png",
+		},
+		{
+			name:     "web page",
+			link:     markdown.Link{Target: "https://example.com/a.md"},
+			external: true,
+			path:     "https://example.com/a.md",
+		},
+		{
+			name:     "mail",
+			link:     markdown.Link{Target: "mailto:jane@example.com"},
+			external: true,
+			path:     "mailto:jane@example.com",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.external, tt.link.External())
+
+			p, anchor := tt.link.Split()
+
+			assert.Equal(t, tt.path, p)
+			assert.Equal(t, tt.anchor, anchor)
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	testCases := []struct {
+		heading string
+		slug    string
+	}{
+		{heading: "Consequences", slug: "consequences"},
+		{heading: "Pros and Cons of the Options", slug: "pros-and-cons-of-the-options"},
+		{heading: "1. Use `gRPC` (v2)!", slug: "1-use-grpc-v2"},
+		{heading: "See [ADR-0012](0012-x.md)", slug: "see-adr-0012"},
+		{heading: "snake_case - ok", slug: "snake_case---ok"},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.slug, markdown.Slug(tt.heading))
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package linkcheck
+
+// Options represents the input of the check-links command.
+type Options struct {
+	// Dir limits the check to the documents of a single directory, by name
+	// or path. Links into other directories are still resolved.
+	Dir string
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source=dependencies.go -destination=./mocks.go -package=lint -mock_names RecordStore=mockRecordStore,StateManager=mockStateManager,TemplateSource=mockTemplateSource
+
+package lint
+
+import (
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/state"
+)
+
+// StateManager represents a type that is able to manage the docula state file.
+type StateManager interface {
+	Load() (state.State, error)
+	NormalizePath(path string) (string, error)
+	StateDir() (string, error)
+}
+
+// RecordStore represents a type that is able to scan and read the records of
+// an adr directory, including those that cannot be parsed.
+type RecordStore interface {
+	Scan(stateDir string, dir adr.Directory) ([]adr.Record, []store.InvalidRecord, error)
+	Read(path string) ([]byte, error)
+}
+
+// TemplateSource represents a type that is able to read the source of the
+// template of an adr directory.
+type TemplateSource interface {
+	Source(stateDir string, name string) ([]byte, error)
+}
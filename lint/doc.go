@@ -0,0 +1,4 @@
+// Package lint provides handler functionality for the lint command, which
+// checks the decision records of every directory registered in the docula
+// state file against the rules of the lint/rule package.
+package lint
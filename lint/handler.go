@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/adr/template"
+	"github.com/docula-io/docula/lint/rule"
+	"github.com/docula-io/docula/state"
+)
+
+// ErrFailed is returned when any of the findings is an error.
+var ErrFailed = errors.New("lint failed")
+
+// Handler describes a type that is used to handle the lint command.
+type Handler struct {
+	stateManager StateManager
+	store        RecordStore
+	templates    TemplateSource
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		store:        store.New(),
+		templates:    template.New(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// project reads the records of every directory, along with the sections
+// declared by the template of the directory and the records that cannot be
+// parsed.
+func (h *Handler) project(stateDir string, dirs []adr.Directory) (rule.Project, map[string]string, error) {
+	var (
+		p      rule.Project
+		owners = map[string]string{}
+	)
+
+	for _, dir := range dirs {
+		source, err := h.templates.Source(stateDir, dir.Template)
+		if err != nil {
+			return rule.Project{}, nil, fmt.Errorf("template of %s: %w", dir.Name, err)
+		}
+
+		records, invalid, err := h.store.Scan(stateDir, dir)
+		if err != nil {
+			return rule.Project{}, nil, fmt.Errorf("list records of %s: %w", dir.Name, err)
+		}
+
+		d := rule.Dir{Directory: dir, Invalid: invalid, Sections: rule.Sections(source)}
+
+		for _, inv := range invalid {
+			owners[inv.Path] = dir.Name
+		}
+
+		for _, rec := range records {
+			data, err := h.store.Read(stateDir + rec.Path)
+			if err != nil {
+				return rule.Project{}, nil, err
+			}
+
+			d.Records = append(d.Records, rule.Record{Record: rec, Data: data})
+			owners[rec.Path] = dir.Name
+		}
+
+		p.Dirs = append(p.Dirs, d)
+	}
+
+	return p, owners, nil
+}
+
+// Handle is the main Handler function. This function checks the records of
+// every registered dir with the configured rules, and prints the findings.
+// If any of the findings is an error, then ErrFailed is returned.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	var only string
+
+	if opts.Dir != "" {
+		dir, err := s.ADR.LookupDirectory(opts.Dir, h.stateManager.NormalizePath)
+		if err != nil {
+			return err
+		}
+
+		only = dir.Name
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	p, owners, err := h.project(stateDir, s.ADR.Directories)
+	if err != nil {
+		return err
+	}
+
+	var errs, warnings int
+
+	for _, f := range rule.NewEngine(s.Lint).Run(p) {
+		if only != "" && owners[f.Path] != only {
+			continue
+		}
+
+		location := f.Path
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, f.Line)
+		}
+
+		fmt.Fprintf(out, "%s: %s: %s [%s]\n", location, f.Severity, f.Message, f.Rule)
+
+		if f.Severity == rule.SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+
+	if errs+warnings == 0 {
+		fmt.Fprintln(out, "no problems found")
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s, %s\n", adr.Plural(errs, "error"), adr.Plural(warnings, "warning"))
+
+	if errs > 0 {
+		return fmt.Errorf("%w: %s", ErrFailed, adr.Plural(errs, "error"))
+	}
+
+	return nil
+}
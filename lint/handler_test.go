@@ -0,0 +1,256 @@
+package lint_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/lint"
+	"github.com/docula-io/docula/lint/rule"
+	"github.com/docula-io/docula/state"
+)
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	source = []byte("# {{.Title}}\n\n## Status\n\n{{.Status}}\n\n## Context\n\n")
+
+	decisionsRecord = []byte("# Record decisions\n\n## Status\n\nAccepted\n\n## Context\n\nWe need records.\n")
+	postgresRecord  = []byte("# Use PostgreSQL\n\n## Status\n\nDone\n\n## Context\n")
+	mtlsRecord      = []byte("# Add mTLS\n\n## Status\n\nProposed\n\n## Context\n\nTraffic is unencrypted.\n")
+
+	platformRecords = []adr.Record{
+		{ID: "0001", Title: "Record decisions", Status: "Accepted", Dir: "platform", Path: "docs/adr/0001-record-decisions.md"},
+		{ID: "0002", Title: "Use PostgreSQL", Status: "Done", Dir: "platform", Path: "docs/adr/0002-use-postgresql.md"},
+	}
+
+	securityRecords = []adr.Record{
+		{ID: "0001", Title: "Add mTLS", Status: "Proposed", Dir: "security", Path: "security/0001-Add_mTLS.md"},
+	}
+
+	files = map[string][]byte{
+		"/docs/adr/0001-record-decisions.md": decisionsRecord,
+		"/docs/adr/0002-use-postgresql.md":   postgresRecord,
+		"/security/0001-Add_mTLS.md":         mtlsRecord,
+	}
+)
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) lint.StateManager
+		store        func(ctrl *gomock.Controller) lint.RecordStore
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	loaded := func(s state.State) func(ctrl *gomock.Controller) lint.StateManager {
+		return func(ctrl *gomock.Controller) lint.StateManager {
+			sm := lint.NewmockStateManager(ctrl)
+			sm.EXPECT().Load().Return(s, nil)
+			sm.EXPECT().StateDir().Return("/", nil)
+
+			return sm
+		}
+	}
+
+	project := func(ctrl *gomock.Controller) lint.RecordStore {
+		rs := lint.NewmockRecordStore(ctrl)
+		rs.EXPECT().Scan("/", platformDir).Return(platformRecords, nil, nil)
+		rs.EXPECT().Scan("/", securityDir).Return(securityRecords, nil, nil)
+		rs.EXPECT().Read(gomock.Any()).DoAndReturn(func(path string) ([]byte, error) {
+			return files[path], nil
+		}).AnyTimes()
+
+		return rs
+	}
+
+	noStore := func(ctrl *gomock.Controller) lint.RecordStore {
+		return lint.NewmockRecordStore(ctrl)
+	}
+
+	testCases := []struct {
+		name  string
+		opts  lint.Options
+		setup setup
+		wants want
+	}{
+		{
+			name: "happy path",
+			setup: setup{
+				stateManager: loaded(defaultState),
+				store:        project,
+			},
+			wants: want{
+				err: lint.ErrFailed,
+				output: "docs/adr/0002-use-postgresql.md:5: error: unknown status \"Done\", " +
+					"expected one of Draft, Proposed, Accepted, Rejected, Deprecated, Superseded [unknown-status]\n" +
+					"docs/adr/0002-use-postgresql.md:7: error: section \"Context\" is empty [required-sections]\n" +
+					"security/0001-Add_mTLS.md: warning: \"Add_mTLS\" is not a lower case slug of the title [file-name]\n" +
+					"2 errors, 1 warning\n",
+			},
+		},
+		{
+			name: "configured severities",
+			setup: setup{
+				stateManager: loaded(state.State{
+					ADR: defaultState.ADR,
+					Lint: rule.Config{Rules: map[string]rule.Severity{
+						rule.UnknownStatus:    rule.SeverityWarning,
+						rule.RequiredSections: rule.SeverityOff,
+					}},
+				}),
+				store: project,
+			},
+			wants: want{
+				output: "docs/adr/0002-use-postgresql.md:5: warning: unknown status \"Done\", " +
+					"expected one of Draft, Proposed, Accepted, Rejected, Deprecated, Superseded [unknown-status]\n" +
+					"security/0001-Add_mTLS.md: warning: \"Add_mTLS\" is not a lower case slug of the title [file-name]\n" +
+					"0 errors, 2 warnings\n",
+			},
+		},
+		{
+			name: "single dir",
+			opts: lint.Options{Dir: "security"},
+			setup: setup{
+				stateManager: loaded(defaultState),
+				store:        project,
+			},
+			wants: want{
+				output: "security/0001-Add_mTLS.md: warning: \"Add_mTLS\" is not a lower case slug of the title [file-name]\n" +
+					"0 errors, 1 warning\n",
+			},
+		},
+		{
+			name: "no problems",
+			setup: setup{
+				stateManager: loaded(state.State{ADR: adr.State{Directories: []adr.Directory{platformDir}}}),
+				store: func(ctrl *gomock.Controller) lint.RecordStore {
+					rs := lint.NewmockRecordStore(ctrl)
+					rs.EXPECT().Scan("/", platformDir).Return(platformRecords[:1], nil, nil)
+					rs.EXPECT().Read("/docs/adr/0001-record-decisions.md").Return(decisionsRecord, nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				output: "no problems found\n",
+			},
+		},
+		{
+			name: "record that cannot be parsed",
+			setup: setup{
+				stateManager: loaded(state.State{ADR: adr.State{Directories: []adr.Directory{platformDir}}}),
+				store: func(ctrl *gomock.Controller) lint.RecordStore {
+					rs := lint.NewmockRecordStore(ctrl)
+					rs.EXPECT().Scan("/", platformDir).Return(platformRecords[:1], []store.InvalidRecord{
+						{Path: "docs/adr/0003-use-kafka.md", Err: fmt.Errorf("%w: yaml: line 1: did not find expected node content", adr.ErrInvalidFrontmatter)},
+					}, nil)
+					rs.EXPECT().Read("/docs/adr/0001-record-decisions.md").Return(decisionsRecord, nil)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: lint.ErrFailed,
+				output: "docs/adr/0003-use-kafka.md: error: invalid frontmatter: yaml: line 1: " +
+					"did not find expected node content [invalid-metadata]\n" +
+					"1 error, 0 warnings\n",
+			},
+		},
+		{
+			name: "unknown dir",
+			opts: lint.Options{Dir: "nope"},
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) lint.StateManager {
+					sm := lint.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(defaultState, nil)
+					sm.EXPECT().NormalizePath("nope").Return("nope", nil)
+
+					return sm
+				},
+				store: noStore,
+			},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "fail to load state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) lint.StateManager {
+					sm := lint.NewmockStateManager(ctrl)
+					sm.EXPECT().Load().Return(state.State{}, os.ErrNotExist)
+
+					return sm
+				},
+				store: noStore,
+			},
+			wants: want{
+				err: os.ErrNotExist,
+			},
+		},
+		{
+			name: "fail to read record",
+			setup: setup{
+				stateManager: loaded(defaultState),
+				store: func(ctrl *gomock.Controller) lint.RecordStore {
+					rs := lint.NewmockRecordStore(ctrl)
+					rs.EXPECT().Scan("/", platformDir).Return(platformRecords, nil, nil)
+					rs.EXPECT().Read("/docs/adr/0001-record-decisions.md").Return(nil, os.ErrPermission)
+
+					return rs
+				},
+			},
+			wants: want{
+				err: os.ErrPermission,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			templates := lint.NewmockTemplateSource(ctrl)
+			templates.EXPECT().Source("/", "").Return(source, nil).AnyTimes()
+
+			h := lint.New(
+				lint.WithStateManager(tt.setup.stateManager(ctrl)),
+				lint.WithRecordStore(tt.setup.store(ctrl)),
+				lint.WithTemplateSource(templates),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.opts)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
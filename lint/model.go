@@ -0,0 +1,9 @@
+package lint
+
+// Options represents the input of the lint command.
+type Options struct {
+	// Dir limits the findings to a single directory, by name or path. The
+	// records of every directory are still checked, so that links and
+	// supersedes across directories are resolved.
+	Dir string
+}
@@ -0,0 +1,27 @@
+package lint
+
+// Option represents a type that is able to override the default resources of
+// the handler. These options are mainly used in a testing capacity.
+type Option func(h *Handler)
+
+// WithStateManager is used to override the internal StateManager of the handler.
+func WithStateManager(sm StateManager) Option {
+	return func(h *Handler) {
+		h.stateManager = sm
+	}
+}
+
+// WithRecordStore is used to override the internal RecordStore of the handler.
+func WithRecordStore(rs RecordStore) Option {
+	return func(h *Handler) {
+		h.store = rs
+	}
+}
+
+// WithTemplateSource is used to override the internal TemplateSource of the
+// handler.
+func WithTemplateSource(ts TemplateSource) Option {
+	return func(h *Handler) {
+		h.templates = ts
+	}
+}
@@ -0,0 +1,71 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The severities a rule can be configured with.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+var (
+	// ErrUnknownSeverity is returned when a rule is configured with a
+	// severity that is not supported.
+	ErrUnknownSeverity = errors.New("unknown severity")
+
+	// ErrUnknownRule is returned when the configuration refers to a rule
+	// that does not exist.
+	ErrUnknownRule = errors.New("unknown rule")
+)
+
+// Severity describes how a finding of a rule is treated. Errors fail the
+// lint command, while warnings are only reported.
+type Severity string
+
+// Config represents the lint configuration of the state file. Rules that are
+// not configured use their default severity.
+type Config struct {
+	// Rules maps the name of a rule to its severity.
+	Rules map[string]Severity `yaml:"rules,omitempty"`
+}
+
+// Validate checks that the configuration only refers to the built-in rules,
+// using the supported severities.
+func (c Config) Validate() error {
+	for name, severity := range c.Rules {
+		if _, ok := find(Builtin(), name); !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownRule, name)
+		}
+
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return fmt.Errorf("%w %q for rule %s", ErrUnknownSeverity, severity, name)
+		}
+	}
+
+	return nil
+}
+
+// Severity returns the configured severity of the rule, or its default.
+func (c Config) Severity(r Rule) Severity {
+	if severity, ok := c.Rules[r.Name()]; ok {
+		return severity
+	}
+
+	return r.Severity()
+}
+
+func find(rules []Rule, name string) (Rule, bool) {
+	for _, r := range rules {
+		if r.Name() == name {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,4 @@
+// Package rule provides the rule engine behind the lint command. Each rule
+// checks the decision records of a project for a single kind of problem,
+// and the severity of every rule can be configured in the state file.
+package rule
@@ -0,0 +1,142 @@
+package rule
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+)
+
+// Rule represents a single check over the records of a project. Rules are
+// pluggable, so the engine can run any type that implements this interface
+// alongside or instead of the built-in rules.
+type Rule interface {
+	// Name is the name the rule is configured by in the state file.
+	Name() string
+	// Description briefly describes the problem the rule finds.
+	Description() string
+	// Severity is the severity of the rule when it is not configured.
+	Severity() Severity
+	// Check returns the problems found within the project. The severity of
+	// the findings is set by the engine.
+	Check(p Project) []Finding
+}
+
+// Project represents the records of every adr directory of a project.
+type Project struct {
+	Dirs []Dir
+}
+
+// Dir represents an adr directory along with its records.
+type Dir struct {
+	adr.Directory
+
+	Records []Record
+	// Invalid holds the files of the directory that are named like a
+	// record, but whose metadata cannot be parsed.
+	Invalid []store.InvalidRecord
+	// Sections lists the sections that the template of the directory
+	// declares, which every record is expected to fill in.
+	Sections []string
+}
+
+// Record represents a record along with the markdown it was parsed from.
+type Record struct {
+	adr.Record
+
+	Data []byte
+}
+
+// Finding represents a single problem found by a rule.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	// Path is the location of the file, relative to the state dir.
+	Path string
+	// Line is the line of the problem, starting at 1, or zero if the
+	// problem concerns the whole file.
+	Line    int
+	Message string
+}
+
+// Engine runs a set of rules with the severities of a configuration.
+type Engine struct {
+	config Config
+	rules  []Rule
+}
+
+// NewEngine produces an engine that runs the given rules, or the built-in
+// rules if none are given.
+func NewEngine(config Config, rules ...Rule) *Engine {
+	if len(rules) == 0 {
+		rules = Builtin()
+	}
+
+	return &Engine{config: config, rules: rules}
+}
+
+// Run checks the project with every rule that is not turned off. The
+// findings are ordered by path and line.
+func (e *Engine) Run(p Project) []Finding {
+	var findings []Finding
+
+	for _, r := range e.rules {
+		severity := e.config.Severity(r)
+		if severity == SeverityOff {
+			continue
+		}
+
+		for _, f := range r.Check(p) {
+			f.Rule, f.Severity = r.Name(), severity
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings
+}
+
+// Sections returns the sections declared by the source of a template, which
+// are its second level headings. Headings that are filled in by the template
+// are skipped.
+func Sections(source []byte) []string {
+	var sections []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "## ") && !strings.Contains(line, "{{") {
+			sections = append(sections, strings.TrimSpace(line[3:]))
+		}
+	}
+
+	return sections
+}
+
+// lineOf returns the first line of the markdown that contains the text, or
+// zero if there is none.
+func lineOf(data []byte, text string) int {
+	if text == "" {
+		return 0
+	}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.Contains(line, text) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,204 @@
+package rule_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/adr/store"
+	"github.com/docula-io/docula/lint/rule"
+)
+
+func record(t *testing.T, dir string, filePath string, data string) rule.Record {
+	rec, err := adr.ParseRecord(path.Base(filePath), []byte(data))
+	assert.NoError(t, err)
+
+	rec.Dir, rec.Path = dir, filePath
+
+	return rule.Record{Record: rec, Data: []byte(data)}
+}
+
+func project(t *testing.T) rule.Project {
+	return rule.Project{
+		Dirs: []rule.Dir{
+			{
+				Directory: adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential},
+				Sections:  []string{"Status", "Context", "Decision"},
+				Records: []rule.Record{
+					record(t, "platform", "docs/adr/0001-use-postgresql.md", `# Use PostgreSQL
+
+## Status
+
+Accepted
+
+## Context
+
+We need a database.
+
+## Decision
+
+<!-- to be written -->
+`),
+					record(t, "platform", "docs/adr/0002-use-kafka.md", `---
+status: Approved
+---
+# Use Kafka
+
+## Context
+
+Supersedes [0001. Use PostgreSQL](0001-use-postgresql.md)
+
+## Decision
+
+Kafka.
+`),
+					record(t, "platform", "docs/adr/002-Use-NATS.md", `# Use NATS
+
+## Status
+
+Proposed
+
+Relates to [0009. Gone](0009-gone.md)
+
+## Context
+
+NATS.
+
+## Decision
+
+NATS.
+`),
+				},
+			},
+			{
+				Directory: adr.Directory{Path: "security", Name: "security", Index: adr.IndexTimestamp},
+				Records: []rule.Record{
+					record(t, "security", "security/20220714093000-add-mtls.md", "# Add mTLS\n\nStatus: Accepted\n"),
+					record(t, "security", "security/20220801-pin-tls.md", "# Pin TLS\n\nStatus: Accepted\n"),
+					record(t, "security", "security/0003-rotate-keys.md", "# Rotate keys\n"),
+				},
+				Invalid: []store.InvalidRecord{
+					{Path: "security/0004-pin-tls.md", Err: adr.ErrInvalidFrontmatter},
+				},
+			},
+		},
+	}
+}
+
+func TestEngine(t *testing.T) {
+	findings := rule.NewEngine(rule.Config{}).Run(project(t))
+
+	assert.Equal(t, []rule.Finding{
+		{
+			Rule: rule.SupersededAccepted, Severity: rule.SeverityError, Path: "docs/adr/0001-use-postgresql.md", Line: 5,
+			Message: "superseded by docs/adr/0002-use-kafka.md but still marked Accepted",
+		},
+		{
+			Rule: rule.RequiredSections, Severity: rule.SeverityError, Path: "docs/adr/0001-use-postgresql.md", Line: 11,
+			Message: `section "Decision" is empty`,
+		},
+		{
+			Rule: rule.DuplicateNumber, Severity: rule.SeverityError, Path: "docs/adr/0002-use-kafka.md",
+			Message: "number 0002 is also used by docs/adr/002-Use-NATS.md",
+		},
+		{
+			Rule: rule.UnknownStatus, Severity: rule.SeverityError, Path: "docs/adr/0002-use-kafka.md", Line: 2,
+			Message: "unknown status \"Approved\", expected one of Draft, Proposed, Accepted, Rejected, Deprecated, Superseded",
+		},
+		{
+			Rule: rule.DuplicateNumber, Severity: rule.SeverityError, Path: "docs/adr/002-Use-NATS.md",
+			Message: "number 002 is also used by docs/adr/0002-use-kafka.md",
+		},
+		{
+			Rule: rule.FileName, Severity: rule.SeverityWarning, Path: "docs/adr/002-Use-NATS.md",
+			Message: "002 is not a sequential number of at least 4 digits",
+		},
+		{
+			Rule: rule.FileName, Severity: rule.SeverityWarning, Path: "docs/adr/002-Use-NATS.md",
+			Message: `"Use-NATS" is not a lower case slug of the title`,
+		},
+		{
+			Rule: rule.DanglingLink, Severity: rule.SeverityError, Path: "docs/adr/002-Use-NATS.md", Line: 7,
+			Message: "Relates to link to 0009-gone.md does not resolve to a record",
+		},
+		{
+			Rule: rule.UnknownStatus, Severity: rule.SeverityError, Path: "security/0003-rotate-keys.md",
+			Message: "record has no status",
+		},
+		{
+			Rule: rule.FileName, Severity: rule.SeverityWarning, Path: "security/0003-rotate-keys.md",
+			Message: "0003 is not a timestamp of the form 20060102150405 or 20060102",
+		},
+		{
+			Rule: rule.InvalidMetadata, Severity: rule.SeverityError, Path: "security/0004-pin-tls.md",
+			Message: "invalid frontmatter",
+		},
+	}, findings)
+}
+
+func TestEngineConfig(t *testing.T) {
+	config := rule.Config{
+		Rules: map[string]rule.Severity{
+			rule.DuplicateNumber:    rule.SeverityOff,
+			rule.FileName:           rule.SeverityOff,
+			rule.RequiredSections:   rule.SeverityOff,
+			rule.SupersededAccepted: rule.SeverityOff,
+			rule.UnknownStatus:      rule.SeverityWarning,
+		},
+	}
+
+	assert.NoError(t, config.Validate())
+
+	findings := rule.NewEngine(config).Run(project(t))
+
+	var severities []rule.Severity
+
+	for _, f := range findings {
+		severities = append(severities, f.Severity)
+	}
+
+	assert.Equal(t, []rule.Severity{rule.SeverityWarning, rule.SeverityError, rule.SeverityWarning, rule.SeverityError}, severities)
+}
+
+type titleRule struct{}
+
+func (titleRule) Name() string            { return "title" }
+func (titleRule) Description() string     { return "titles are not shouting" }
+func (titleRule) Severity() rule.Severity { return rule.SeverityWarning }
+func (titleRule) Check(p rule.Project) []rule.Finding {
+	var findings []rule.Finding
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			if rec.Title == "Use NATS" {
+				findings = append(findings, rule.Finding{Path: rec.Path, Line: 1, Message: "NATS is shouting"})
+			}
+		}
+	}
+
+	return findings
+}
+
+func TestEngineCustomRules(t *testing.T) {
+	findings := rule.NewEngine(rule.Config{}, titleRule{}).Run(project(t))
+
+	assert.Equal(t, []rule.Finding{
+		{Rule: "title", Severity: rule.SeverityWarning, Path: "docs/adr/002-Use-NATS.md", Line: 1, Message: "NATS is shouting"},
+	}, findings)
+}
+
+func TestConfigValidate(t *testing.T) {
+	err := rule.Config{Rules: map[string]rule.Severity{"nope": rule.SeverityError}}.Validate()
+	assert.ErrorIs(t, err, rule.ErrUnknownRule)
+
+	err = rule.Config{Rules: map[string]rule.Severity{rule.FileName: "fatal"}}.Validate()
+	assert.ErrorIs(t, err, rule.ErrUnknownSeverity)
+}
+
+func TestSections(t *testing.T) {
+	source := "# {{ .Title }}\n\n## Status\n\n{{ .Status }}\n\n## Context\n\n### Detail\n\n## {{ .Custom }}\n"
+
+	assert.Equal(t, []string{"Status", "Context"}, rule.Sections([]byte(source)))
+}
@@ -0,0 +1,392 @@
+package rule
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// The names of the built-in rules.
+const (
+	RequiredSections   = "required-sections"
+	UnknownStatus      = "unknown-status"
+	DuplicateNumber    = "duplicate-number"
+	FileName           = "file-name"
+	DanglingLink       = "dangling-link"
+	SupersededAccepted = "superseded-accepted"
+	InvalidMetadata    = "invalid-metadata"
+)
+
+var slug = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Builtin returns the rules that docula ships with.
+func Builtin() []Rule {
+	return []Rule{
+		requiredSections{},
+		unknownStatus{},
+		duplicateNumber{},
+		fileName{},
+		danglingLink{},
+		supersededAccepted{},
+		invalidMetadata{},
+	}
+}
+
+// requiredSections reports records that lack a section declared by the
+// template of their directory, or that leave one empty.
+type requiredSections struct{}
+
+func (requiredSections) Name() string { return RequiredSections }
+
+func (requiredSections) Description() string {
+	return "sections of the template are missing or empty"
+}
+
+func (requiredSections) Severity() Severity { return SeverityError }
+
+func (requiredSections) Check(p Project) []Finding {
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			found := sections(rec.Data)
+
+			for _, name := range dir.Sections {
+				// The status section moves into the frontmatter of records
+				// that have one.
+				if rec.Style == adr.StyleFrontmatter && strings.EqualFold(name, "status") {
+					continue
+				}
+
+				s, ok := found[strings.ToLower(name)]
+
+				switch {
+				case !ok:
+					findings = append(findings, Finding{
+						Path:    rec.Path,
+						Message: fmt.Sprintf("missing section %q", name),
+					})
+				case !s.content:
+					findings = append(findings, Finding{
+						Path:    rec.Path,
+						Line:    s.line,
+						Message: fmt.Sprintf("section %q is empty", name),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+type section struct {
+	line    int
+	content bool
+}
+
+// sections returns the second level sections of the markdown keyed by their
+// lower case name. Blank lines and comments do not count as content, and
+// neither do lower level headings.
+func sections(data []byte) map[string]*section {
+	res := map[string]*section{}
+
+	var (
+		current *section
+		fenced  bool
+	)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+		}
+
+		switch {
+		case !fenced && strings.HasPrefix(trimmed, "## "):
+			current = &section{line: i + 1}
+			res[strings.ToLower(strings.TrimSpace(trimmed[3:]))] = current
+		case !fenced && strings.HasPrefix(trimmed, "# "):
+			current = nil
+		case current == nil || trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "<!--") && strings.HasSuffix(trimmed, "-->"):
+		default:
+			current.content = true
+		}
+	}
+
+	return res
+}
+
+// unknownStatus reports records whose status is not declared by the
+// workflow of their directory.
+type unknownStatus struct{}
+
+func (unknownStatus) Name() string { return UnknownStatus }
+
+func (unknownStatus) Description() string {
+	return "the status is missing or not part of the workflow of the directory"
+}
+
+func (unknownStatus) Severity() Severity { return SeverityError }
+
+func (unknownStatus) Check(p Project) []Finding {
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		workflow := dir.StatusWorkflow()
+
+		for _, rec := range dir.Records {
+			if rec.Status == "" {
+				findings = append(findings, Finding{Path: rec.Path, Message: "record has no status"})
+				continue
+			}
+
+			if _, ok := workflow.Status(rec.Status); !ok {
+				findings = append(findings, Finding{
+					Path: rec.Path,
+					Line: lineOf(rec.Data, rec.Status),
+					Message: fmt.Sprintf("unknown status %q, expected one of %s",
+						adr.BaseStatus(rec.Status), strings.Join(workflow.Statuses, ", ")),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// duplicateNumber reports records that share their number with another
+// record of the same directory.
+type duplicateNumber struct{}
+
+func (duplicateNumber) Name() string { return DuplicateNumber }
+
+func (duplicateNumber) Description() string {
+	return "several records of a directory have the same number"
+}
+
+func (duplicateNumber) Severity() Severity { return SeverityError }
+
+func (duplicateNumber) Check(p Project) []Finding {
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		byNumber := map[string][]string{}
+
+		for _, rec := range dir.Records {
+			byNumber[adr.IDNumber(rec.ID)] = append(byNumber[adr.IDNumber(rec.ID)], rec.Path)
+		}
+
+		for _, rec := range dir.Records {
+			var others []string
+
+			for _, p := range byNumber[adr.IDNumber(rec.ID)] {
+				if p != rec.Path {
+					others = append(others, p)
+				}
+			}
+
+			if len(others) > 0 {
+				findings = append(findings, Finding{
+					Path:    rec.Path,
+					Message: fmt.Sprintf("number %s is also used by %s", rec.ID, strings.Join(others, ", ")),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// fileName reports records whose file name does not match the index type of
+// their directory, or whose title slug is not lower case.
+type fileName struct{}
+
+func (fileName) Name() string { return FileName }
+
+func (fileName) Description() string {
+	return "the file name does not match the index type of the directory"
+}
+
+func (fileName) Severity() Severity { return SeverityWarning }
+
+func (fileName) Check(p Project) []Finding {
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			name := path.Base(rec.Path)
+			rest := strings.TrimSuffix(strings.TrimPrefix(name, rec.ID+"-"), ".md")
+
+			switch dir.IndexType() {
+			case adr.IndexTimestamp:
+				if !adr.IsTimestampID(rec.ID) {
+					findings = append(findings, Finding{
+						Path:    rec.Path,
+						Message: fmt.Sprintf("%s is not a timestamp of the form %s or %s", rec.ID, adr.TimestampIndexFormat, adr.DateIndexFormat),
+					})
+				}
+			default:
+				if len(rec.ID) < 4 || adr.InferIndex([]string{rec.ID}) == adr.IndexTimestamp {
+					findings = append(findings, Finding{
+						Path:    rec.Path,
+						Message: fmt.Sprintf("%s is not a sequential number of at least 4 digits", rec.ID),
+					})
+				}
+			}
+
+			if !slug.MatchString(rest) {
+				findings = append(findings, Finding{
+					Path:    rec.Path,
+					Message: fmt.Sprintf("%q is not a lower case slug of the title", rest),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// danglingLink reports links between records whose target is not a record
+// of any directory.
+type danglingLink struct{}
+
+func (danglingLink) Name() string { return DanglingLink }
+
+func (danglingLink) Description() string {
+	return "a supersedes, amends or relates to link does not resolve to a record"
+}
+
+func (danglingLink) Severity() Severity { return SeverityError }
+
+func (danglingLink) Check(p Project) []Finding {
+	records := byPath(p)
+
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			for _, l := range rec.Links {
+				if _, ok := records[l.Resolve(rec.Path)]; ok {
+					continue
+				}
+
+				findings = append(findings, Finding{
+					Path:    rec.Path,
+					Line:    lineOf(rec.Data, "("+l.Target+")"),
+					Message: fmt.Sprintf("%s link to %s does not resolve to a record", l.Type, l.Target),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// supersededAccepted reports records that have been superseded, but whose
+// status still says they are accepted.
+type supersededAccepted struct{}
+
+func (supersededAccepted) Name() string { return SupersededAccepted }
+
+func (supersededAccepted) Description() string {
+	return "a superseded record is still marked as accepted"
+}
+
+func (supersededAccepted) Severity() Severity { return SeverityError }
+
+func (supersededAccepted) Check(p Project) []Finding {
+	records := byPath(p)
+
+	// successors maps the path of each superseded record to the records
+	// that supersede it, taking the links from both ends.
+	successors := map[string][]string{}
+
+	add := func(old string, successor string) {
+		for _, s := range successors[old] {
+			if s == successor {
+				return
+			}
+		}
+
+		successors[old] = append(successors[old], successor)
+	}
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			for _, l := range rec.Links {
+				target, ok := records[l.Resolve(rec.Path)]
+				if !ok {
+					continue
+				}
+
+				switch l.Type {
+				case adr.LinkSupersedes:
+					add(target.Path, rec.Path)
+				case adr.LinkSupersededBy:
+					add(rec.Path, target.Path)
+				}
+			}
+		}
+	}
+
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			if len(successors[rec.Path]) == 0 || !rec.HasStatus(adr.StatusAccepted) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Path: rec.Path,
+				Line: lineOf(rec.Data, rec.Status),
+				Message: fmt.Sprintf("superseded by %s but still marked %s",
+					strings.Join(successors[rec.Path], ", "), rec.Status),
+			})
+		}
+	}
+
+	return findings
+}
+
+func byPath(p Project) map[string]Record {
+	records := map[string]Record{}
+
+	for _, dir := range p.Dirs {
+		for _, rec := range dir.Records {
+			records[rec.Path] = rec
+		}
+	}
+
+	return records
+}
+
+// invalidMetadata reports files that are named like a record, but whose
+// metadata cannot be parsed. Such records are left out of every other rule.
+type invalidMetadata struct{}
+
+func (invalidMetadata) Name() string { return InvalidMetadata }
+
+func (invalidMetadata) Description() string {
+	return "the metadata of a record cannot be parsed"
+}
+
+func (invalidMetadata) Severity() Severity { return SeverityError }
+
+func (invalidMetadata) Check(p Project) []Finding {
+	var findings []Finding
+
+	for _, dir := range p.Dirs {
+		for _, inv := range dir.Invalid {
+			findings = append(findings, Finding{Path: inv.Path, Message: inv.Err.Error()})
+		}
+	}
+
+	return findings
+}
@@ -14,6 +14,9 @@ func main() {
 	defer cancel()
 
 	if err := cmd.Execute(ctx); err != nil {
-		fmt.Println("failed to execute command:", err)
+		fmt.Fprintln(os.Stderr, "failed to execute command:", err)
+
+		cancel()
+		os.Exit(1)
 	}
 }
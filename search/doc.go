@@ -0,0 +1,5 @@
+// Package search provides handler functionality for the search command,
+// which searches the documents of every directory registered in the docula
+// state file. The search index is cached next to the state file and only
+// updated for the documents that changed since the last search.
+package search
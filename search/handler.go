@@ -0,0 +1,260 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/search/index"
+	"github.com/docula-io/docula/state"
+)
+
+// Handler describes a type that is used to handle the search command.
+type Handler struct {
+	stateManager StateManager
+	fs           FileSystem
+}
+
+// New acts as the default constructor for the Handler type. This method
+// will initialize defaults for the internal resources, or will override them
+// with any provided options. This method should be used instead of direct
+// instantiation.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		stateManager: state.NewManager(),
+		fs:           &defaultFileSystem{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// loadIndex reads the cached index. A missing or unreadable cache, such as
+// one written by another version of docula, yields an empty index.
+func (h *Handler) loadIndex(cachePath string) (*index.Index, error) {
+	data, err := h.fs.ReadFile(cachePath)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return index.New(), nil
+	case err != nil:
+		return nil, fmt.Errorf("read search index: %w", err)
+	}
+
+	idx, err := index.Decode(data)
+	if err != nil {
+		return index.New(), nil
+	}
+
+	return idx, nil
+}
+
+func (h *Handler) saveIndex(stateDir string, idx *index.Index) error {
+	data, err := idx.Encode()
+	if err != nil {
+		return err
+	}
+
+	if err = h.fs.MkdirAll(stateDir+CacheDir, 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	cachePath := path.Join(stateDir+CacheDir, IndexName)
+	tmpPath := cachePath + ".tmp"
+
+	if err = h.fs.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("write search index: %w", err)
+	}
+
+	if err = h.fs.Rename(tmpPath, cachePath); err != nil {
+		return fmt.Errorf("rename search index: %w", err)
+	}
+
+	return nil
+}
+
+// update brings the index up to date with the markdown files of the dir and
+// its sub dirs. Files whose size and modification time are unchanged are
+// skipped, and files whose contents hash the same are not indexed again.
+// It reports whether the index was changed.
+func (h *Handler) update(idx *index.Index, stateDir string, dir adr.Directory, rel string, seen map[string]bool) (bool, error) {
+	entries, err := h.fs.ReadDir(stateDir + rel)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("read dir %s: %w", rel, err)
+	}
+
+	var changed bool
+
+	for _, entry := range entries {
+		filePath := path.Join(rel, entry.Name())
+
+		if strings.HasPrefix(entry.Name(), ".") || seen[filePath] {
+			continue
+		}
+
+		if entry.IsDir() {
+			c, err := h.update(idx, stateDir, dir, filePath, seen)
+			if err != nil {
+				return false, err
+			}
+
+			changed = changed || c
+
+			continue
+		}
+
+		if path.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		seen[filePath] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			return false, fmt.Errorf("stat %s: %w", filePath, err)
+		}
+
+		doc, ok := idx.Docs[filePath]
+		if ok && doc.Dir == dir.Name && doc.ModTime == info.ModTime().UnixNano() && doc.Size == info.Size() {
+			continue
+		}
+
+		data, err := h.fs.ReadFile(stateDir + filePath)
+		if err != nil {
+			return false, fmt.Errorf("read %s: %w", filePath, err)
+		}
+
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		changed = true
+
+		if ok && doc.Dir == dir.Name && doc.Hash == hash {
+			doc.ModTime, doc.Size = info.ModTime().UnixNano(), info.Size()
+			continue
+		}
+
+		updated := index.NewDocument(dir.Name, filePath, data)
+		updated.ModTime, updated.Size, updated.Hash = info.ModTime().UnixNano(), info.Size(), hash
+
+		idx.Add(updated)
+	}
+
+	return changed, nil
+}
+
+func write(out io.Writer, results []index.Result) {
+	for _, res := range results {
+		location := res.Document.Path
+		if res.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, res.Line)
+		}
+
+		title := res.Document.Title
+		if res.Document.Status != "" {
+			title = fmt.Sprintf("%s (%s)", title, adr.BaseStatus(res.Document.Status))
+		}
+
+		fmt.Fprintf(out, "%s  %s\n", location, title)
+
+		if res.Snippet != "" {
+			fmt.Fprintf(out, "    %s\n", res.Snippet)
+		}
+	}
+}
+
+// Handle is the main Handler function. This function brings the cached
+// search index up to date with the documents of every registered dir, and
+// prints the documents matching the search terms, most relevant first.
+func (h *Handler) Handle(ctx context.Context, out io.Writer, opts Options) error {
+	q, err := index.ParseQuery(opts.Terms)
+	if err != nil {
+		return err
+	}
+
+	s, err := h.stateManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading state: %w", err)
+	}
+
+	var only string
+
+	if opts.Dir != "" {
+		dir, err := s.ADR.LookupDirectory(opts.Dir, h.stateManager.NormalizePath)
+		if err != nil {
+			return err
+		}
+
+		only = dir.Name
+	}
+
+	stateDir, err := h.stateManager.StateDir()
+	if err != nil {
+		return fmt.Errorf("obtain state path: %w", err)
+	}
+
+	idx, err := h.loadIndex(path.Join(stateDir+CacheDir, IndexName))
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	changed := false
+
+	for _, dir := range s.ADR.Directories {
+		c, err := h.update(idx, stateDir, dir, dir.Path, seen)
+		if err != nil {
+			return err
+		}
+
+		changed = changed || c
+	}
+
+	for p := range idx.Docs {
+		if !seen[p] {
+			idx.Remove(p)
+			changed = true
+		}
+	}
+
+	if changed {
+		if err = h.saveIndex(stateDir, idx); err != nil {
+			return err
+		}
+	}
+
+	var results []index.Result
+
+	for _, res := range idx.Search(q) {
+		if only == "" || res.Document.Dir == only {
+			results = append(results, res)
+		}
+	}
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(out, "no documents match %q\n", opts.Terms)
+		return nil
+	}
+
+	write(out, results)
+
+	return nil
+}
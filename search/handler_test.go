@@ -0,0 +1,375 @@
+package search_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/search"
+	"github.com/docula-io/docula/search/index"
+	"github.com/docula-io/docula/state"
+)
+
+type fileInfo struct {
+	size    int64
+	modTime time.Time
+}
+
+func (f fileInfo) Name() string       { return "" }
+func (f fileInfo) Size() int64        { return f.size }
+func (f fileInfo) Mode() fs.FileMode  { return 0 }
+func (f fileInfo) ModTime() time.Time { return f.modTime }
+func (f fileInfo) IsDir() bool        { return false }
+func (f fileInfo) Sys() interface{}   { return nil }
+
+type dirEntry struct {
+	name string
+	dir  bool
+	info fileInfo
+}
+
+func (d dirEntry) Name() string               { return d.name }
+func (d dirEntry) IsDir() bool                { return d.dir }
+func (d dirEntry) Type() fs.FileMode          { return 0 }
+func (d dirEntry) Info() (fs.FileInfo, error) { return d.info, nil }
+
+var (
+	platformDir = adr.Directory{Path: "docs/adr", Name: "platform", Index: adr.IndexSequential}
+	securityDir = adr.Directory{Path: "security", Name: "security", Index: adr.IndexSequential}
+
+	defaultState = state.State{
+		ADR: adr.State{
+			Directories: []adr.Directory{platformDir, securityDir},
+		},
+	}
+
+	modTime = time.Date(2022, 7, 14, 9, 30, 0, 0, time.UTC)
+
+	kafkaRecord = []byte("# Use Kafka\n\n## Status\n\nAccepted\n\n## Context\n\nEvent sourcing needs a durable log.\n")
+	draftRecord = []byte("# Event sourcing\n\nA draft on event sourcing and snapshots.\n")
+	mtlsRecord  = []byte("# Add mTLS\n\n## Status\n\nProposed\n\n## Context\n\nEvent streams must be encrypted.\n")
+
+	cachePath = "/.docula-cache/search.idx"
+)
+
+func entry(name string, data []byte) dirEntry {
+	return dirEntry{name: name, info: fileInfo{size: int64(len(data)), modTime: modTime}}
+}
+
+func document(dir string, filePath string, data []byte) index.Document {
+	sum := sha256.Sum256(data)
+
+	doc := index.NewDocument(dir, filePath, data)
+	doc.ModTime, doc.Size, doc.Hash = modTime.UnixNano(), int64(len(data)), hex.EncodeToString(sum[:])
+
+	return doc
+}
+
+// indexOf matches an encoded index holding exactly the given documents.
+type indexOf []index.Document
+
+func (m indexOf) Matches(x interface{}) bool {
+	data, ok := x.([]byte)
+	if !ok {
+		return false
+	}
+
+	idx, err := index.Decode(data)
+	if err != nil || len(idx.Docs) != len(m) {
+		return false
+	}
+
+	for _, doc := range m {
+		if got, ok := idx.Docs[doc.Path]; !ok || !reflect.DeepEqual(*got, doc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m indexOf) String() string {
+	return fmt.Sprintf("is an index of %d documents", len(m))
+}
+
+// cached produces the encoded index of the given documents.
+func cached(t *testing.T, docs ...index.Document) []byte {
+	idx := index.New()
+
+	for _, doc := range docs {
+		idx.Add(doc)
+	}
+
+	data, err := idx.Encode()
+	assert.NoError(t, err)
+
+	return data
+}
+
+func TestHandler(t *testing.T) {
+	type setup struct {
+		stateManager func(ctrl *gomock.Controller) search.StateManager
+		fs           func(ctrl *gomock.Controller) search.FileSystem
+	}
+
+	type want struct {
+		err    error
+		output string
+	}
+
+	defaultStateManager := func(ctrl *gomock.Controller) search.StateManager {
+		s := search.NewmockStateManager(ctrl)
+		s.EXPECT().Load().Return(defaultState, nil)
+		s.EXPECT().StateDir().Return("/", nil)
+		return s
+	}
+
+	upToDate := []index.Document{
+		document("platform", "docs/adr/0001-use-kafka.md", kafkaRecord),
+		document("platform", "docs/adr/drafts/events.md", draftRecord),
+		document("security", "security/0001-add-mtls.md", mtlsRecord),
+	}
+
+	// listDirs sets up the listing of every registered dir, which holds a
+	// record and a sub dir in the platform dir, and a record in the security
+	// dir. Hidden and non markdown files are skipped.
+	listDirs := func(readDir func(name interface{}) *gomock.Call) {
+		readDir("/docs/adr").Return([]os.DirEntry{
+			dirEntry{name: ".git", dir: true},
+			entry("0001-use-kafka.md", kafkaRecord),
+			dirEntry{name: "drafts", dir: true},
+			entry("diagram.png", nil),
+		}, nil)
+		readDir("/docs/adr/drafts").Return([]os.DirEntry{
+			entry("events.md", draftRecord),
+		}, nil)
+		readDir("/security").Return([]os.DirEntry{
+			entry("0001-add-mtls.md", mtlsRecord),
+		}, nil)
+	}
+
+	readDocs := func(readFile func(name interface{}) *gomock.Call) {
+		readFile("/docs/adr/0001-use-kafka.md").Return(kafkaRecord, nil)
+		readFile("/docs/adr/drafts/events.md").Return(draftRecord, nil)
+		readFile("/security/0001-add-mtls.md").Return(mtlsRecord, nil)
+	}
+
+	eventOutput := "docs/adr/drafts/events.md:1  Event sourcing\n" +
+		"    # Event sourcing\n" +
+		"docs/adr/0001-use-kafka.md:9  Use Kafka (Accepted)\n" +
+		"    Event sourcing needs a durable log.\n"
+
+	testCases := []struct {
+		name  string
+		setup setup
+		input search.Options
+		wants want
+	}{
+		{
+			name: "builds the index when there is no cache",
+			setup: setup{
+				stateManager: defaultStateManager,
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(nil, os.ErrNotExist)
+					listDirs(fs.EXPECT().ReadDir)
+					readDocs(fs.EXPECT().ReadFile)
+					fs.EXPECT().MkdirAll("/.docula-cache", os.FileMode(0o755)).Return(nil)
+					fs.EXPECT().WriteFile(cachePath+".tmp", indexOf(upToDate), os.FileMode(0o644)).Return(nil)
+					fs.EXPECT().Rename(cachePath+".tmp", cachePath).Return(nil)
+					return fs
+				},
+			},
+			input: search.Options{Terms: `"event sourcing"`},
+			wants: want{
+				output: eventOutput,
+			},
+		},
+		{
+			name: "uses the cache when nothing changed",
+			setup: setup{
+				stateManager: defaultStateManager,
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(cached(t, upToDate...), nil)
+					listDirs(fs.EXPECT().ReadDir)
+					return fs
+				},
+			},
+			input: search.Options{Terms: `"event sourcing"`},
+			wants: want{
+				output: eventOutput,
+			},
+		},
+		{
+			name: "indexes changed files and drops removed ones",
+			setup: setup{
+				stateManager: defaultStateManager,
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					stale := document("platform", "docs/adr/0001-use-kafka.md", []byte("# Use RabbitMQ\n"))
+					touched := document("security", "security/0001-add-mtls.md", mtlsRecord)
+					touched.ModTime = 0
+
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(cached(t,
+						stale, touched, upToDate[1],
+						document("platform", "docs/adr/0002-removed.md", []byte("# Removed event sourcing\n")),
+					), nil)
+					listDirs(fs.EXPECT().ReadDir)
+					fs.EXPECT().ReadFile("/docs/adr/0001-use-kafka.md").Return(kafkaRecord, nil)
+					fs.EXPECT().ReadFile("/security/0001-add-mtls.md").Return(mtlsRecord, nil)
+					fs.EXPECT().MkdirAll("/.docula-cache", os.FileMode(0o755)).Return(nil)
+					fs.EXPECT().WriteFile(cachePath+".tmp", indexOf(upToDate), os.FileMode(0o644)).Return(nil)
+					fs.EXPECT().Rename(cachePath+".tmp", cachePath).Return(nil)
+					return fs
+				},
+			},
+			input: search.Options{Terms: `"event sourcing"`},
+			wants: want{
+				output: eventOutput,
+			},
+		},
+		{
+			name: "field scoped terms within a single dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) search.StateManager {
+					s := search.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().StateDir().Return("/", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(cached(t, upToDate...), nil)
+					listDirs(fs.EXPECT().ReadDir)
+					return fs
+				},
+			},
+			input: search.Options{Terms: "status:proposed event", Dir: "security"},
+			wants: want{
+				output: "security/0001-add-mtls.md:9  Add mTLS (Proposed)\n" +
+					"    Event streams must be encrypted.\n",
+			},
+		},
+		{
+			name: "limits the results",
+			setup: setup{
+				stateManager: defaultStateManager,
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(cached(t, upToDate...), nil)
+					listDirs(fs.EXPECT().ReadDir)
+					return fs
+				},
+			},
+			input: search.Options{Terms: "event", Limit: 1},
+			wants: want{
+				output: "docs/adr/drafts/events.md:1  Event sourcing\n" +
+					"    # Event sourcing\n",
+			},
+		},
+		{
+			name: "no matches",
+			setup: setup{
+				stateManager: defaultStateManager,
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					fs := search.NewmockFileSystem(ctrl)
+					fs.EXPECT().ReadFile(cachePath).Return(cached(t, upToDate...), nil)
+					listDirs(fs.EXPECT().ReadDir)
+					return fs
+				},
+			},
+			input: search.Options{Terms: "graphql"},
+			wants: want{
+				output: "no documents match \"graphql\"\n",
+			},
+		},
+		{
+			name: "empty query",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) search.StateManager {
+					return search.NewmockStateManager(ctrl)
+				},
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					return search.NewmockFileSystem(ctrl)
+				},
+			},
+			input: search.Options{Terms: `""`},
+			wants: want{
+				err: index.ErrEmptyQuery,
+			},
+		},
+		{
+			name: "unknown dir",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) search.StateManager {
+					s := search.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(defaultState, nil)
+					s.EXPECT().NormalizePath("nope").Return("nope", nil)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					return search.NewmockFileSystem(ctrl)
+				},
+			},
+			input: search.Options{Terms: "event", Dir: "nope"},
+			wants: want{
+				err: adr.ErrDirNotFound,
+			},
+		},
+		{
+			name: "failing to load the state",
+			setup: setup{
+				stateManager: func(ctrl *gomock.Controller) search.StateManager {
+					s := search.NewmockStateManager(ctrl)
+					s.EXPECT().Load().Return(state.State{}, state.ErrNotFound)
+					return s
+				},
+				fs: func(ctrl *gomock.Controller) search.FileSystem {
+					return search.NewmockFileSystem(ctrl)
+				},
+			},
+			input: search.Options{Terms: "event"},
+			wants: want{
+				err: state.ErrNotFound,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := search.New(
+				search.WithStateManager(tt.setup.stateManager(ctrl)),
+				search.WithFileSystem(tt.setup.fs(ctrl)),
+			)
+
+			out := &bytes.Buffer{}
+
+			err := h.Handle(context.Background(), out, tt.input)
+
+			if tt.wants.err != nil {
+				assert.ErrorIs(t, err, tt.wants.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wants.output, out.String())
+		})
+	}
+}
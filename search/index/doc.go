@@ -0,0 +1,5 @@
+// Package index provides the inverted index behind the search command. The
+// index records where each term occurs within the documents, and is encoded
+// into a cache file so that it only needs to be updated for the documents
+// that changed since the last search.
+package index
@@ -0,0 +1,110 @@
+package index
+
+import (
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/docula-io/docula/adr"
+)
+
+// The fields of a document that terms are indexed under. Queries can be
+// scoped to a field, such as title:postgres.
+const (
+	FieldBody   = "body"
+	FieldTitle  = "title"
+	FieldStatus = "status"
+	FieldTag    = "tag"
+	FieldDir    = "dir"
+)
+
+// Fields returns the fields that a query can be scoped to.
+func Fields() []string {
+	return []string{FieldBody, FieldTitle, FieldStatus, FieldTag, FieldDir}
+}
+
+// Document represents a single indexed file.
+type Document struct {
+	// Path is the location of the file, relative to the state dir.
+	Path   string
+	Dir    string
+	Title  string
+	Status string
+	Tags   []string
+
+	// ModTime, Size and Hash describe the contents the document was indexed
+	// from, and are used to tell whether it needs to be indexed again.
+	ModTime int64
+	Size    int64
+	Hash    string
+
+	// Lines holds the text of the document, from which snippets are taken.
+	Lines []string
+}
+
+// NewDocument produces the document of the file at the given path. The
+// metadata of decision records is read from the record, while the title of
+// any other document is its first heading.
+func NewDocument(dir string, filePath string, data []byte) Document {
+	doc := Document{
+		Path:  filePath,
+		Dir:   dir,
+		Lines: strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"),
+	}
+
+	if rec, err := adr.ParseRecord(path.Base(filePath), data); err == nil {
+		doc.Title = rec.Title
+		doc.Status = rec.Status
+		doc.Tags = rec.Tags
+
+		return doc
+	}
+
+	for _, line := range doc.Lines {
+		if strings.HasPrefix(line, "# ") {
+			doc.Title = strings.TrimSpace(line[2:])
+			break
+		}
+	}
+
+	return doc
+}
+
+// token represents a single term along with where it was found.
+type token struct {
+	term string
+	occ  Occurrence
+}
+
+// tokens splits the fields of the document into terms. Terms are the runs of
+// letters and digits of the text, folded to lower case.
+func (d Document) tokens() []token {
+	var res []token
+
+	add := func(field string, line int, text string) {
+		for _, term := range Terms(text) {
+			res = append(res, token{term: term, occ: Occurrence{Field: field, Line: line, Pos: len(res)}})
+		}
+	}
+
+	add(FieldTitle, 0, d.Title)
+	add(FieldStatus, 0, d.Status)
+	add(FieldDir, 0, d.Dir)
+
+	for _, tag := range d.Tags {
+		add(FieldTag, 0, tag)
+	}
+
+	for i, line := range d.Lines {
+		add(FieldBody, i+1, line)
+	}
+
+	return res
+}
+
+// Terms splits the text into the terms that are indexed.
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,114 @@
+package index
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// Version is the version of the encoded index. Caches of another version are
+// discarded and built again.
+const Version = 1
+
+// ErrVersion is returned when decoding an index of another version.
+var ErrVersion = errors.New("unsupported index version")
+
+// Occurrence represents a single occurrence of a term within a document.
+type Occurrence struct {
+	Field string
+	// Line is the line of the document the term was found on, starting at 1.
+	// Terms of the metadata fields have no line.
+	Line int
+	// Pos is the position of the term within the terms of the document,
+	// which is used to match phrases.
+	Pos int
+}
+
+// Index represents an inverted index over a set of documents.
+type Index struct {
+	Version int
+	// Docs holds the indexed documents, keyed by path.
+	Docs map[string]*Document
+	// Postings holds the occurrences of each term, keyed by term and then by
+	// the path of the document.
+	Postings map[string]map[string][]Occurrence
+	// Lengths holds the number of terms of each document, keyed by path.
+	Lengths map[string]int
+}
+
+// New produces an empty index.
+func New() *Index {
+	return &Index{
+		Version:  Version,
+		Docs:     map[string]*Document{},
+		Postings: map[string]map[string][]Occurrence{},
+		Lengths:  map[string]int{},
+	}
+}
+
+// Add indexes the document, replacing any document with the same path.
+func (i *Index) Add(doc Document) {
+	i.Remove(doc.Path)
+
+	tokens := doc.tokens()
+
+	for _, t := range tokens {
+		docs, ok := i.Postings[t.term]
+		if !ok {
+			docs = map[string][]Occurrence{}
+			i.Postings[t.term] = docs
+		}
+
+		docs[doc.Path] = append(docs[doc.Path], t.occ)
+	}
+
+	i.Docs[doc.Path] = &doc
+	i.Lengths[doc.Path] = len(tokens)
+}
+
+// Remove drops the document at the given path from the index.
+func (i *Index) Remove(path string) {
+	doc, ok := i.Docs[path]
+	if !ok {
+		return
+	}
+
+	for _, t := range doc.tokens() {
+		docs := i.Postings[t.term]
+		delete(docs, path)
+
+		if len(docs) == 0 {
+			delete(i.Postings, t.term)
+		}
+	}
+
+	delete(i.Docs, path)
+	delete(i.Lengths, path)
+}
+
+// Encode produces the binary form of the index that is written to the cache.
+func (i *Index) Encode() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	if err := gob.NewEncoder(buffer).Encode(i); err != nil {
+		return nil, fmt.Errorf("encode index: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Decode reads an index from its binary form.
+func Decode(data []byte) (*Index, error) {
+	idx := New()
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(idx); err != nil {
+		return nil, fmt.Errorf("decode index: %w", err)
+	}
+
+	if idx.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, idx.Version)
+	}
+
+	return idx, nil
+}
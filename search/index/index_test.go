@@ -0,0 +1,232 @@
+package index_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docula-io/docula/search/index"
+)
+
+var documents = []index.Document{
+	index.NewDocument("platform", "docs/adr/0001-use-postgresql.md", []byte(`# Use PostgreSQL
+
+Date: 2022-01-10
+Tags: db, storage
+
+## Status
+
+Accepted
+
+## Context
+
+We need a relational database for event sourcing.
+`)),
+	index.NewDocument("platform", "docs/adr/0002-use-kafka.md", []byte(`# Use Kafka
+
+## Status
+
+Proposed
+
+## Context
+
+Event sourcing needs a durable log. The database stays for queries.
+`)),
+	index.NewDocument("platform", "docs/adr/README.md", []byte("# Platform decisions\n\nSourcing of events is described below.\n")),
+	index.NewDocument("platform", "docs/adr/0003-use-cdn.md", []byte(`# Use a CDN
+
+## Status
+
+Accepted
+
+## Context
+
+Assets are served from https://example.com/assets.
+
+ADR-0012: cache assets at the edge.
+`)),
+}
+
+func build() *index.Index {
+	idx := index.New()
+
+	for _, doc := range documents {
+		idx.Add(doc)
+	}
+
+	return idx
+}
+
+func paths(results []index.Result) []string {
+	var res []string
+
+	for _, r := range results {
+		res = append(res, r.Document.Path)
+	}
+
+	return res
+}
+
+func TestNewDocument(t *testing.T) {
+	assert.Equal(t, "Use PostgreSQL", documents[0].Title)
+	assert.Equal(t, "Accepted", documents[0].Status)
+	assert.Equal(t, []string{"db", "storage"}, documents[0].Tags)
+	assert.Equal(t, "Platform decisions", documents[2].Title)
+	assert.Empty(t, documents[2].Status)
+}
+
+func TestSearch(t *testing.T) {
+	type result struct {
+		path    string
+		line    int
+		snippet string
+	}
+
+	testCases := []struct {
+		query string
+		wants []result
+		err   error
+	}{
+		{
+			query: "postgresql",
+			wants: []result{
+				{path: "docs/adr/0001-use-postgresql.md", line: 1, snippet: "# Use PostgreSQL"},
+			},
+		},
+		{
+			query: `"event sourcing"`,
+			wants: []result{
+				{path: "docs/adr/0002-use-kafka.md", line: 9, snippet: "Event sourcing needs a durable log. The database stays for queries."},
+				{path: "docs/adr/0001-use-postgresql.md", line: 12, snippet: "We need a relational database for event sourcing."},
+			},
+		},
+		{
+			query: "database log",
+			wants: []result{
+				{path: "docs/adr/0002-use-kafka.md", line: 9, snippet: "Event sourcing needs a durable log. The database stays for queries."},
+			},
+		},
+		{
+			query: "status:proposed",
+			wants: []result{
+				{path: "docs/adr/0002-use-kafka.md"},
+			},
+		},
+		{
+			query: `title:"use kafka"`,
+			wants: []result{
+				{path: "docs/adr/0002-use-kafka.md"},
+			},
+		},
+		{
+			query: `title:"kafka use"`,
+		},
+		{
+			query: "tag:db sourcing",
+			wants: []result{
+				{path: "docs/adr/0001-use-postgresql.md", line: 12, snippet: "We need a relational database for event sourcing."},
+			},
+		},
+		{
+			query: "https://example.com",
+			wants: []result{
+				{path: "docs/adr/0003-use-cdn.md", line: 9, snippet: "Assets are served from https://example.com/assets."},
+			},
+		},
+		{
+			query: "ADR-0012: cache",
+			wants: []result{
+				{path: "docs/adr/0003-use-cdn.md", line: 11, snippet: "ADR-0012: cache assets at the edge."},
+			},
+		},
+		{
+			query: ` "" -- `,
+			err:   index.ErrEmptyQuery,
+		},
+	}
+
+	idx := build()
+
+	for _, tt := range testCases {
+		tt := tt
+
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := index.ParseQuery(tt.query)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var res []result
+
+			for _, r := range idx.Search(q) {
+				res = append(res, result{path: r.Document.Path, line: r.Line, snippet: r.Snippet})
+			}
+
+			assert.Equal(t, tt.wants, res)
+		})
+	}
+}
+
+func TestSearchRanksTitlesFirst(t *testing.T) {
+	q, err := index.ParseQuery("kafka")
+	assert.NoError(t, err)
+
+	idx := build()
+	idx.Add(index.NewDocument("platform", "docs/adr/0003-use-nats.md", []byte(
+		"# Use NATS\n\n## Status\n\nProposed\n\n## Context\n\nKafka is heavy.\n",
+	)))
+
+	assert.Equal(t, []string{"docs/adr/0002-use-kafka.md", "docs/adr/0003-use-nats.md"}, paths(idx.Search(q)))
+}
+
+func TestSnippet(t *testing.T) {
+	line := strings.Repeat("lorem ipsum ", 20) + "needle" + strings.Repeat(" dolor sit", 20)
+
+	idx := index.New()
+	idx.Add(index.NewDocument("platform", "docs/notes.md", []byte(line)))
+
+	q, err := index.ParseQuery("needle")
+	assert.NoError(t, err)
+
+	res := idx.Search(q)
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Snippet, "needle")
+	assert.True(t, strings.HasPrefix(res[0].Snippet, "…"))
+	assert.True(t, strings.HasSuffix(res[0].Snippet, "…"))
+}
+
+func TestUpdate(t *testing.T) {
+	idx := build()
+
+	idx.Add(index.NewDocument("platform", "docs/adr/0002-use-kafka.md", []byte("# Use Pulsar\n")))
+	idx.Remove("docs/adr/README.md")
+
+	kafka, err := index.ParseQuery("kafka")
+	assert.NoError(t, err)
+	assert.Empty(t, idx.Search(kafka))
+
+	pulsar, err := index.ParseQuery("pulsar")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/adr/0002-use-kafka.md"}, paths(idx.Search(pulsar)))
+
+	data, err := idx.Encode()
+	assert.NoError(t, err)
+
+	decoded, err := index.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, idx, decoded)
+
+	old := index.New()
+	old.Version = index.Version + 1
+
+	data, err = old.Encode()
+	assert.NoError(t, err)
+
+	_, err = index.Decode(data)
+	assert.ErrorIs(t, err, index.ErrVersion)
+}
@@ -0,0 +1,262 @@
+package index
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// The parameters of the BM25 ranking function.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// snippetWidth is the number of characters kept around the match of a
+// snippet.
+const snippetWidth = 120
+
+var (
+	// ErrEmptyQuery is returned when a query has no terms to search for.
+	ErrEmptyQuery = errors.New("empty query")
+)
+
+// weights boosts matches within the metadata of a document over those in
+// its body.
+var weights = map[string]float64{
+	FieldTitle:  3,
+	FieldStatus: 2,
+	FieldTag:    2,
+	FieldDir:    1,
+	FieldBody:   1,
+}
+
+// Clause represents a single term or phrase of a query, which is optionally
+// scoped to a field.
+type Clause struct {
+	Field string
+	Terms []string
+}
+
+// Query represents a parsed search query. A document matches the query if
+// it matches every clause.
+type Query []Clause
+
+// ParseQuery parses the search terms. Double quotes group terms into a
+// phrase, and a field name followed by a colon scopes the next term or
+// phrase to that field, as in title:"event sourcing". Any other word followed
+// by a colon, as in a url, is searched for as text.
+func ParseQuery(input string) (Query, error) {
+	var q Query
+
+	rest := strings.TrimSpace(input)
+
+	for rest != "" {
+		var field string
+
+		if end := strings.IndexAny(rest, ": \""); end > 0 && rest[end] == ':' {
+			if _, ok := weights[strings.ToLower(rest[:end])]; ok {
+				field = strings.ToLower(rest[:end])
+				rest = rest[end+1:]
+			}
+		}
+
+		var text string
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				end = len(rest) - 1
+			}
+
+			text, rest = rest[1:end+1], rest[min(end+2, len(rest)):]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			text, rest = rest[:end], rest[end:]
+		}
+
+		if terms := Terms(text); len(terms) > 0 {
+			q = append(q, Clause{Field: field, Terms: terms})
+		}
+
+		rest = strings.TrimSpace(rest)
+	}
+
+	if len(q) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	return q, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// Result represents a document that matched a query.
+type Result struct {
+	Document *Document
+	Score    float64
+	// Line is the first line of the body that matched, starting at 1. It is
+	// zero if only the metadata of the document matched.
+	Line    int
+	Snippet string
+}
+
+// Search returns the documents that match the query, ranked by relevance.
+func (i *Index) Search(q Query) []Result {
+	scores := map[string]float64{}
+	lines := map[string]int{}
+
+	var avgLength float64
+
+	for _, l := range i.Lengths {
+		avgLength += float64(l)
+	}
+
+	if len(i.Lengths) > 0 {
+		avgLength /= float64(len(i.Lengths))
+	}
+
+	for n, clause := range q {
+		matches := i.match(clause)
+
+		idf := math.Log(1 + (float64(len(i.Docs))-float64(len(matches))+0.5)/(float64(len(matches))+0.5))
+
+		next := map[string]float64{}
+
+		for path, occs := range matches {
+			if _, ok := scores[path]; n > 0 && !ok {
+				continue
+			}
+
+			var tf float64
+
+			for _, occ := range occs {
+				tf += weights[occ.Field]
+
+				if occ.Field == FieldBody && (lines[path] == 0 || occ.Line < lines[path]) {
+					lines[path] = occ.Line
+				}
+			}
+
+			norm := 1 - bm25B + bm25B*float64(i.Lengths[path])/math.Max(avgLength, 1)
+
+			next[path] = scores[path] + idf*tf*(bm25K1+1)/(tf+bm25K1*norm)
+		}
+
+		scores = next
+	}
+
+	results := make([]Result, 0, len(scores))
+
+	for path, score := range scores {
+		doc := i.Docs[path]
+
+		res := Result{Document: doc, Score: score, Line: lines[path]}
+		if res.Line > 0 {
+			res.Snippet = snippet(doc.Lines[res.Line-1], q)
+		}
+
+		results = append(results, res)
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+
+		return results[a].Document.Path < results[b].Document.Path
+	})
+
+	return results
+}
+
+// match returns the occurrences of the clause within each document that
+// contains it. The occurrences of a phrase are those of its first term.
+func (i *Index) match(c Clause) map[string][]Occurrence {
+	res := map[string][]Occurrence{}
+
+	for path, occs := range i.Postings[c.Terms[0]] {
+	next:
+		for _, occ := range occs {
+			if c.Field != "" && occ.Field != c.Field {
+				continue
+			}
+
+			for n, term := range c.Terms[1:] {
+				if !i.has(term, path, occ.Field, occ.Pos+n+1) {
+					continue next
+				}
+			}
+
+			res[path] = append(res[path], occ)
+		}
+	}
+
+	return res
+}
+
+func (i *Index) has(term string, path string, field string, pos int) bool {
+	for _, occ := range i.Postings[term][path] {
+		if occ.Pos == pos && occ.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// snippet trims the line to the text around the first term of the query that
+// it contains.
+func snippet(line string, q Query) string {
+	line = strings.TrimSpace(line)
+
+	if utf8.RuneCountInString(line) <= snippetWidth {
+		return line
+	}
+
+	lower := strings.ToLower(line)
+	at := 0
+
+	for _, c := range q {
+		if i := strings.Index(lower, c.Terms[0]); i >= 0 {
+			at = utf8.RuneCountInString(lower[:i])
+			break
+		}
+	}
+
+	runes := []rune(line)
+
+	start := at - snippetWidth/3
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + snippetWidth
+	if end > len(runes) {
+		end, start = len(runes), len(runes)-snippetWidth
+	}
+
+	res := strings.TrimSpace(string(runes[start:end]))
+
+	if start > 0 {
+		res = "…" + res
+	}
+
+	if end < len(runes) {
+		res += "…"
+	}
+
+	return res
+}
@@ -0,0 +1,22 @@
+package search
+
+// The location of the search index, relative to the state dir.
+const (
+	CacheDir  = ".docula-cache"
+	IndexName = "search.idx"
+)
+
+// DefaultLimit is the number of results shown when no limit is given.
+const DefaultLimit = 10
+
+// Options represents the input of the search command.
+type Options struct {
+	// Terms holds the search query, which may contain quoted phrases and
+	// field scoped terms such as title:postgres.
+	Terms string
+	// Dir limits the results to a single directory, by name or path.
+	Dir string
+	// Limit is the maximum number of results shown, where zero shows all
+	// of them.
+	Limit int
+}
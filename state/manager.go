@@ -101,6 +101,10 @@ func (m *Manager) Load() (State, error) {
 		return State{}, fmt.Errorf("unmarshal docula state file: %w", err)
 	}
 
+	if err = res.Validate(); err != nil {
+		return State{}, fmt.Errorf("invalid docula state file: %w", err)
+	}
+
 	return res, nil
 }
 
@@ -174,6 +178,10 @@ func (m *Manager) NormalizePath(path string) (string, error) {
 		case ".":
 			continue
 		case "..":
+			if len(resolved) == 0 {
+				return "", ErrInvalidPath
+			}
+
 			resolved = resolved[:len(resolved)-1]
 		default:
 			resolved = append(resolved, x)
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/lint/rule"
 	"github.com/docula-io/docula/state"
 )
 
@@ -284,6 +285,38 @@ func TestManagerLoad(t *testing.T) {
 				err: os.ErrInvalid,
 			},
 		},
+		{
+			name: "unknown index type",
+			setup: func(ctrl *gomock.Controller) state.FileSystem {
+				fs := state.NewmockFileSystem(ctrl)
+				fs.EXPECT().Getwd().Return("/foo/bar/boo", nil)
+				fs.EXPECT().Stat("/foo/bar/boo/.docula").Return(nil, nil)
+				fs.EXPECT().ReadFile("/foo/bar/boo/.docula").Return(
+					[]byte("adr:\n  dirs:\n    - path: foo\n      index: uuid\n"), nil,
+				)
+
+				return fs
+			},
+			wants: want{
+				err: adr.ErrUnknownIndex,
+			},
+		},
+		{
+			name: "unknown lint rule",
+			setup: func(ctrl *gomock.Controller) state.FileSystem {
+				fs := state.NewmockFileSystem(ctrl)
+				fs.EXPECT().Getwd().Return("/foo/bar/boo", nil)
+				fs.EXPECT().Stat("/foo/bar/boo/.docula").Return(nil, nil)
+				fs.EXPECT().ReadFile("/foo/bar/boo/.docula").Return(
+					[]byte("adr:\n  dirs:\n    - path: foo\nlint:\n  rules:\n    nope: error\n"), nil,
+				)
+
+				return fs
+			},
+			wants: want{
+				err: rule.ErrUnknownRule,
+			},
+		},
 		{
 			name: "bad yaml",
 			setup: func(ctrl *gomock.Controller) state.FileSystem {
@@ -354,6 +387,14 @@ func TestManagerNormalizePath(t *testing.T) {
 				err: true,
 			},
 		},
+		{
+			name:  "parents beyond the root",
+			input: "../../../../../../x",
+			setup: setupFs("/home/bar"),
+			wants: want{
+				err: true,
+			},
+		},
 		{
 			name:  "current dir",
 			input: "./",
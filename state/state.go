@@ -1,11 +1,30 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/docula-io/docula/adr"
+	"github.com/docula-io/docula/lint/rule"
 )
 
 // State represents the docula state file which is associated with a project.
 // This file is used to store the state of the docula changes.
 type State struct {
 	ADR adr.State `yaml:"adr"`
+
+	// Lint configures the severity of the rules of the lint command.
+	Lint rule.Config `yaml:"lint,omitempty"`
+}
+
+// Validate checks that the state is usable by each of the docula commands.
+func (s State) Validate() error {
+	if err := s.ADR.Validate(); err != nil {
+		return fmt.Errorf("adr state: %w", err)
+	}
+
+	if err := s.Lint.Validate(); err != nil {
+		return fmt.Errorf("lint config: %w", err)
+	}
+
+	return nil
 }